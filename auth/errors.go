@@ -0,0 +1,21 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import "errors"
+
+var (
+	ErrNestedWrapper   = errors.New("nested wrapper auth")
+	ErrSponsorMismatch = errors.New("sponsor mismatch")
+
+	ErrUnknownKeyType         = errors.New("unknown key type")
+	ErrInvalidSigner          = errors.New("invalid signer")
+	ErrUnknownSigner          = errors.New("unknown signer")
+	ErrTooManySigners         = errors.New("too many signers")
+	ErrUnsortedSigners        = errors.New("signers not sorted or contain duplicates")
+	ErrInvalidThreshold       = errors.New("invalid threshold")
+	ErrInvalidSignerIndex     = errors.New("invalid signer index")
+	ErrUnsortedSignatures     = errors.New("signatures not sorted or contain duplicates")
+	ErrInsufficientSignatures = errors.New("insufficient signatures")
+)
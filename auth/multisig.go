@@ -0,0 +1,482 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+var (
+	_ chain.Auth              = (*Multisig)(nil)
+	_ chain.AuthFactory       = (*MultisigFactory)(nil)
+	_ vm.AuthEngine           = (*MultisigEngine)(nil)
+	_ chain.AuthBatchVerifier = (*MultisigBatch)(nil)
+)
+
+// Key types that can be members of a [Multisig].
+const (
+	ED25519Key   uint8 = 0
+	SECP256R1Key uint8 = 1
+)
+
+const (
+	// MaxMultisigSigners is the maximum number of signers in a [Multisig].
+	MaxMultisigSigners = 32
+
+	// MultisigBaseComputeUnits is charged for deriving the [Multisig] address.
+	MultisigBaseComputeUnits = 1
+
+	// Every signer is charged (not just those that sign) because the
+	// entire signer set must be hashed to derive the address.
+	ED25519SignerComputeUnits   = 5
+	SECP256R1SignerComputeUnits = 10 // can't be batched like ed25519
+)
+
+// MultisigSigner is a member of a [Multisig].
+type MultisigSigner struct {
+	KeyType   uint8  `json:"keyType"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+func (s MultisigSigner) size() int {
+	return consts.ByteLen + len(s.PublicKey)
+}
+
+func (s MultisigSigner) bytes() []byte {
+	b := make([]byte, 0, s.size())
+	b = append(b, s.KeyType)
+	return append(b, s.PublicKey...)
+}
+
+func (s MultisigSigner) verify(msg []byte, sig []byte) bool {
+	switch s.KeyType {
+	case ED25519Key:
+		return ed25519.Verify(msg, ed25519.PublicKey(s.PublicKey), ed25519.Signature(sig))
+	case SECP256R1Key:
+		return secp256r1.Verify(msg, secp256r1.PublicKey(s.PublicKey), secp256r1.Signature(sig))
+	default:
+		return false
+	}
+}
+
+func keyLens(keyType uint8) (int, int, uint64, error) {
+	switch keyType {
+	case ED25519Key:
+		return ed25519.PublicKeyLen, ed25519.SignatureLen, ED25519SignerComputeUnits, nil
+	case SECP256R1Key:
+		return secp256r1.PublicKeyLen, secp256r1.SignatureLen, SECP256R1SignerComputeUnits, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: %d", ErrUnknownKeyType, keyType)
+	}
+}
+
+// MultisigSignature is a signature by the signer at [Index] in the
+// [Multisig] signer set.
+type MultisigSignature struct {
+	Index     uint8  `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// Multisig is an m-of-n [chain.Auth]. Its address is derived from the sorted
+// signer set and the threshold, so the same set of signers can control many
+// accounts with different thresholds.
+type Multisig struct {
+	Threshold  uint8               `json:"threshold"`
+	Signers    []MultisigSigner    `json:"signers"`
+	Signatures []MultisigSignature `json:"signatures"`
+
+	typeID uint8
+	addr   codec.Address
+}
+
+// NewMultisig returns a [Multisig] (without signatures) for [signers] that
+// requires [threshold] signatures. [signers] are sorted.
+func NewMultisig(typeID uint8, threshold uint8, signers []MultisigSigner) (*Multisig, error) {
+	sorted := make([]MultisigSigner, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].bytes(), sorted[j].bytes()) < 0
+	})
+	if err := verifySigners(threshold, sorted); err != nil {
+		return nil, err
+	}
+	return &Multisig{Threshold: threshold, Signers: sorted, typeID: typeID}, nil
+}
+
+func verifySigners(threshold uint8, signers []MultisigSigner) error {
+	if len(signers) > MaxMultisigSigners {
+		return ErrTooManySigners
+	}
+	if threshold == 0 || int(threshold) > len(signers) {
+		return ErrInvalidThreshold
+	}
+	for i, signer := range signers {
+		keyLen, _, _, err := keyLens(signer.KeyType)
+		if err != nil {
+			return err
+		}
+		if len(signer.PublicKey) != keyLen {
+			return fmt.Errorf("%w: public key has length %d", ErrInvalidSigner, len(signer.PublicKey))
+		}
+		if i > 0 && bytes.Compare(signers[i-1].bytes(), signer.bytes()) >= 0 {
+			return ErrUnsortedSigners
+		}
+	}
+	return nil
+}
+
+func (m *Multisig) address() codec.Address {
+	if m.addr == codec.EmptyAddress {
+		m.addr = NewMultisigAddress(m.typeID, m.Threshold, m.Signers)
+	}
+	return m.addr
+}
+
+// NewMultisigAddress derives the address of a [Multisig] from its sorted
+// signer set and threshold.
+func NewMultisigAddress(typeID uint8, threshold uint8, signers []MultisigSigner) codec.Address {
+	b := []byte{threshold}
+	for _, signer := range signers {
+		b = append(b, signer.bytes()...)
+	}
+	return codec.CreateAddress(typeID, utils.ToID(b))
+}
+
+// Index returns the position of the signer with [publicKey] in the sorted
+// signer set.
+func (m *Multisig) Index(keyType uint8, publicKey []byte) (uint8, error) {
+	for i, signer := range m.Signers {
+		if signer.KeyType == keyType && bytes.Equal(signer.PublicKey, publicKey) {
+			return uint8(i), nil
+		}
+	}
+	return 0, ErrUnknownSigner
+}
+
+// SignED25519 creates a partial signature over [msg] that can be
+// added to a [MultisigFactory] by another party.
+func (m *Multisig) SignED25519(msg []byte, priv ed25519.PrivateKey) (MultisigSignature, error) {
+	pub := priv.PublicKey()
+	index, err := m.Index(ED25519Key, pub[:])
+	if err != nil {
+		return MultisigSignature{}, err
+	}
+	sig := ed25519.Sign(msg, priv)
+	return MultisigSignature{Index: index, Signature: sig[:]}, nil
+}
+
+// SignSECP256R1 creates a partial signature over [msg] that can be
+// added to a [MultisigFactory] by another party.
+func (m *Multisig) SignSECP256R1(msg []byte, priv secp256r1.PrivateKey) (MultisigSignature, error) {
+	pub := priv.PublicKey()
+	index, err := m.Index(SECP256R1Key, pub[:])
+	if err != nil {
+		return MultisigSignature{}, err
+	}
+	sig, err := secp256r1.Sign(msg, priv)
+	if err != nil {
+		return MultisigSignature{}, err
+	}
+	return MultisigSignature{Index: index, Signature: sig[:]}, nil
+}
+
+func (m *Multisig) GetTypeID() uint8 {
+	return m.typeID
+}
+
+func (m *Multisig) ComputeUnits(chain.Rules) uint64 {
+	units := uint64(MultisigBaseComputeUnits)
+	for _, signer := range m.Signers {
+		_, _, signerUnits, _ := keyLens(signer.KeyType)
+		units += signerUnits
+	}
+	return units
+}
+
+func (*Multisig) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (m *Multisig) Verify(_ context.Context, msg []byte) error {
+	for _, sig := range m.Signatures {
+		if !m.Signers[sig.Index].verify(msg, sig.Signature) {
+			return crypto.ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func (m *Multisig) Actor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Sponsor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Size() int {
+	size := consts.ByteLen + consts.ByteLen
+	for _, signer := range m.Signers {
+		size += signer.size()
+	}
+	for _, sig := range m.Signatures {
+		size += consts.ByteLen + len(sig.Signature)
+	}
+	return size
+}
+
+// Signatures are not length-prefixed because exactly [Threshold] must be
+// provided.
+func (m *Multisig) Marshal(p *codec.Packer) {
+	p.PackByte(m.Threshold)
+	p.PackByte(uint8(len(m.Signers)))
+	for _, signer := range m.Signers {
+		p.PackByte(signer.KeyType)
+		p.PackFixedBytes(signer.PublicKey)
+	}
+	for _, sig := range m.Signatures {
+		p.PackByte(sig.Index)
+		p.PackFixedBytes(sig.Signature)
+	}
+}
+
+// NewMultisigParser returns the function that should be registered for
+// [typeID] to parse a [Multisig].
+func NewMultisigParser(typeID uint8) func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+	return func(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+		m := &Multisig{typeID: typeID}
+		m.Threshold = p.UnpackByte()
+		signers := p.UnpackByte()
+		if signers > MaxMultisigSigners {
+			return nil, ErrTooManySigners
+		}
+		m.Signers = make([]MultisigSigner, signers)
+		for i := range m.Signers {
+			keyType := p.UnpackByte()
+			keyLen, _, _, err := keyLens(keyType)
+			if err != nil {
+				return nil, err
+			}
+			publicKey := make([]byte, keyLen)
+			p.UnpackFixedBytes(keyLen, &publicKey)
+			m.Signers[i] = MultisigSigner{keyType, publicKey}
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		if err := verifySigners(m.Threshold, m.Signers); err != nil {
+			return nil, err
+		}
+		m.Signatures = make([]MultisigSignature, m.Threshold)
+		for i := range m.Signatures {
+			index := p.UnpackByte()
+			if int(index) >= len(m.Signers) {
+				return nil, fmt.Errorf("%w: %d", ErrInvalidSignerIndex, index)
+			}
+			// Requiring increasing indices prevents the same signer from
+			// being counted twice.
+			if i > 0 && index <= m.Signatures[i-1].Index {
+				return nil, ErrUnsortedSignatures
+			}
+			_, sigLen, _, _ := keyLens(m.Signers[index].KeyType)
+			sig := make([]byte, sigLen)
+			p.UnpackFixedBytes(sigLen, &sig)
+			m.Signatures[i] = MultisigSignature{index, sig}
+		}
+		return m, p.Err()
+	}
+}
+
+// MultisigFactory assembles a [Multisig] from local keys and partial
+// signatures collected from other parties.
+//
+// Because the transaction digest can be computed before signing, signers can
+// create partial signatures offline (using [Multisig.SignED25519] or
+// [Multisig.SignSECP256R1]) and send them to whoever submits the transaction.
+type MultisigFactory struct {
+	account *Multisig
+
+	ed25519Keys   []ed25519.PrivateKey
+	secp256r1Keys []secp256r1.PrivateKey
+	partials      []MultisigSignature
+}
+
+func NewMultisigFactory(typeID uint8, threshold uint8, signers []MultisigSigner) (*MultisigFactory, error) {
+	account, err := NewMultisig(typeID, threshold, signers)
+	if err != nil {
+		return nil, err
+	}
+	return &MultisigFactory{account: account}, nil
+}
+
+// Account returns the unsigned [Multisig] controlled by this factory.
+func (f *MultisigFactory) Account() *Multisig {
+	return f.account
+}
+
+func (f *MultisigFactory) Address() codec.Address {
+	return f.account.address()
+}
+
+// AddED25519 adds a local key that will sign in [Sign].
+func (f *MultisigFactory) AddED25519(priv ed25519.PrivateKey) error {
+	pub := priv.PublicKey()
+	if _, err := f.account.Index(ED25519Key, pub[:]); err != nil {
+		return err
+	}
+	f.ed25519Keys = append(f.ed25519Keys, priv)
+	return nil
+}
+
+// AddSECP256R1 adds a local key that will sign in [Sign].
+func (f *MultisigFactory) AddSECP256R1(priv secp256r1.PrivateKey) error {
+	pub := priv.PublicKey()
+	if _, err := f.account.Index(SECP256R1Key, pub[:]); err != nil {
+		return err
+	}
+	f.secp256r1Keys = append(f.secp256r1Keys, priv)
+	return nil
+}
+
+// AddSignature adds a partial signature created by another party.
+//
+// Partial signatures are checked against the message passed to [Sign].
+func (f *MultisigFactory) AddSignature(sig MultisigSignature) error {
+	if int(sig.Index) >= len(f.account.Signers) {
+		return fmt.Errorf("%w: %d", ErrInvalidSignerIndex, sig.Index)
+	}
+	_, sigLen, _, _ := keyLens(f.account.Signers[sig.Index].KeyType)
+	if len(sig.Signature) != sigLen {
+		return fmt.Errorf("%w: signature has length %d", crypto.ErrInvalidSignature, len(sig.Signature))
+	}
+	f.partials = append(f.partials, sig)
+	return nil
+}
+
+func (f *MultisigFactory) Sign(msg []byte) (chain.Auth, error) {
+	sigs := map[uint8]MultisigSignature{}
+	for _, sig := range f.partials {
+		if !f.account.Signers[sig.Index].verify(msg, sig.Signature) {
+			return nil, fmt.Errorf("%w: signer %d", crypto.ErrInvalidSignature, sig.Index)
+		}
+		sigs[sig.Index] = sig
+	}
+	for _, priv := range f.ed25519Keys {
+		sig, err := f.account.SignED25519(msg, priv)
+		if err != nil {
+			return nil, err
+		}
+		sigs[sig.Index] = sig
+	}
+	for _, priv := range f.secp256r1Keys {
+		sig, err := f.account.SignSECP256R1(msg, priv)
+		if err != nil {
+			return nil, err
+		}
+		sigs[sig.Index] = sig
+	}
+	if len(sigs) < int(f.account.Threshold) {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientSignatures, len(sigs), f.account.Threshold)
+	}
+	signatures := make([]MultisigSignature, 0, len(sigs))
+	for _, sig := range sigs {
+		signatures = append(signatures, sig)
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		return signatures[i].Index < signatures[j].Index
+	})
+	return &Multisig{
+		Threshold:  f.account.Threshold,
+		Signers:    f.account.Signers,
+		Signatures: signatures[:f.account.Threshold],
+		typeID:     f.account.typeID,
+		addr:       f.account.address(),
+	}, nil
+}
+
+// MaxUnits assumes the signers with the largest signatures sign.
+func (f *MultisigFactory) MaxUnits() (uint64, uint64) {
+	bandwidth := f.account.Size()
+	sigLens := make([]int, len(f.account.Signers))
+	for i, signer := range f.account.Signers {
+		_, sigLens[i], _, _ = keyLens(signer.KeyType)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sigLens)))
+	for _, sigLen := range sigLens[:f.account.Threshold] {
+		bandwidth += consts.ByteLen + sigLen
+	}
+	return uint64(bandwidth), f.account.ComputeUnits(nil)
+}
+
+// MultisigEngine batch verifies the ed25519 signatures of [Multisig]. Other
+// signatures are verified individually.
+type MultisigEngine struct{}
+
+func NewMultisigEngine() *MultisigEngine {
+	return &MultisigEngine{}
+}
+
+func (*MultisigEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	batchSize := math.Max(count/cores, ed25519.MinBatchSize)
+	return &MultisigBatch{batchSize: batchSize}
+}
+
+func (*MultisigEngine) Cache(chain.Auth) {}
+
+type MultisigBatch struct {
+	batchSize int
+
+	counter int
+	batch   *ed25519.Batch
+}
+
+func (b *MultisigBatch) Add(msg []byte, rauth chain.Auth) func() error {
+	auth := rauth.(*Multisig)
+	var fs []func() error
+	for _, sig := range auth.Signatures {
+		signer, signature := auth.Signers[sig.Index], sig.Signature
+		if signer.KeyType != ED25519Key {
+			fs = append(fs, func() error {
+				if !signer.verify(msg, signature) {
+					return crypto.ErrInvalidSignature
+				}
+				return nil
+			})
+			continue
+		}
+		if b.batch == nil {
+			b.batch = ed25519.NewBatch(b.batchSize)
+		}
+		b.batch.Add(msg, ed25519.PublicKey(signer.PublicKey), ed25519.Signature(signature))
+		b.counter++
+		if b.counter == b.batchSize {
+			fs = append(fs, b.batch.VerifyAsync())
+			b.batch = nil
+			b.counter = 0
+		}
+	}
+	return combine(fs...)
+}
+
+func (b *MultisigBatch) Done() []func() error {
+	if b.batch == nil {
+		return nil
+	}
+	return []func() error{b.batch.VerifyAsync()}
+}
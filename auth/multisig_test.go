@@ -0,0 +1,158 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/stretchr/testify/require"
+)
+
+const testMultisigID uint8 = 2
+
+type testMultisigKeys struct {
+	ed25519   []ed25519.PrivateKey
+	secp256r1 []secp256r1.PrivateKey
+	signers   []MultisigSigner
+}
+
+func newTestMultisigKeys(t *testing.T, ed25519Count int, secp256r1Count int) *testMultisigKeys {
+	require := require.New(t)
+	keys := &testMultisigKeys{}
+	for i := 0; i < ed25519Count; i++ {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		pub := priv.PublicKey()
+		keys.ed25519 = append(keys.ed25519, priv)
+		keys.signers = append(keys.signers, MultisigSigner{ED25519Key, pub[:]})
+	}
+	for i := 0; i < secp256r1Count; i++ {
+		priv, err := secp256r1.GeneratePrivateKey()
+		require.NoError(err)
+		pub := priv.PublicKey()
+		keys.secp256r1 = append(keys.secp256r1, priv)
+		keys.signers = append(keys.signers, MultisigSigner{SECP256R1Key, pub[:]})
+	}
+	return keys
+}
+
+func TestMultisigAddress(t *testing.T) {
+	require := require.New(t)
+	keys := newTestMultisigKeys(t, 2, 1)
+
+	m1, err := NewMultisig(testMultisigID, 2, keys.signers)
+	require.NoError(err)
+	reversed := []MultisigSigner{keys.signers[2], keys.signers[1], keys.signers[0]}
+	m2, err := NewMultisig(testMultisigID, 2, reversed)
+	require.NoError(err)
+	require.Equal(m1.Actor(), m2.Actor())
+	require.Equal(m1.Actor(), m1.Sponsor())
+	require.Equal(testMultisigID, m1.Actor()[0])
+
+	m3, err := NewMultisig(testMultisigID, 3, keys.signers)
+	require.NoError(err)
+	require.NotEqual(m1.Actor(), m3.Actor())
+
+	_, err = NewMultisig(testMultisigID, 0, keys.signers)
+	require.ErrorIs(err, ErrInvalidThreshold)
+	_, err = NewMultisig(testMultisigID, 4, keys.signers)
+	require.ErrorIs(err, ErrInvalidThreshold)
+	_, err = NewMultisig(testMultisigID, 1, []MultisigSigner{keys.signers[0], keys.signers[0]})
+	require.ErrorIs(err, ErrUnsortedSigners)
+	_, err = NewMultisig(testMultisigID, 1, []MultisigSigner{{KeyType: 5, PublicKey: keys.signers[0].PublicKey}})
+	require.ErrorIs(err, ErrUnknownKeyType)
+}
+
+func TestMultisigFactory(t *testing.T) {
+	require := require.New(t)
+	keys := newTestMultisigKeys(t, 2, 2)
+	factory, err := NewMultisigFactory(testMultisigID, 3, keys.signers)
+	require.NoError(err)
+	msg := []byte("digest")
+
+	// Local keys alone do not meet the threshold
+	require.NoError(factory.AddED25519(keys.ed25519[0]))
+	require.NoError(factory.AddSECP256R1(keys.secp256r1[0]))
+	_, err = factory.Sign(msg)
+	require.ErrorIs(err, ErrInsufficientSignatures)
+
+	// Keys outside of the signer set are rejected
+	other, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	require.ErrorIs(factory.AddED25519(other), ErrUnknownSigner)
+
+	// Add a partial signature created offline
+	partial, err := factory.Account().SignED25519(msg, keys.ed25519[1])
+	require.NoError(err)
+	require.NoError(factory.AddSignature(partial))
+	rauth, err := factory.Sign(msg)
+	require.NoError(err)
+	auth := rauth.(*Multisig)
+	require.Len(auth.Signatures, 3)
+	require.Equal(factory.Address(), auth.Actor())
+	require.Equal(uint64(MultisigBaseComputeUnits+2*ED25519SignerComputeUnits+2*SECP256R1SignerComputeUnits), auth.ComputeUnits(nil))
+	require.NoError(auth.Verify(context.Background(), msg))
+	require.ErrorIs(auth.Verify(context.Background(), []byte("other")), crypto.ErrInvalidSignature)
+
+	bandwidth, compute := factory.MaxUnits()
+	require.LessOrEqual(uint64(auth.Size()), bandwidth)
+	require.Equal(auth.ComputeUnits(nil), compute)
+
+	// Partial signatures over a different message are rejected
+	_, err = factory.Sign([]byte("other"))
+	require.ErrorIs(err, crypto.ErrInvalidSignature)
+}
+
+func TestMultisigMarshal(t *testing.T) {
+	require := require.New(t)
+	keys := newTestMultisigKeys(t, 2, 1)
+	factory, err := NewMultisigFactory(testMultisigID, 2, keys.signers)
+	require.NoError(err)
+	require.NoError(factory.AddED25519(keys.ed25519[0]))
+	require.NoError(factory.AddSECP256R1(keys.secp256r1[0]))
+	msg := []byte("digest")
+	auth, err := factory.Sign(msg)
+	require.NoError(err)
+
+	p := codec.NewWriter(auth.Size(), consts.NetworkSizeLimit)
+	auth.Marshal(p)
+	require.NoError(p.Err())
+	require.Len(p.Bytes(), auth.Size())
+	parsed, err := NewMultisigParser(testMultisigID)(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), nil)
+	require.NoError(err)
+	require.Equal(auth.Actor(), parsed.Actor())
+	require.NoError(parsed.Verify(context.Background(), msg))
+
+	// Duplicate signatures are rejected
+	dup := *(auth.(*Multisig))
+	dup.Signatures = []MultisigSignature{dup.Signatures[0], dup.Signatures[0]}
+	p = codec.NewWriter(dup.Size(), consts.NetworkSizeLimit)
+	dup.Marshal(p)
+	require.NoError(p.Err())
+	_, err = NewMultisigParser(testMultisigID)(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), nil)
+	require.ErrorIs(err, ErrUnsortedSignatures)
+}
+
+func TestMultisigBatch(t *testing.T) {
+	require := require.New(t)
+	keys := newTestMultisigKeys(t, 3, 1)
+	factory, err := NewMultisigFactory(testMultisigID, 3, keys.signers)
+	require.NoError(err)
+	require.NoError(factory.AddED25519(keys.ed25519[0]))
+	require.NoError(factory.AddED25519(keys.ed25519[1]))
+	require.NoError(factory.AddSECP256R1(keys.secp256r1[0]))
+	msg := []byte("digest")
+	auth, err := factory.Sign(msg)
+	require.NoError(err)
+
+	engine := NewMultisigEngine()
+	require.NoError(runBatch(engine.GetBatchVerifier(1, 1), msg, auth))
+	require.Error(runBatch(engine.GetBatchVerifier(1, 1), []byte("other"), auth))
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+var (
+	_ chain.Auth              = (*SponsorWrapper)(nil)
+	_ chain.WrapperAuth       = (*SponsorWrapper)(nil)
+	_ chain.AuthFactory       = (*SponsorWrapperFactory)(nil)
+	_ vm.AuthEngine           = (*SponsorWrapperEngine)(nil)
+	_ chain.AuthBatchVerifier = (*SponsorWrapperBatch)(nil)
+)
+
+// SponsorWrapper allows a [Sponsor] to pay the fees of a transaction
+// authorized by a different [Actor].
+//
+// The [ActorAuth] signs the transaction digest and the address of the
+// [Sponsor] (so that the transaction cannot be replayed with a different fee
+// payer). The [SponsorAuth] signs the transaction digest and the signed
+// [ActorAuth].
+type SponsorWrapper struct {
+	ActorAuth   chain.Auth `json:"actor"`
+	SponsorAuth chain.Auth `json:"sponsor"`
+
+	typeID uint8
+}
+
+// NewSponsorWrapper assembles a [SponsorWrapper] from an [actor] signature over
+// [ActorMessage] and a [sponsor] signature over [SponsorMessage]. This can be
+// used when the [actor] and [sponsor] sign on different machines.
+func NewSponsorWrapper(typeID uint8, actor chain.Auth, sponsor chain.Auth) *SponsorWrapper {
+	return &SponsorWrapper{
+		ActorAuth:   actor,
+		SponsorAuth: sponsor,
+		typeID:      typeID,
+	}
+}
+
+// ActorMessage is the message signed by the [Actor] of a sponsored transaction.
+func ActorMessage(digest []byte, sponsor codec.Address) []byte {
+	msg := make([]byte, 0, len(digest)+codec.AddressLen)
+	msg = append(msg, digest...)
+	return append(msg, sponsor[:]...)
+}
+
+// SponsorMessage is the message signed by the [Sponsor] of a sponsored transaction.
+func SponsorMessage(digest []byte, actor chain.Auth) ([]byte, error) {
+	p := codec.NewWriter(len(digest)+consts.ByteLen+actor.Size(), consts.NetworkSizeLimit)
+	p.PackFixedBytes(digest)
+	p.PackByte(actor.GetTypeID())
+	actor.Marshal(p)
+	return p.Bytes(), p.Err()
+}
+
+func (s *SponsorWrapper) GetTypeID() uint8 {
+	return s.typeID
+}
+
+func (s *SponsorWrapper) ComputeUnits(r chain.Rules) uint64 {
+	return s.ActorAuth.ComputeUnits(r) + s.SponsorAuth.ComputeUnits(r)
+}
+
+// ValidRange is the intersection of the ranges of the wrapped [chain.Auth].
+func (s *SponsorWrapper) ValidRange(r chain.Rules) (int64, int64) {
+	actorStart, actorEnd := s.ActorAuth.ValidRange(r)
+	sponsorStart, sponsorEnd := s.SponsorAuth.ValidRange(r)
+	return intersectRange(actorStart, actorEnd, sponsorStart, sponsorEnd)
+}
+
+func (s *SponsorWrapper) Verify(ctx context.Context, msg []byte) error {
+	if err := s.ActorAuth.Verify(ctx, ActorMessage(msg, s.Sponsor())); err != nil {
+		return err
+	}
+	sponsorMsg, err := SponsorMessage(msg, s.ActorAuth)
+	if err != nil {
+		return err
+	}
+	return s.SponsorAuth.Verify(ctx, sponsorMsg)
+}
+
+func (s *SponsorWrapper) Actor() codec.Address {
+	return s.ActorAuth.Actor()
+}
+
+func (s *SponsorWrapper) Sponsor() codec.Address {
+	return s.SponsorAuth.Sponsor()
+}
+
+func (s *SponsorWrapper) ActorTypeID() uint8 {
+	return s.ActorAuth.GetTypeID()
+}
+
+func (s *SponsorWrapper) SponsorTypeID() uint8 {
+	return s.SponsorAuth.GetTypeID()
+}
+
+func (s *SponsorWrapper) Size() int {
+	return consts.ByteLen + s.ActorAuth.Size() + consts.ByteLen + s.SponsorAuth.Size()
+}
+
+func (s *SponsorWrapper) Marshal(p *codec.Packer) {
+	p.PackByte(s.ActorAuth.GetTypeID())
+	s.ActorAuth.Marshal(p)
+	p.PackByte(s.SponsorAuth.GetTypeID())
+	s.SponsorAuth.Marshal(p)
+}
+
+// NewSponsorWrapperParser returns the function that should be registered for
+// [typeID] in [registry] to parse a [SponsorWrapper]. The wrapped [chain.Auth]
+// are parsed using [registry].
+func NewSponsorWrapperParser(
+	typeID uint8,
+	registry chain.AuthRegistry,
+) func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+	return func(p *codec.Packer, wm *warp.Message) (chain.Auth, error) {
+		actor, err := unmarshalWrapped(p, wm, typeID, registry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal actor", err)
+		}
+		sponsor, err := unmarshalWrapped(p, wm, typeID, registry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal sponsor", err)
+		}
+		return NewSponsorWrapper(typeID, actor, sponsor), p.Err()
+	}
+}
+
+func unmarshalWrapped(
+	p *codec.Packer,
+	wm *warp.Message,
+	wrapperID uint8,
+	registry chain.AuthRegistry,
+) (chain.Auth, error) {
+	authType := p.UnpackByte()
+	if authType == wrapperID {
+		return nil, ErrNestedWrapper
+	}
+	unmarshalAuth, authWarp, ok := (*codec.TypeParser[chain.Auth, *warp.Message, bool])(registry).LookupIndex(authType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d is unknown auth type", chain.ErrInvalidObject, authType)
+	}
+	if authWarp && wm == nil {
+		return nil, fmt.Errorf("%w: auth %d", chain.ErrExpectedWarpMessage, authType)
+	}
+	return unmarshalAuth(p, wm)
+}
+
+// SponsorWrapperFactory signs a transaction on behalf of both the [actor] and
+// the [sponsor] (which must be the fee payer of [sponsorAddr]).
+type SponsorWrapperFactory struct {
+	typeID      uint8
+	actor       chain.AuthFactory
+	sponsor     chain.AuthFactory
+	sponsorAddr codec.Address
+}
+
+func NewSponsorWrapperFactory(
+	typeID uint8,
+	actor chain.AuthFactory,
+	sponsor chain.AuthFactory,
+	sponsorAddr codec.Address,
+) *SponsorWrapperFactory {
+	return &SponsorWrapperFactory{typeID, actor, sponsor, sponsorAddr}
+}
+
+func (s *SponsorWrapperFactory) Sign(msg []byte) (chain.Auth, error) {
+	actor, err := s.actor.Sign(ActorMessage(msg, s.sponsorAddr))
+	if err != nil {
+		return nil, err
+	}
+	sponsorMsg, err := SponsorMessage(msg, actor)
+	if err != nil {
+		return nil, err
+	}
+	sponsor, err := s.sponsor.Sign(sponsorMsg)
+	if err != nil {
+		return nil, err
+	}
+	if sponsor.Sponsor() != s.sponsorAddr {
+		return nil, ErrSponsorMismatch
+	}
+	return NewSponsorWrapper(s.typeID, actor, sponsor), nil
+}
+
+func (s *SponsorWrapperFactory) MaxUnits() (uint64, uint64) {
+	actorBandwidth, actorCompute := s.actor.MaxUnits()
+	sponsorBandwidth, sponsorCompute := s.sponsor.MaxUnits()
+	return consts.ByteLen + actorBandwidth + consts.ByteLen + sponsorBandwidth, actorCompute + sponsorCompute
+}
+
+// SponsorWrapperEngine batch verifies the wrapped [chain.Auth] using
+// [engines] (keyed by the type of the wrapped [chain.Auth]). Wrapped
+// [chain.Auth] without an engine are verified individually.
+type SponsorWrapperEngine struct {
+	engines map[uint8]vm.AuthEngine
+}
+
+func NewSponsorWrapperEngine(engines map[uint8]vm.AuthEngine) *SponsorWrapperEngine {
+	return &SponsorWrapperEngine{engines}
+}
+
+func (s *SponsorWrapperEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	// Each [SponsorWrapper] may add 2 items to the same batch
+	return &SponsorWrapperBatch{newWrappedBatch(s.engines, cores, 2*count)}
+}
+
+func (s *SponsorWrapperEngine) Cache(rauth chain.Auth) {
+	auth := rauth.(*SponsorWrapper)
+	for _, inner := range []chain.Auth{auth.ActorAuth, auth.SponsorAuth} {
+		if engine, ok := s.engines[inner.GetTypeID()]; ok {
+			engine.Cache(inner)
+		}
+	}
+}
+
+type SponsorWrapperBatch struct {
+	*wrappedBatch
+}
+
+func (b *SponsorWrapperBatch) Add(msg []byte, rauth chain.Auth) func() error {
+	auth := rauth.(*SponsorWrapper)
+	sponsorMsg, err := SponsorMessage(msg, auth.ActorAuth)
+	if err != nil {
+		return func() error { return err }
+	}
+	return combine(
+		b.add(ActorMessage(msg, auth.Sponsor()), auth.ActorAuth),
+		b.add(sponsorMsg, auth.SponsorAuth),
+	)
+}
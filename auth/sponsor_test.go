@@ -0,0 +1,235 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/vm"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testED25519ID        uint8 = 0
+	testSponsorWrapperID uint8 = 1
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+type testED25519 struct {
+	Signer    ed25519.PublicKey
+	Signature ed25519.Signature
+}
+
+func (*testED25519) GetTypeID() uint8 {
+	return testED25519ID
+}
+
+func (*testED25519) ComputeUnits(chain.Rules) uint64 {
+	return 5
+}
+
+func (*testED25519) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *testED25519) Verify(_ context.Context, msg []byte) error {
+	if !ed25519.Verify(msg, d.Signer, d.Signature) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (d *testED25519) Actor() codec.Address {
+	return codec.CreateAddress(testED25519ID, ids.ID(d.Signer))
+}
+
+func (d *testED25519) Sponsor() codec.Address {
+	return d.Actor()
+}
+
+func (*testED25519) Size() int {
+	return ed25519.PublicKeyLen + ed25519.SignatureLen
+}
+
+func (d *testED25519) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.Signer[:])
+	p.PackFixedBytes(d.Signature[:])
+}
+
+func unmarshalTestED25519(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var d testED25519
+	signer := d.Signer[:]
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &signer)
+	signature := d.Signature[:]
+	p.UnpackFixedBytes(ed25519.SignatureLen, &signature)
+	copy(d.Signer[:], signer)
+	copy(d.Signature[:], signature)
+	return &d, p.Err()
+}
+
+type testED25519Factory struct {
+	priv ed25519.PrivateKey
+}
+
+func (d *testED25519Factory) Sign(msg []byte) (chain.Auth, error) {
+	return &testED25519{Signer: d.priv.PublicKey(), Signature: ed25519.Sign(msg, d.priv)}, nil
+}
+
+func (*testED25519Factory) MaxUnits() (uint64, uint64) {
+	return ed25519.PublicKeyLen + ed25519.SignatureLen, 5
+}
+
+type testED25519Engine struct{}
+
+func (*testED25519Engine) GetBatchVerifier(_ int, count int) chain.AuthBatchVerifier {
+	return &testED25519Batch{batch: ed25519.NewBatch(count)}
+}
+
+func (*testED25519Engine) Cache(chain.Auth) {}
+
+type testED25519Batch struct {
+	batch *ed25519.Batch
+	added int
+}
+
+func (b *testED25519Batch) Add(msg []byte, rauth chain.Auth) func() error {
+	auth := rauth.(*testED25519)
+	b.batch.Add(msg, auth.Signer, auth.Signature)
+	b.added++
+	return nil
+}
+
+func (b *testED25519Batch) Done() []func() error {
+	if b.added == 0 {
+		return nil
+	}
+	return []func() error{b.batch.VerifyAsync()}
+}
+
+func newTestRegistry(t *testing.T) *codec.TypeParser[chain.Auth, *warp.Message, bool] {
+	require := require.New(t)
+	registry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(registry.Register(testED25519ID, unmarshalTestED25519, false))
+	require.NoError(registry.Register(testSponsorWrapperID, NewSponsorWrapperParser(testSponsorWrapperID, registry), false))
+	return registry
+}
+
+func newTestFactory(t *testing.T) *testED25519Factory {
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &testED25519Factory{priv}
+}
+
+func runBatch(bv chain.AuthBatchVerifier, msg []byte, auth chain.Auth) error {
+	fs := []func() error{}
+	if f := bv.Add(msg, auth); f != nil {
+		fs = append(fs, f)
+	}
+	fs = append(fs, bv.Done()...)
+	for _, f := range fs {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestSponsorWrapper(t *testing.T) {
+	require := require.New(t)
+	registry := newTestRegistry(t)
+	actorFactory := newTestFactory(t)
+	sponsorFactory := newTestFactory(t)
+	sponsorAddr := codec.CreateAddress(testED25519ID, ids.ID(sponsorFactory.priv.PublicKey()))
+	factory := NewSponsorWrapperFactory(testSponsorWrapperID, actorFactory, sponsorFactory, sponsorAddr)
+
+	msg := []byte("digest")
+	rauth, err := factory.Sign(msg)
+	require.NoError(err)
+	auth := rauth.(*SponsorWrapper)
+	require.Equal(testSponsorWrapperID, auth.GetTypeID())
+	require.Equal(codec.CreateAddress(testED25519ID, ids.ID(actorFactory.priv.PublicKey())), auth.Actor())
+	require.Equal(sponsorAddr, auth.Sponsor())
+	require.Equal(uint64(10), auth.ComputeUnits(nil))
+	require.NoError(auth.Verify(context.Background(), msg))
+	require.ErrorIs(auth.Verify(context.Background(), []byte("other")), errInvalidSignature)
+
+	// Marshal and unmarshal
+	p := codec.NewWriter(auth.Size(), consts.NetworkSizeLimit)
+	auth.Marshal(p)
+	require.NoError(p.Err())
+	bandwidth, _ := factory.MaxUnits()
+	require.Equal(uint64(auth.Size()), bandwidth)
+	unmarshal, _, ok := registry.LookupIndex(testSponsorWrapperID)
+	require.True(ok)
+	parsed, err := unmarshal(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), nil)
+	require.NoError(err)
+	require.Equal(auth.Actor(), parsed.Actor())
+	require.Equal(auth.Sponsor(), parsed.Sponsor())
+	require.NoError(parsed.Verify(context.Background(), msg))
+
+	// Batch verification (with and without an engine for the wrapped auth)
+	for _, engines := range []map[uint8]vm.AuthEngine{
+		{testED25519ID: &testED25519Engine{}},
+		{},
+	} {
+		engine := NewSponsorWrapperEngine(engines)
+		require.NoError(runBatch(engine.GetBatchVerifier(1, 1), msg, auth))
+		require.Error(runBatch(engine.GetBatchVerifier(1, 1), []byte("other"), auth))
+	}
+}
+
+func TestSponsorWrapperReplay(t *testing.T) {
+	require := require.New(t)
+	actorFactory := newTestFactory(t)
+	sponsorFactory := newTestFactory(t)
+	otherFactory := newTestFactory(t)
+	sponsorAddr := codec.CreateAddress(testED25519ID, ids.ID(sponsorFactory.priv.PublicKey()))
+	factory := NewSponsorWrapperFactory(testSponsorWrapperID, actorFactory, sponsorFactory, sponsorAddr)
+
+	msg := []byte("digest")
+	rauth, err := factory.Sign(msg)
+	require.NoError(err)
+	auth := rauth.(*SponsorWrapper)
+
+	// A different sponsor cannot reuse the actor signature
+	sponsorMsg, err := SponsorMessage(msg, auth.ActorAuth)
+	require.NoError(err)
+	otherSponsor, err := otherFactory.Sign(sponsorMsg)
+	require.NoError(err)
+	replayed := NewSponsorWrapper(testSponsorWrapperID, auth.ActorAuth, otherSponsor)
+	require.ErrorIs(replayed.Verify(context.Background(), msg), errInvalidSignature)
+
+	// The factory rejects sponsors that do not match
+	otherAddr := codec.CreateAddress(testED25519ID, ids.ID(otherFactory.priv.PublicKey()))
+	_, err = NewSponsorWrapperFactory(testSponsorWrapperID, actorFactory, sponsorFactory, otherAddr).Sign(msg)
+	require.ErrorIs(err, ErrSponsorMismatch)
+}
+
+func TestSponsorWrapperNested(t *testing.T) {
+	require := require.New(t)
+	registry := newTestRegistry(t)
+	actorFactory := newTestFactory(t)
+	sponsorFactory := newTestFactory(t)
+	sponsorAddr := codec.CreateAddress(testED25519ID, ids.ID(sponsorFactory.priv.PublicKey()))
+	inner, err := NewSponsorWrapperFactory(testSponsorWrapperID, actorFactory, sponsorFactory, sponsorAddr).Sign([]byte("digest"))
+	require.NoError(err)
+	outer := NewSponsorWrapper(testSponsorWrapperID, inner, inner)
+
+	p := codec.NewWriter(outer.Size(), consts.NetworkSizeLimit)
+	outer.Marshal(p)
+	require.NoError(p.Err())
+	unmarshal, _, ok := registry.LookupIndex(testSponsorWrapperID)
+	require.True(ok)
+	_, err = unmarshal(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), nil)
+	require.ErrorIs(err, ErrNestedWrapper)
+}
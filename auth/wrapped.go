@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+// wrappedBatch verifies [chain.Auth] wrapped by another [chain.Auth]. Items
+// are added to the batch verifier of the wrapped type (if one exists) and are
+// otherwise verified individually.
+type wrappedBatch struct {
+	engines map[uint8]vm.AuthEngine
+	cores   int
+	count   int
+
+	verifiers map[uint8]chain.AuthBatchVerifier
+}
+
+func newWrappedBatch(engines map[uint8]vm.AuthEngine, cores int, count int) *wrappedBatch {
+	return &wrappedBatch{
+		engines:   engines,
+		cores:     cores,
+		count:     count,
+		verifiers: map[uint8]chain.AuthBatchVerifier{},
+	}
+}
+
+func (b *wrappedBatch) add(msg []byte, auth chain.Auth) func() error {
+	typeID := auth.GetTypeID()
+	engine, ok := b.engines[typeID]
+	if !ok {
+		return func() error { return auth.Verify(context.TODO(), msg) }
+	}
+	bv, ok := b.verifiers[typeID]
+	if !ok {
+		bv = engine.GetBatchVerifier(b.cores, b.count)
+		b.verifiers[typeID] = bv
+	}
+	return bv.Add(msg, auth)
+}
+
+func (b *wrappedBatch) Done() []func() error {
+	var fs []func() error
+	for _, bv := range b.verifiers {
+		fs = append(fs, bv.Done()...)
+	}
+	return fs
+}
+
+// combine returns a single function that runs all non-nil [fs] or nil
+// if there is nothing to run.
+func combine(fs ...func() error) func() error {
+	jobs := make([]func() error, 0, len(fs))
+	for _, f := range fs {
+		if f != nil {
+			jobs = append(jobs, f)
+		}
+	}
+	switch len(jobs) {
+	case 0:
+		return nil
+	case 1:
+		return jobs[0]
+	default:
+		return func() error {
+			for _, job := range jobs {
+				if err := job(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// intersectRange returns the overlap of 2 ranges, where -1 means no
+// start/end.
+func intersectRange(start1, end1, start2, end2 int64) (int64, int64) {
+	start := start1
+	if start2 >= 0 && (start < 0 || start2 > start) {
+		start = start2
+	}
+	end := end1
+	if end2 >= 0 && (end < 0 || end2 < end) {
+		end = end2
+	}
+	return start, end
+}
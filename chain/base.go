@@ -13,6 +13,11 @@ import (
 
 const BaseSize = consts.Uint64Len*2 + consts.IDLen
 
+// nonceFlag is added to the encoded [Base.Timestamp] (which must be a multiple
+// of a second) when a non-zero [Base.Nonce] follows [Base.MaxFee]. This ensures
+// transactions only pay for the bandwidth of the nonce when they use it.
+const nonceFlag = 1
+
 type Base struct {
 	// Timestamp is the expiry of the transaction (inclusive). Once this time passes and the
 	// transaction is not included in a block, it is safe to regenerate it.
@@ -26,6 +31,10 @@ type Base struct {
 	//
 	// If the fee is too low to pay all fees, the transaction will be dropped.
 	MaxFee uint64 `json:"maxFee"`
+
+	// Nonce is the sequence number of the transaction for its sponsor. It is only
+	// used if the [StateManager] implements [NonceManager] (and must be 0 otherwise).
+	Nonce uint64 `json:"nonce"`
 }
 
 func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
@@ -44,24 +53,42 @@ func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
 	}
 }
 
-func (*Base) Size() int {
-	return BaseSize
+func (b *Base) Size() int {
+	if b.Nonce == 0 {
+		return BaseSize
+	}
+	return BaseSize + consts.Uint64Len
 }
 
 func (b *Base) Marshal(p *codec.Packer) {
-	p.PackInt64(b.Timestamp)
+	if b.Nonce == 0 {
+		p.PackInt64(b.Timestamp)
+	} else {
+		p.PackInt64(b.Timestamp + nonceFlag)
+	}
 	p.PackID(b.ChainID)
 	p.PackUint64(b.MaxFee)
+	if b.Nonce != 0 {
+		p.PackUint64(b.Nonce)
+	}
 }
 
 func UnmarshalBase(p *codec.Packer) (*Base, error) {
 	var base Base
 	base.Timestamp = p.UnpackInt64(true)
+	hasNonce := base.Timestamp%consts.MillisecondsPerSecond == nonceFlag
+	if hasNonce {
+		base.Timestamp -= nonceFlag
+	}
 	if base.Timestamp%consts.MillisecondsPerSecond != 0 {
 		// TODO: make this modulus configurable
 		return nil, fmt.Errorf("%w: timestamp=%d", ErrMisalignedTime, base.Timestamp)
 	}
 	p.UnpackID(true, &base.ChainID)
 	base.MaxFee = p.UnpackUint64(true)
+	if hasNonce {
+		// A flagged nonce must be non-zero, so each [Base] has a single encoding
+		base.Nonce = p.UnpackUint64(true)
+	}
 	return &base, p.Err()
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/x/merkledb"
@@ -53,6 +54,9 @@ type StatefulBlock struct {
 	StateRoot   ids.ID     `json:"stateRoot"`
 	WarpResults set.Bits64 `json:"warpResults"`
 
+	// EventsRoot commits to all [Event]s emitted by [Txs] (see [EventsRoot]).
+	EventsRoot ids.ID `json:"eventsRoot"`
+
 	size int
 
 	// authCounts can be used by batch signature verification
@@ -77,7 +81,7 @@ func (b *StatefulBlock) ID() (ids.ID, error) {
 type warpJob struct {
 	msg          *warp.Message
 	signers      int
-	verifiedChan chan bool
+	verifiedChan chan error // nil if verified
 	verified     bool
 	warpNum      int
 }
@@ -119,6 +123,12 @@ type StatelessBlock struct {
 	results    []*Result
 	feeManager *FeeManager
 
+	// stateChanges are only retained by archive nodes (see [StateChanges])
+	stateChanges map[string]maybe.Maybe[[]byte]
+
+	// traces are only collected if tracing is enabled (see [Traces])
+	traces []*TxTrace
+
 	vm   VM
 	view merkledb.View
 
@@ -212,7 +222,7 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 			b.warpMessages[tx.ID()] = &warpJob{
 				msg:          tx.WarpMessage,
 				signers:      signers,
-				verifiedChan: make(chan bool, 1),
+				verifiedChan: make(chan error, 1),
 				warpNum:      len(b.warpMessages),
 			}
 			b.containsWarp = true
@@ -433,6 +443,22 @@ func (b *StatelessBlock) verifyWarpMessage(ctx context.Context, r Rules, msg *wa
 	return true
 }
 
+// warpResultErr returns the error to execute a transaction with when its warp
+// message from [sourceChainID] was (or was not) [verified].
+//
+// The error only depends on [r] and the verification result recorded in the
+// block, so every node reports the same [Result.WarpError] (the reason a
+// signature could not be verified is only logged).
+func warpResultErr(r Rules, sourceChainID ids.ID, verified bool) error {
+	if verified {
+		return nil
+	}
+	if allowed, _, _ := r.GetWarpConfig(sourceChainID); !allowed {
+		return ErrDisabledChainID
+	}
+	return ErrWarpNotVerified
+}
+
 // innerVerify executes the block on top of the provided [VerifyContext].
 //
 // Invariants:
@@ -542,7 +568,7 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 				if b.vm.IsBootstrapped() && !invalidWarpResult {
 					start := time.Now()
 					verified := b.verifyWarpMessage(ctx, r, msg.msg)
-					msg.verifiedChan <- verified
+					msg.verifiedChan <- warpResultErr(r, msg.msg.SourceChainID, verified)
 					msg.verified = verified
 					log.Info(
 						"processed warp message",
@@ -560,7 +586,7 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 					// We also use the result in the block when we have found
 					// a verification mismatch (our verify result is different than the
 					// block) to avoid doing extra work.
-					msg.verifiedChan <- blockVerified
+					msg.verifiedChan <- warpResultErr(r, msg.msg.SourceChainID, blockVerified)
 					msg.verified = blockVerified
 				}
 			}
@@ -605,6 +631,20 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 		return ErrWarpResultMismatch
 	}
 
+	// Ensure events are correct
+	eventsRoot, err := EventsRoot(results)
+	if err != nil {
+		return err
+	}
+	if b.EventsRoot != eventsRoot {
+		return fmt.Errorf(
+			"%w: expected=%s found=%s",
+			ErrEventsRootMismatch,
+			eventsRoot,
+			b.EventsRoot,
+		)
+	}
+
 	// Update chain metadata
 	heightKeyStr := string(heightKey)
 	timestampKeyStr := string(timestampKey)
@@ -664,6 +704,9 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 		return err
 	}
 	b.view = view
+	if b.vm.GetArchive() {
+		b.stateChanges = ts.ChangedKeys()
+	}
 
 	// Kickoff root generation
 	go func() {
@@ -967,6 +1010,19 @@ func (b *StatelessBlock) Results() []*Result {
 	return b.results
 }
 
+// StateChanges returns the new value of each key modified by [b] (Nothing if
+// the key was deleted). This is only populated on archive nodes after [b] is
+// executed.
+func (b *StatelessBlock) StateChanges() map[string]maybe.Maybe[[]byte] {
+	return b.stateChanges
+}
+
+// Traces returns the [TxTrace] of each transaction in [b]. This is only
+// populated if [VM.GetTransactionTracing] is enabled when [b] is executed.
+func (b *StatelessBlock) Traces() []*TxTrace {
+	return b.traces
+}
+
 func (b *StatelessBlock) FeeManager() *FeeManager {
 	return b.feeManager
 }
@@ -975,7 +1031,7 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 	size := consts.IDLen + consts.Uint64Len + consts.Uint64Len +
 		consts.Uint64Len + window.WindowSliceSize +
 		consts.IntLen + codec.CummSize(b.Txs) +
-		consts.IDLen + consts.Uint64Len + consts.Uint64Len
+		consts.IDLen + consts.Uint64Len + consts.Uint64Len + consts.IDLen
 
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 
@@ -994,6 +1050,7 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 
 	p.PackID(b.StateRoot)
 	p.PackUint64(uint64(b.WarpResults))
+	p.PackID(b.EventsRoot)
 	bytes := p.Bytes()
 	if err := p.Err(); err != nil {
 		return nil, err
@@ -1019,7 +1076,7 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 	b.Txs = []*Transaction{} // don't preallocate all to avoid DoS
 	b.authCounts = map[uint8]int{}
 	for i := 0; i < txCount; i++ {
-		tx, err := UnmarshalTx(p, actionRegistry, authRegistry)
+		tx, err := UnmarshalTx(p, b.Tmstmp, actionRegistry, authRegistry)
 		if err != nil {
 			return nil, err
 		}
@@ -1029,6 +1086,7 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 
 	p.UnpackID(false, &b.StateRoot)
 	b.WarpResults = set.Bits64(p.UnpackUint64(false))
+	p.UnpackID(false, &b.EventsRoot)
 
 	// Ensure no leftover bytes
 	if !p.Empty() {
@@ -46,12 +46,26 @@ func HandlePreExecute(log logging.Logger, err error) bool {
 		return false
 	case errors.Is(err, ErrInvalidBalance):
 		return false
+	case errors.Is(err, ErrMaxFeeTooLow):
+		// Unit prices may fall before the transaction expires
+		return true
 	case errors.Is(err, ErrAuthNotActivated):
 		return false
+	case errors.Is(err, ErrAuthDeprecated):
+		return false
 	case errors.Is(err, ErrAuthFailed):
 		return false
 	case errors.Is(err, ErrActionNotActivated):
 		return false
+	case errors.Is(err, ErrActionDeprecated):
+		return false
+	case errors.Is(err, ErrNonceTooHigh):
+		// The transactions that fill the gap may be executed first
+		return true
+	case errors.Is(err, ErrNonceTooLow):
+		return false
+	case errors.Is(err, ErrInvalidNonce):
+		return false
 	default:
 		// If unknown error, drop
 		log.Warn("unknown PreExecute error", zap.Error(err))
@@ -127,6 +141,8 @@ func BuildBlock(
 		start        = time.Now()
 		txsAttempted = 0
 		results      = []*Result{}
+		traces       = []*TxTrace{}
+		tracing      = vm.GetTransactionTracing()
 
 		vdrState = vm.ValidatorState()
 		sm       = vm.StateManager()
@@ -273,7 +289,10 @@ func BuildBlock(
 
 				// Execute block
 				tsv := ts.NewView(stateKeys, storage)
-				if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, nextTime); err != nil {
+				if tracing {
+					tsv.EnableTracing()
+				}
+				if err := tx.PreExecuteWithMaxFee(ctx, feeManager, sm, r, tsv, nextTime); err != nil {
 					// We don't need to rollback [tsv] here because it will never
 					// be committed.
 					if HandlePreExecute(log, err) {
@@ -309,6 +328,7 @@ func BuildBlock(
 							zap.Error(warpErr),
 						)
 					}
+					warpErr = warpResultErr(r, tx.WarpMessage.SourceChainID, warpErr == nil)
 				}
 
 				// If execution works, keep moving forward with new state
@@ -342,7 +362,7 @@ func BuildBlock(
 					r,
 					tsv,
 					nextTime,
-					tx.WarpMessage != nil && warpErr == nil,
+					warpErr,
 				)
 				if err != nil {
 					// Returning an error here should be avoided at all costs (can be a DoS). Rather,
@@ -379,6 +399,9 @@ func BuildBlock(
 				tsv.Commit()
 				b.Txs = append(b.Txs, tx)
 				results = append(results, result)
+				if tracing {
+					traces = append(traces, newTxTrace(tsv, reads, result, feeManager))
+				}
 				if tx.WarpMessage != nil {
 					if warpErr == nil {
 						// Add a bit if the warp message was verified
@@ -468,6 +491,13 @@ func BuildBlock(
 	}
 	b.StateRoot = root
 
+	// Commit to all events emitted in the block
+	eventsRoot, err := EventsRoot(results)
+	if err != nil {
+		return nil, err
+	}
+	b.EventsRoot = eventsRoot
+
 	// Get view from [tstate] after writing all changed keys
 	view, err := ts.ExportMerkleDBView(ctx, vm.Tracer(), parentView)
 	if err != nil {
@@ -479,6 +509,12 @@ func BuildBlock(
 		log.Warn("block failed", zap.Int("txs", len(b.Txs)), zap.Any("consumed", feeManager.UnitsConsumed()))
 		return nil, err
 	}
+	if vm.GetArchive() {
+		b.stateChanges = ts.ChangedKeys()
+	}
+	if tracing {
+		b.traces = traces
+	}
 
 	// Kickoff root generation
 	go func() {
@@ -34,6 +34,18 @@ const (
 	HeightKeyChunks       = 1
 	TimestampKeyChunks    = 1
 	FeeKeyChunks          = 8 // 96 (per dimension) * 5 (num dimensions)
+	// MaxActionEvents is the maximum number of events a single [Action] can emit.
+	MaxActionEvents = 16
+	// MaxEventTopics is the maximum number of topics an [Event] can have.
+	MaxEventTopics = 4
+	// MaxEventDataSize is the maximum size of [Event.Data].
+	MaxEventDataSize = 1 * units.KiB
+	// MaxWarpStateReadKeys is the maximum number of keys a [WarpStateRead]
+	// can attest to.
+	MaxWarpStateReadKeys = 16
+	// MaxWarpStateReadSize is the maximum size of a [WarpStateRead] payload
+	// (leaving room for the rest of the warp message).
+	MaxWarpStateReadSize = MaxWarpMessageSize / 2
 )
 
 func HeightKey(prefix []byte) []byte {
@@ -63,6 +63,8 @@ type VM interface {
 	AuthVerifiers() workers.Workers
 	GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (AuthBatchVerifier, bool)
 	GetVerifyAuth() bool
+	GetArchive() bool
+	GetTransactionTracing() bool
 
 	IsBootstrapped() bool
 	LastAcceptedBlock() *StatelessBlock
@@ -78,6 +80,7 @@ type VM interface {
 	IsRepeat(context.Context, []*Transaction, set.Bits, bool) set.Bits
 	GetTargetBuildDuration() time.Duration
 	GetTransactionExecutionCores() int
+	GetOptimisticTransactionExecution() bool
 
 	Verified(context.Context, *StatelessBlock)
 	Rejected(context.Context, *StatelessBlock)
@@ -123,6 +126,8 @@ type Rules interface {
 	GetMinEmptyBlockGap() int64 // in milliseconds
 	GetValidityWindow() int64   // in milliseconds
 
+	GetMaxActionsPerTx() uint8
+
 	GetMinUnitPrice() Dimensions
 	GetUnitPriceChangeDenominator() Dimensions
 	GetWindowTargetUnits() Dimensions
@@ -155,6 +160,10 @@ type Rules interface {
 
 	GetWarpConfig(sourceChainID ids.ID) (bool, uint64, uint64)
 
+	// GetUpgradeTimestamp returns the activation timestamp (in milliseconds)
+	// of the network upgrade named [name], if it is scheduled.
+	GetUpgradeTimestamp(name string) (int64, bool)
+
 	FetchCustom(string) (any, bool)
 }
 
@@ -248,7 +257,9 @@ type Action interface {
 	// key (formatted as a big-endian uint16). This is used to automatically calculate storage usage.
 	//
 	// If any key is removed and then re-created, this will count as a creation instead of a modification.
-	StateKeys(actor codec.Address, txID ids.ID) []string
+	//
+	// [actionID] is unique to each [Action] in a transaction (see [Transaction.ActionID]).
+	StateKeys(actor codec.Address, actionID ids.ID) []string
 
 	// Execute actually runs the [Action]. Any state changes that the [Action] performs should
 	// be done here.
@@ -257,22 +268,37 @@ type Action interface {
 	// will revert and the max fee will be charged.
 	//
 	// An error should only be returned if a fatal error was encountered, otherwise [success] should
-	// be marked as false and fees will still be charged.
+	// be marked as false and fees will still be charged. If any [Action] in a transaction is not
+	// successful, the changes of all [Action]s in that transaction are reverted.
+	//
+	// [events] are only recorded if the transaction is successful. Each [Action] can emit at most
+	// [MaxActionEvents] and should charge [computeUnits] for emitting them.
 	Execute(
 		ctx context.Context,
 		r Rules,
 		mu state.Mutable,
 		timestamp int64,
 		actor codec.Address,
-		txID ids.ID,
+		actionID ids.ID,
 		warpVerified bool,
-	) (success bool, computeUnits uint64, output []byte, warpMessage *warp.UnsignedMessage, err error)
+	) (success bool, computeUnits uint64, output []byte, events []*Event, warpMessage *warp.UnsignedMessage, err error)
 
 	// OutputsWarpMessage indicates whether an [Action] will produce a warp message. The max size
 	// of any warp message is [MaxOutgoingWarpChunks].
+	//
+	// At most one [Action] in a transaction may produce a warp message.
 	OutputsWarpMessage() bool
 }
 
+// Participants is an optional interface an [Action] can implement to declare
+// the addresses (other than the [Auth.Actor] and [Auth.Sponsor]) it involves.
+//
+// Declared addresses are used to index transactions by address and have no
+// effect on execution.
+type Participants interface {
+	Participants() []codec.Address
+}
+
 type Auth interface {
 	Object
 
@@ -296,15 +322,23 @@ type Auth interface {
 	//
 	// If the [Actor] is not the same as [Sponsor], it is likely that the [Actor] signature
 	// is wrapped by the [Sponsor] signature. It is important that the [Actor], in this case,
-	// signs the [Sponsor] address or else their transaction could be replayed.
-	//
-	// TODO: add a standard sponsor wrapper auth (but this does not need to be handled natively)
+	// signs the [Sponsor] address or else their transaction could be replayed. The
+	// [auth.SponsorWrapper] is a standard implementation of this pattern.
 	//
 	// To avoid collisions with other [Auth] modules, this must be prefixed
 	// by the [TypeID].
 	Sponsor() codec.Address
 }
 
+// WrapperAuth is an optional interface an [Auth] that wraps other [Auth] (like
+// [auth.SponsorWrapper]) must implement. The [Auth.Actor] and [Auth.Sponsor] of
+// a wrapper are prefixed by the [TypeID] of the wrapped [Auth] they belong to
+// (rather than the [TypeID] of the wrapper).
+type WrapperAuth interface {
+	ActorTypeID() uint8
+	SponsorTypeID() uint8
+}
+
 type AuthBatchVerifier interface {
 	Add([]byte, Auth) func() error
 	Done() []func() error
@@ -28,11 +28,13 @@ var (
 	ErrStateRootMismatch    = errors.New("state root mismatch")
 	ErrInvalidResult        = errors.New("invalid result")
 	ErrInvalidBlockHeight   = errors.New("invalid block height")
+	ErrEventsRootMismatch   = errors.New("events root mismatch")
 
 	// Tx Correctness
 	ErrInvalidSignature     = errors.New("invalid signature")
 	ErrDuplicateTx          = errors.New("duplicate transaction")
 	ErrInsufficientPrice    = errors.New("insufficient price")
+	ErrMaxFeeTooLow         = errors.New("max fee too low")
 	ErrInvalidType          = errors.New("invalid tx type")
 	ErrInvalidID            = errors.New("invalid content ID")
 	ErrInvalidSchema        = errors.New("invalid schema")
@@ -45,17 +47,29 @@ var (
 	ErrServicerMissing      = errors.New("servicer missing")
 	ErrTooManyTxs           = errors.New("too many transactions")
 	ErrActionNotActivated   = errors.New("action not activated")
+	ErrActionDeprecated     = errors.New("action deprecated")
 	ErrAuthNotActivated     = errors.New("auth not activated")
+	ErrAuthDeprecated       = errors.New("auth deprecated")
 	ErrAuthFailed           = errors.New("auth failed")
 	ErrMisalignedTime       = errors.New("misaligned time")
 	ErrInvalidActor         = errors.New("invalid actor")
 	ErrInvalidSponsor       = errors.New("invalid sponsor")
+	ErrNoActions            = errors.New("no actions")
+	ErrTooManyActions       = errors.New("too many actions")
+	ErrInvalidNonce         = errors.New("invalid nonce")
+	ErrNonceTooLow          = errors.New("nonce too low")
+	ErrNonceTooHigh         = errors.New("nonce too high")
 
 	// Execution Correctness
 	ErrInvalidBalance  = errors.New("invalid balance")
 	ErrBlockTooBig     = errors.New("block too big")
 	ErrKeyNotSpecified = errors.New("key not specified")
 
+	// Events
+	ErrTooManyEvents     = errors.New("too many events")
+	ErrTooManyTopics     = errors.New("too many topics")
+	ErrEventDataTooLarge = errors.New("event data too large")
+
 	// Warp
 	ErrDisabledChainID           = errors.New("cannot import from chain ID")
 	ErrMissingBlockContext       = errors.New("cannot verify warp messages without block context")
@@ -65,6 +79,16 @@ var (
 	ErrEmptyWarpPayload          = errors.New("empty warp payload")
 	ErrTooManyWarpMessages       = errors.New("too many warp messages")
 	ErrWarpResultMismatch        = errors.New("warp result mismatch")
+	ErrDuplicateWarpMessage      = errors.New("duplicate warp message")
+	ErrWarpNotVerified           = errors.New("warp message not verified")
+	ErrInvalidWarpQuorum         = errors.New("invalid warp quorum")
+	ErrConflictingWarpPolicy     = errors.New("chain is both allowed and denied")
+	ErrDuplicateWarpChain        = errors.New("duplicate warp chain quorum")
+	ErrReservedWarpPayload       = errors.New("warp payload uses reserved prefix")
+	ErrNotWarpStateRead          = errors.New("not a warp state read")
+	ErrTooManyWarpStateReadKeys  = errors.New("too many warp state read keys")
+	ErrWarpStateReadTooLarge     = errors.New("warp state read too large")
+	ErrTooManyWarpActions        = errors.New("too many warp actions")
 
 	// Misc
 	ErrNotImplemented         = errors.New("not implemented")
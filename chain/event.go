@@ -0,0 +1,116 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// Event is a structured log emitted by an [Action] during execution.
+//
+// Events are only recorded if a transaction is successful and can be
+// consumed by subscribing to them over websockets (filtered by [Topics]
+// and/or [Address]) instead of decoding [Action] outputs.
+type Event struct {
+	// Type is defined by the [Action] that emitted the event.
+	Type uint8 `json:"type"`
+
+	// Address is the account the event is about.
+	Address codec.Address `json:"address"`
+
+	// Topics are indexed values that can be used to filter events.
+	Topics []ids.ID `json:"topics"`
+
+	// Data is any additional (unindexed) information.
+	Data []byte `json:"data"`
+}
+
+func (e *Event) Size() int {
+	return consts.ByteLen + codec.AddressLen + consts.ByteLen + len(e.Topics)*consts.IDLen + codec.BytesLen(e.Data)
+}
+
+func (e *Event) Marshal(p *codec.Packer) {
+	p.PackByte(e.Type)
+	p.PackAddress(e.Address)
+	p.PackByte(uint8(len(e.Topics)))
+	for _, topic := range e.Topics {
+		p.PackID(topic)
+	}
+	p.PackBytes(e.Data)
+}
+
+func UnmarshalEvent(p *codec.Packer) (*Event, error) {
+	var e Event
+	e.Type = p.UnpackByte()
+	p.UnpackAddress(&e.Address)
+	numTopics := p.UnpackByte()
+	if numTopics > MaxEventTopics {
+		return nil, ErrTooManyTopics
+	}
+	if numTopics > 0 {
+		e.Topics = make([]ids.ID, numTopics)
+		for i := range e.Topics {
+			p.UnpackID(false, &e.Topics[i])
+		}
+	}
+	p.UnpackBytes(MaxEventDataSize, false, &e.Data)
+	if len(e.Data) == 0 {
+		// Enforce object standardization
+		e.Data = nil
+	}
+	return &e, p.Err()
+}
+
+// verifyEvents ensures the events emitted by a single [Action] are within
+// the allowed limits.
+func verifyEvents(events []*Event) error {
+	if len(events) > MaxActionEvents {
+		return ErrTooManyEvents
+	}
+	for _, event := range events {
+		if len(event.Topics) > MaxEventTopics {
+			return ErrTooManyTopics
+		}
+		if len(event.Data) > MaxEventDataSize {
+			return ErrEventDataTooLarge
+		}
+		if len(event.Data) == 0 && event.Data != nil {
+			return ErrInvalidObject
+		}
+	}
+	return nil
+}
+
+// EventsRoot is the commitment to all events emitted by transactions in a
+// block (in order) that is included in [StatefulBlock].
+//
+// If no events are emitted, the root is [ids.Empty].
+func EventsRoot(results []*Result) (ids.ID, error) {
+	var (
+		size  = 0
+		count = 0
+	)
+	for _, result := range results {
+		size += consts.IntLen + codec.CummSize(result.Events)
+		count += len(result.Events)
+	}
+	if count == 0 {
+		return ids.Empty, nil
+	}
+	p := codec.NewWriter(size, consts.MaxInt)
+	for _, result := range results {
+		p.PackInt(len(result.Events))
+		for _, event := range result.Events {
+			event.Marshal(p)
+		}
+	}
+	if err := p.Err(); err != nil {
+		return ids.Empty, err
+	}
+	return utils.ToID(p.Bytes()), nil
+}
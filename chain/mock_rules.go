@@ -99,6 +99,20 @@ func (mr *MockRulesMockRecorder) GetBaseWarpComputeUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseWarpComputeUnits", reflect.TypeOf((*MockRules)(nil).GetBaseWarpComputeUnits))
 }
 
+// GetMaxActionsPerTx mocks base method.
+func (m *MockRules) GetMaxActionsPerTx() uint8 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxActionsPerTx")
+	ret0, _ := ret[0].(uint8)
+	return ret0
+}
+
+// GetMaxActionsPerTx indicates an expected call of GetMaxActionsPerTx.
+func (mr *MockRulesMockRecorder) GetMaxActionsPerTx() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxActionsPerTx", reflect.TypeOf((*MockRules)(nil).GetMaxActionsPerTx))
+}
+
 // GetMaxBlockUnits mocks base method.
 func (m *MockRules) GetMaxBlockUnits() Dimensions {
 	m.ctrl.T.Helper()
@@ -281,6 +295,21 @@ func (mr *MockRulesMockRecorder) GetUnitPriceChangeDenominator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitPriceChangeDenominator", reflect.TypeOf((*MockRules)(nil).GetUnitPriceChangeDenominator))
 }
 
+// GetUpgradeTimestamp mocks base method.
+func (m *MockRules) GetUpgradeTimestamp(arg0 string) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpgradeTimestamp", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetUpgradeTimestamp indicates an expected call of GetUpgradeTimestamp.
+func (mr *MockRulesMockRecorder) GetUpgradeTimestamp(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgradeTimestamp", reflect.TypeOf((*MockRules)(nil).GetUpgradeTimestamp), arg0)
+}
+
 // GetValidityWindow mocks base method.
 func (m *MockRules) GetValidityWindow() int64 {
 	m.ctrl.T.Helper()
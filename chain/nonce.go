@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// NonceChunks is the max number of chunks used by the nonce of a [Sponsor].
+const NonceChunks uint16 = 1
+
+// NonceManager can be implemented by a [StateManager] to enable account
+// nonces.
+//
+// If enabled, each transaction must set [Base.Nonce] to the next nonce of its
+// [Sponsor] (starting at 0), which is incremented when the transaction is
+// included in a block (whether or not its actions succeed). This guarantees
+// that the transactions of a [Sponsor] are executed in the order they were
+// created.
+//
+// The nonce of a [Sponsor] is read and written by each of its transactions
+// (in addition to [StateManager.SponsorStateKeys]), so fee estimates should be
+// made with [EstimateMaxUnits] and nonces set to true.
+type NonceManager interface {
+	NonceKeyPrefix(addr codec.Address) []byte
+}
+
+// nonceKey returns the key of the nonce of [addr] (or false, if nonces are
+// not enabled by [sm]).
+func nonceKey(sm StateManager, addr codec.Address) ([]byte, bool) {
+	nm, ok := sm.(NonceManager)
+	if !ok {
+		return nil, false
+	}
+	return keys.EncodeChunks(nm.NonceKeyPrefix(addr), NonceChunks), true
+}
+
+// GetNonce returns the next nonce of [addr] in [im] (or false, if nonces are
+// not enabled by [sm]).
+func GetNonce(ctx context.Context, sm StateManager, im state.Immutable, addr codec.Address) (uint64, bool, error) {
+	k, ok := nonceKey(sm, addr)
+	if !ok {
+		return 0, false, nil
+	}
+	nonce, err := getNonce(ctx, im, k)
+	return nonce, true, err
+}
+
+func getNonce(ctx context.Context, im state.Immutable, k []byte) (uint64, error) {
+	v, err := im.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(v) != consts.Uint64Len {
+		return 0, fmt.Errorf("%w: nonce has length %d", ErrInvalidObject, len(v))
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func incrementNonce(ctx context.Context, mu state.Mutable, k []byte) error {
+	nonce, err := getNonce(ctx, mu, k)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, nonce+1))
+}
+
+// verifyNonce ensures [t] has the next nonce of its [Sponsor] (if nonces are
+// enabled by [sm]).
+func (t *Transaction) verifyNonce(ctx context.Context, sm StateManager, im state.Immutable) error {
+	next, enabled, err := GetNonce(ctx, sm, im, t.Auth.Sponsor())
+	if err != nil {
+		return err
+	}
+	switch {
+	case !enabled && t.Base.Nonce != 0:
+		return fmt.Errorf("%w: nonces are not enabled", ErrInvalidNonce)
+	case !enabled:
+		return nil
+	case t.Base.Nonce < next:
+		return fmt.Errorf("%w: nonce=%d next=%d", ErrNonceTooLow, t.Base.Nonce, next)
+	case t.Base.Nonce > next:
+		return fmt.Errorf("%w: nonce=%d next=%d", ErrNonceTooHigh, t.Base.Nonce, next)
+	default:
+		return nil
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/set"
 
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/keys"
@@ -25,6 +26,88 @@ type fetchData struct {
 	chunks uint16
 }
 
+// fetcher caches the values of keys in [im] that are read by transactions
+// in a block.
+type fetcher struct {
+	im state.Immutable
+
+	l     sync.RWMutex
+	cache map[string]*fetchData
+}
+
+func newFetcher(im state.Immutable, items int) *fetcher {
+	return &fetcher{
+		im:    im,
+		cache: make(map[string]*fetchData, items),
+	}
+}
+
+// fetch returns the number of chunks read for each of [stateKeys] and the
+// values of [stateKeys] that exist. Keys that were not already cached are
+// returned in [toCache] and should be added with [update].
+func (f *fetcher) fetch(
+	ctx context.Context,
+	stateKeys set.Set[string],
+) (map[string]uint16, map[string][]byte, map[string]*fetchData, error) {
+	// Fetch keys from cache
+	var (
+		reads    = make(map[string]uint16, len(stateKeys))
+		storage  = make(map[string][]byte, len(stateKeys))
+		toLookup = make([]string, 0, len(stateKeys))
+	)
+	f.l.RLock()
+	for k := range stateKeys {
+		if v, ok := f.cache[k]; ok {
+			reads[k] = v.chunks
+			if v.exists {
+				storage[k] = v.v
+			}
+			continue
+		}
+		toLookup = append(toLookup, k)
+	}
+	f.l.RUnlock()
+
+	// Fetch keys from disk
+	var toCache map[string]*fetchData
+	if len(toLookup) > 0 {
+		toCache = make(map[string]*fetchData, len(toLookup))
+		for _, k := range toLookup {
+			v, err := f.im.GetValue(ctx, []byte(k))
+			if errors.Is(err, database.ErrNotFound) {
+				reads[k] = 0
+				toCache[k] = &fetchData{nil, false, 0}
+				continue
+			} else if err != nil {
+				return nil, nil, nil, err
+			}
+			// We verify that the [NumChunks] is already less than the number
+			// added on the write path, so we don't need to do so again here.
+			numChunks, ok := keys.NumChunks(v)
+			if !ok {
+				return nil, nil, nil, ErrInvalidKeyValue
+			}
+			reads[k] = numChunks
+			toCache[k] = &fetchData{v, true, numChunks}
+			storage[k] = v
+		}
+	}
+	return reads, storage, toCache, nil
+}
+
+// update adds [toCache] to the key cache.
+func (f *fetcher) update(toCache map[string]*fetchData) {
+	if len(toCache) == 0 {
+		return
+	}
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	for k := range toCache {
+		f.cache[k] = toCache[k]
+	}
+}
+
 func (b *StatelessBlock) Execute(
 	ctx context.Context,
 	tracer trace.Tracer, //nolint:interfacer
@@ -35,16 +118,20 @@ func (b *StatelessBlock) Execute(
 	ctx, span := tracer.Start(ctx, "Processor.Execute")
 	defer span.End()
 
+	if b.vm.GetOptimisticTransactionExecution() {
+		return b.executeOptimistic(ctx, im, feeManager, r)
+	}
+
 	var (
-		sm        = b.vm.StateManager()
-		numTxs    = len(b.Txs)
-		t         = b.GetTimestamp()
-		cacheLock sync.RWMutex
-		cache     = make(map[string]*fetchData, numTxs)
+		sm     = b.vm.StateManager()
+		numTxs = len(b.Txs)
+		t      = b.GetTimestamp()
+		f      = newFetcher(im, numTxs)
 
 		e       = executor.New(numTxs, b.vm.GetTransactionExecutionCores(), b.vm.GetExecutorVerifyRecorder())
 		ts      = tstate.New(numTxs * 2) // TODO: tune this heuristic
 		results = make([]*Result, numTxs)
+		traces  = make([]*TxTrace, numTxs)
 	)
 
 	// Fetch required keys and execute transactions
@@ -58,48 +145,9 @@ func (b *StatelessBlock) Execute(
 			return nil, nil, err
 		}
 		e.Run(stateKeys, func() error {
-			// Fetch keys from cache
-			var (
-				reads    = make(map[string]uint16, len(stateKeys))
-				storage  = make(map[string][]byte, len(stateKeys))
-				toLookup = make([]string, 0, len(stateKeys))
-			)
-			cacheLock.RLock()
-			for k := range stateKeys {
-				if v, ok := cache[k]; ok {
-					reads[k] = v.chunks
-					if v.exists {
-						storage[k] = v.v
-					}
-					continue
-				}
-				toLookup = append(toLookup, k)
-			}
-			cacheLock.RUnlock()
-
-			// Fetch keys from disk
-			var toCache map[string]*fetchData
-			if len(toLookup) > 0 {
-				toCache = make(map[string]*fetchData, len(toLookup))
-				for _, k := range toLookup {
-					v, err := im.GetValue(ctx, []byte(k))
-					if errors.Is(err, database.ErrNotFound) {
-						reads[k] = 0
-						toCache[k] = &fetchData{nil, false, 0}
-						continue
-					} else if err != nil {
-						return err
-					}
-					// We verify that the [NumChunks] is already less than the number
-					// added on the write path, so we don't need to do so again here.
-					numChunks, ok := keys.NumChunks(v)
-					if !ok {
-						return ErrInvalidKeyValue
-					}
-					reads[k] = numChunks
-					toCache[k] = &fetchData{v, true, numChunks}
-					storage[k] = v
-				}
+			reads, storage, toCache, err := f.fetch(ctx, stateKeys)
+			if err != nil {
+				return err
 			}
 
 			// Execute transaction
@@ -108,26 +156,17 @@ func (b *StatelessBlock) Execute(
 			// processed
 			tsv := ts.NewView(stateKeys, storage)
 
-			// Ensure we have enough funds to pay fees
-			if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
-				return err
-			}
-
 			// Wait to execute transaction until we have the warp result processed.
-			var warpVerified bool
-			warpMsg, ok := b.warpMessages[tx.ID()]
-			if ok {
-				select {
-				case warpVerified = <-warpMsg.verifiedChan:
-				case <-ctx.Done():
-					return ctx.Err()
-				}
+			warpErr, err := b.waitWarp(ctx, tx)
+			if err != nil {
+				return err
 			}
-			result, err := tx.Execute(ctx, feeManager, reads, sm, r, tsv, t, ok && warpVerified)
+			result, trace, err := b.executeTx(ctx, tx, feeManager, reads, sm, r, tsv, t, warpErr)
 			if err != nil {
 				return err
 			}
 			results[i] = result
+			traces[i] = trace
 
 			// Update block metadata with units actually consumed (if more is consumed than block allows, we will non-deterministically
 			// exit with an error based on which tx over the limit is processed first)
@@ -139,20 +178,231 @@ func (b *StatelessBlock) Execute(
 			tsv.Commit()
 
 			// Update key cache
-			if len(toCache) > 0 {
-				cacheLock.Lock()
-				for k := range toCache {
-					cache[k] = toCache[k]
-				}
-				cacheLock.Unlock()
-			}
+			f.update(toCache)
 			return nil
 		})
 	}
 	if err := e.Wait(); err != nil {
 		return nil, nil, err
 	}
+	if b.vm.GetTransactionTracing() {
+		b.traces = traces
+	}
+
+	// Return tstate that can be used to add block-level keys to state
+	return results, ts, nil
+}
+
+// waitWarp waits for the warp message of [tx] (if any) to be verified and
+// returns the reason it could not be verified (nil if it was verified or [tx]
+// has no warp message).
+//
+// This can only be called once per transaction.
+func (b *StatelessBlock) waitWarp(ctx context.Context, tx *Transaction) (error, error) {
+	warpMsg, ok := b.warpMessages[tx.ID()]
+	if !ok {
+		return nil, nil
+	}
+	select {
+	case warpErr := <-warpMsg.verifiedChan:
+		return warpErr, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// executeTx ensures [tx] can pay fees and then executes it on [tsv].
+func (b *StatelessBlock) executeTx(
+	ctx context.Context,
+	tx *Transaction,
+	feeManager *FeeManager,
+	reads map[string]uint16,
+	sm StateManager,
+	r Rules,
+	tsv *tstate.TStateView,
+	t int64,
+	warpErr error,
+) (*Result, *TxTrace, error) {
+	tracing := b.vm.GetTransactionTracing()
+	if tracing {
+		tsv.EnableTracing()
+	}
+
+	// Ensure we have enough funds to pay fees
+	if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
+		return nil, nil, err
+	}
+	result, err := tx.Execute(ctx, feeManager, reads, sm, r, tsv, t, warpErr)
+	if err != nil || !tracing {
+		return result, nil, err
+	}
+	return result, newTxTrace(tsv, reads, result, feeManager), nil
+}
+
+// speculation is the result of optimistically executing a transaction
+// before all previous transactions in the block are committed.
+type speculation struct {
+	done chan struct{}
+
+	reads    map[string]uint16
+	storage  map[string][]byte
+	warpErr  error
+	fetchErr error // aborts block execution
+
+	tsv    *tstate.TStateView
+	result *Result
+	trace  *TxTrace
+	err    error // may be caused by a stale read
+}
+
+// executeOptimistic executes transactions in the style of Block-STM. Every
+// transaction is speculatively executed (in parallel) on a tracked
+// [tstate.TStateView], regardless of whether its [StateKeys] conflict with
+// those of other transactions. Speculative executions are then validated and
+// committed in order. If any value read by a transaction was modified by a
+// transaction committed after it was read, the transaction is aborted and
+// re-executed (which can't fail validation because all previous transactions
+// are committed).
+//
+// This produces the same results as [Execute] but is much faster when
+// transactions declare overlapping [StateKeys] that they rarely modify.
+func (b *StatelessBlock) executeOptimistic(
+	ctx context.Context,
+	im state.Immutable,
+	feeManager *FeeManager,
+	r Rules,
+) ([]*Result, *tstate.TState, error) {
+	var (
+		sm      = b.vm.StateManager()
+		numTxs  = len(b.Txs)
+		t       = b.GetTimestamp()
+		f       = newFetcher(im, numTxs)
+		metrics = b.vm.GetExecutorVerifyRecorder()
+
+		ts           = tstate.New(numTxs * 2) // TODO: tune this heuristic
+		results      = make([]*Result, numTxs)
+		traces       = make([]*TxTrace, numTxs)
+		speculations = make([]*speculation, numTxs)
+		stateKeys    = make([]set.Set[string], numTxs)
+	)
+	for i, tx := range b.Txs {
+		txStateKeys, err := tx.StateKeys(sm)
+		if err != nil {
+			return nil, nil, err
+		}
+		stateKeys[i] = txStateKeys
+		speculations[i] = &speculation{done: make(chan struct{})}
+	}
+
+	// Speculatively execute all transactions
+	//
+	// Speculation is stopped before we wait for workers to exit (otherwise
+	// we may wait for a worker blocked on a warp message that will never be
+	// verified).
+	sctx, cancel := context.WithCancel(ctx)
+	var (
+		wg    sync.WaitGroup
+		tasks = make(chan int, numTxs)
+	)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	for i := 0; i < numTxs; i++ {
+		tasks <- i
+	}
+	close(tasks)
+	for w := 0; w < b.vm.GetTransactionExecutionCores(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range tasks {
+				if sctx.Err() != nil {
+					return
+				}
+				b.speculate(sctx, f, ts, feeManager, sm, r, t, stateKeys[i], b.Txs[i], speculations[i])
+				if metrics != nil {
+					metrics.RecordExecutable()
+				}
+			}
+		}()
+	}
+
+	// Validate and commit transactions in order
+	for i, tx := range b.Txs {
+		s := speculations[i]
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		if s.fetchErr != nil {
+			return nil, nil, s.fetchErr
+		}
+		tsv, result, trace := s.tsv, s.result, s.trace
+		if s.err != nil || !tsv.Validate(ctx) {
+			if metrics != nil {
+				metrics.RecordAborted()
+			}
+			tsv = ts.NewView(stateKeys[i], s.storage)
+			var err error
+			result, trace, err = b.executeTx(ctx, tx, feeManager, s.reads, sm, r, tsv, t, s.warpErr)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		results[i] = result
+		traces[i] = trace
+
+		// Update block metadata with units actually consumed
+		if ok, d := feeManager.Consume(result.Consumed, r.GetMaxBlockUnits()); !ok {
+			return nil, nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+		}
+
+		// Commit results to parent [TState]
+		tsv.Commit()
+	}
+	if b.vm.GetTransactionTracing() {
+		b.traces = traces
+	}
 
 	// Return tstate that can be used to add block-level keys to state
 	return results, ts, nil
 }
+
+func (b *StatelessBlock) speculate(
+	ctx context.Context,
+	f *fetcher,
+	ts *tstate.TState,
+	feeManager *FeeManager,
+	sm StateManager,
+	r Rules,
+	t int64,
+	stateKeys set.Set[string],
+	tx *Transaction,
+	s *speculation,
+) {
+	defer close(s.done)
+
+	reads, storage, toCache, err := f.fetch(ctx, stateKeys)
+	if err != nil {
+		s.fetchErr = err
+		return
+	}
+	f.update(toCache)
+	s.reads, s.storage = reads, storage
+
+	// Wait to execute transaction until we have the warp result processed.
+	warpErr, err := b.waitWarp(ctx, tx)
+	if err != nil {
+		s.fetchErr = err
+		return
+	}
+	s.warpErr = warpErr
+
+	// Any error may be caused by a stale read, so we wait to handle it until
+	// the transaction is validated.
+	s.tsv = ts.NewTrackedView(stateKeys, storage)
+	s.result, s.trace, s.err = b.executeTx(ctx, tx, feeManager, reads, sm, r, s.tsv, t, warpErr)
+}
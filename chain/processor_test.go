@@ -0,0 +1,287 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/executor"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+const (
+	counterAction uint8 = 0
+	testAuthID    uint8 = 0
+
+	counterPrefix byte = 0x0
+	balancePrefix byte = 0x1
+)
+
+func counterKey(counter uint8) []byte {
+	return keys.EncodeChunks([]byte{counterPrefix, counter}, 1)
+}
+
+func balanceKey(addr codec.Address) []byte {
+	return keys.EncodeChunks(append([]byte{balancePrefix}, addr[:]...), 1)
+}
+
+func getUint64(ctx context.Context, im state.Immutable, k []byte) (uint64, error) {
+	v, err := im.GetValue(ctx, k)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func putUint64(ctx context.Context, mu state.Mutable, k []byte, v uint64) error {
+	return mu.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, v))
+}
+
+// testCounter increments [Counter] and outputs its new value, so the outcome
+// of every transaction depends on all transactions before it that use the
+// same counter.
+type testCounter struct {
+	Counter uint8
+}
+
+func (*testCounter) GetTypeID() uint8                { return counterAction }
+func (*testCounter) ValidRange(Rules) (int64, int64) { return -1, -1 }
+func (*testCounter) MaxComputeUnits(Rules) uint64    { return 1 }
+func (*testCounter) StateKeysMaxChunks() []uint16    { return []uint16{1} }
+func (*testCounter) OutputsWarpMessage() bool        { return false }
+func (*testCounter) Size() int                       { return consts.Uint8Len }
+func (c *testCounter) Marshal(p *codec.Packer)       { p.PackByte(c.Counter) }
+func (c *testCounter) StateKeys(codec.Address, ids.ID) []string {
+	return []string{string(counterKey(c.Counter))}
+}
+
+func (c *testCounter) Execute(
+	ctx context.Context,
+	_ Rules,
+	mu state.Mutable,
+	_ int64,
+	_ codec.Address,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, []*Event, *warp.UnsignedMessage, error) {
+	v, err := getUint64(ctx, mu, counterKey(c.Counter))
+	if err != nil {
+		return false, 1, nil, nil, nil, err
+	}
+	if err := putUint64(ctx, mu, counterKey(c.Counter), v+1); err != nil {
+		return false, 1, nil, nil, nil, err
+	}
+	return true, 1, binary.BigEndian.AppendUint64(nil, v+1), nil, nil, nil
+}
+
+// testAuth is an unsigned [Auth] for [Signer].
+type testAuth struct {
+	Signer ids.ID
+}
+
+func (*testAuth) GetTypeID() uint8                     { return testAuthID }
+func (*testAuth) ValidRange(Rules) (int64, int64)      { return -1, -1 }
+func (*testAuth) ComputeUnits(Rules) uint64            { return 1 }
+func (*testAuth) Verify(context.Context, []byte) error { return nil }
+func (a *testAuth) Actor() codec.Address               { return codec.CreateAddress(testAuthID, a.Signer) }
+func (a *testAuth) Sponsor() codec.Address             { return a.Actor() }
+func (*testAuth) Size() int                            { return consts.IDLen }
+func (a *testAuth) Marshal(p *codec.Packer)            { p.PackID(a.Signer) }
+func (a *testAuth) Sign([]byte) (Auth, error)          { return a, nil }
+func (*testAuth) MaxUnits() (uint64, uint64)           { return consts.IDLen, 1 }
+
+// testStateManager pays fees from a balance stored for each sponsor.
+type testStateManager struct{}
+
+func (*testStateManager) HeightKey() []byte    { return []byte{0x2} }
+func (*testStateManager) TimestampKey() []byte { return []byte{0x3} }
+func (*testStateManager) FeeKey() []byte       { return []byte{0x4} }
+
+func (*testStateManager) IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) []byte {
+	return append(append([]byte{0x5}, sourceChainID[:]...), msgID[:]...)
+}
+
+func (*testStateManager) OutgoingWarpKeyPrefix(txID ids.ID) []byte {
+	return append([]byte{0x6}, txID[:]...)
+}
+
+func (*testStateManager) SponsorStateKeys(addr codec.Address) []string {
+	return []string{string(balanceKey(addr))}
+}
+
+func (*testStateManager) CanDeduct(ctx context.Context, addr codec.Address, im state.Immutable, amount uint64) error {
+	balance, err := getUint64(ctx, im, balanceKey(addr))
+	if err != nil {
+		return err
+	}
+	if balance < amount {
+		return ErrInvalidBalance
+	}
+	return nil
+}
+
+func (*testStateManager) Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error {
+	balance, err := getUint64(ctx, mu, balanceKey(addr))
+	if err != nil {
+		return err
+	}
+	if balance < amount {
+		return ErrInvalidBalance
+	}
+	return putUint64(ctx, mu, balanceKey(addr), balance-amount)
+}
+
+func (*testStateManager) Refund(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error {
+	balance, err := getUint64(ctx, mu, balanceKey(addr))
+	if err != nil {
+		return err
+	}
+	return putUint64(ctx, mu, balanceKey(addr), balance+amount)
+}
+
+// testState is an immutable in-memory [state.Immutable].
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+// testProcessorVM is the subset of [VM] used by [StatelessBlock.Execute].
+type testProcessorVM struct {
+	VM
+
+	optimistic bool
+}
+
+func (*testProcessorVM) StateManager() StateManager                  { return &testStateManager{} }
+func (*testProcessorVM) GetTransactionExecutionCores() int           { return 4 }
+func (*testProcessorVM) GetTransactionTracing() bool                 { return false }
+func (*testProcessorVM) GetExecutorVerifyRecorder() executor.Metrics { return nil }
+func (vm *testProcessorVM) GetOptimisticTransactionExecution() bool  { return vm.optimistic }
+
+func TestExecuteOptimistic(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	chainID := ids.GenerateTestID()
+	r := NewMockRules(ctrl)
+	r.EXPECT().ChainID().Return(chainID).AnyTimes()
+	r.EXPECT().NetworkID().Return(uint32(1)).AnyTimes()
+	r.EXPECT().GetValidityWindow().Return(validityWindow).AnyTimes()
+	r.EXPECT().GetMaxActionsPerTx().Return(uint8(2)).AnyTimes()
+	r.EXPECT().GetMaxBlockUnits().Return(Dimensions{1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000}).AnyTimes()
+	r.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetSponsorStateKeysMaxChunks().Return([]uint16{1}).AnyTimes()
+	r.EXPECT().GetStorageKeyReadUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueReadUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageKeyAllocateUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueAllocateUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageKeyWriteUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueWriteUnits().Return(uint64(1)).AnyTimes()
+
+	actionRegistry := codec.NewTypeParser[Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(
+		counterAction,
+		func(p *codec.Packer, _ *warp.Message) (Action, error) {
+			return &testCounter{Counter: p.UnpackByte()}, p.Err()
+		},
+		false,
+	))
+	authRegistry := codec.NewTypeParser[Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(
+		testAuthID,
+		func(p *codec.Packer, _ *warp.Message) (Auth, error) {
+			var a testAuth
+			p.UnpackID(true, &a.Signer)
+			return &a, p.Err()
+		},
+		false,
+	))
+
+	// Fund a few sponsors
+	const timestamp int64 = 10_000
+	sponsors := make([]*testAuth, 3)
+	im := testState{}
+	for i := range sponsors {
+		sponsors[i] = &testAuth{Signer: ids.GenerateTestID()}
+		im[string(balanceKey(sponsors[i].Sponsor()))] = binary.BigEndian.AppendUint64(nil, 1_000)
+	}
+
+	// Almost every transaction conflicts with the one before it (through the
+	// counter it increments or the balance its sponsor pays fees from). The
+	// last sponsor cannot pay for all of its transactions.
+	txs := make([]*Transaction, 0, 24)
+	for i := 0; i < cap(txs); i++ {
+		actions := []Action{&testCounter{Counter: uint8(i % 2)}}
+		if i%3 == 0 {
+			actions = append(actions, &testCounter{Counter: uint8(2 + i%4)})
+		}
+		tx, err := NewTx(&Base{Timestamp: timestamp, ChainID: chainID, MaxFee: 1_000}, nil, actions).Sign(
+			sponsors[i%len(sponsors)],
+			actionRegistry,
+			authRegistry,
+		)
+		require.NoError(err)
+		txs = append(txs, tx)
+	}
+	im[string(balanceKey(sponsors[2].Sponsor()))] = binary.BigEndian.AppendUint64(nil, 150)
+
+	execute := func(optimistic bool) ([]*Result, map[string]maybe.Maybe[[]byte], error) {
+		feeManager := NewFeeManager(nil)
+		for d := Dimension(0); d < FeeDimensions; d++ {
+			feeManager.SetUnitPrice(d, 1)
+		}
+		tracer, err := trace.New(&trace.Config{Enabled: false})
+		require.NoError(err)
+		b := &StatelessBlock{
+			StatefulBlock: &StatefulBlock{Tmstmp: timestamp, Txs: txs},
+			vm:            &testProcessorVM{optimistic: optimistic},
+		}
+		results, ts, err := b.Execute(context.Background(), tracer, im, feeManager, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return results, ts.ChangedKeys(), nil
+	}
+
+	// Serial execution fails when the last sponsor can no longer pay
+	_, _, serialErr := execute(false)
+	_, _, optimisticErr := execute(true)
+	require.ErrorIs(serialErr, ErrInvalidBalance)
+	require.ErrorIs(optimisticErr, ErrInvalidBalance)
+
+	// Both modes produce the same results and state for a valid block
+	im[string(balanceKey(sponsors[2].Sponsor()))] = binary.BigEndian.AppendUint64(nil, 1_000)
+	serial, serialChanges, err := execute(false)
+	require.NoError(err)
+	require.Len(serial, len(txs))
+	require.Equal(binary.BigEndian.AppendUint64(nil, uint64(len(txs)/2)), serial[len(txs)-1].Outputs[0])
+	for i := 0; i < 5; i++ {
+		optimistic, optimisticChanges, err := execute(true)
+		require.NoError(err)
+		require.Equal(serial, optimistic)
+		require.Equal(serialChanges, optimisticChanges)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Divergence is the first difference found between the re-execution of an
+// accepted block and what was accepted.
+type Divergence struct {
+	Height  uint64 `json:"height"`
+	BlockID ids.ID `json:"blockID"`
+
+	// TxIndex is -1 if the divergence can't be attributed to a transaction.
+	TxIndex int    `json:"txIndex"`
+	TxID    ids.ID `json:"txID"`
+	Key     []byte `json:"key"`
+
+	Reason   string `json:"reason"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func (d *Divergence) String() string {
+	s := fmt.Sprintf("height=%d blkID=%s", d.Height, d.BlockID)
+	if d.TxIndex >= 0 {
+		s += fmt.Sprintf(" tx=%d txID=%s", d.TxIndex, d.TxID)
+	}
+	if len(d.Key) > 0 {
+		s += fmt.Sprintf(" key=%x", d.Key)
+	}
+	return fmt.Sprintf("%s %s: expected=%s actual=%s", s, d.Reason, d.Expected, d.Actual)
+}
+
+// Replay re-executes the accepted block [b] on [parent] (the post-execution
+// state of its parent) and returns its results and the keys it changed.
+//
+// Unlike [Verify], Replay does not check the block against the roots it
+// commits to (so that callers can report where execution diverged) and
+// [parent] doesn't need to be a merkledb view. Warp messages are not
+// re-verified and are assumed to have the verification results recorded in
+// the block.
+func (b *StatelessBlock) Replay(
+	ctx context.Context,
+	parent state.Immutable,
+) ([]*Result, map[string]maybe.Maybe[[]byte], error) {
+	ctx, span := b.vm.Tracer().Start(ctx, "StatelessBlock.Replay")
+	defer span.End()
+
+	// Accepted blocks are not populated when parsed, so we do so here
+	if b.warpMessages == nil {
+		if err := b.populateTxs(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	r := b.vm.Rules(b.Tmstmp)
+	for _, msg := range b.warpMessages {
+		verified := b.WarpResults.Contains(uint(msg.warpNum))
+		msg.verifiedChan <- warpResultErr(r, msg.msg.SourceChainID, verified)
+		msg.verified = verified
+	}
+
+	// Fetch parent metadata
+	heightKey := HeightKey(b.vm.StateManager().HeightKey())
+	parentHeightRaw, err := parent.GetValue(ctx, heightKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	timestampKey := TimestampKey(b.vm.StateManager().TimestampKey())
+	parentTimestampRaw, err := parent.GetValue(ctx, timestampKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentTimestamp := int64(binary.BigEndian.Uint64(parentTimestampRaw))
+	feeKey := FeeKey(b.vm.StateManager().FeeKey())
+	feeRaw, err := parent.GetValue(ctx, feeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentFeeManager := NewFeeManager(feeRaw)
+	feeManager, err := parentFeeManager.ComputeNext(parentTimestamp, b.Tmstmp, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Process transactions
+	results, ts, err := b.Execute(ctx, b.vm.Tracer(), parent, feeManager, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Update chain metadata
+	heightKeyStr := string(heightKey)
+	timestampKeyStr := string(timestampKey)
+	feeKeyStr := string(feeKey)
+	tsv := ts.NewView(set.Of(heightKeyStr, timestampKeyStr, feeKeyStr), map[string][]byte{
+		heightKeyStr:    parentHeightRaw,
+		timestampKeyStr: parentTimestampRaw,
+		feeKeyStr:       parentFeeManager.Bytes(),
+	})
+	if err := tsv.Insert(ctx, heightKey, binary.BigEndian.AppendUint64(nil, b.Hght)); err != nil {
+		return nil, nil, err
+	}
+	if err := tsv.Insert(ctx, timestampKey, binary.BigEndian.AppendUint64(nil, uint64(b.Tmstmp))); err != nil {
+		return nil, nil, err
+	}
+	if err := tsv.Insert(ctx, feeKey, feeManager.Bytes()); err != nil {
+		return nil, nil, err
+	}
+	tsv.Commit()
+
+	// Ensure signatures are valid
+	if err := b.sigJob.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return results, ts.ChangedKeys(), nil
+}
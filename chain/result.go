@@ -11,27 +11,55 @@ import (
 
 type Result struct {
 	Success bool
-	Output  []byte
+	// Error is the reason a transaction failed (empty if [Success]).
+	Error []byte
+	// Outputs contains the output of each [Action] in the transaction, in the
+	// same order (empty if not [Success]).
+	Outputs [][]byte
+	// Events contains all events emitted by each [Action] in the
+	// transaction, in order (empty if not [Success]).
+	Events []*Event
 
 	Consumed Dimensions
 	Fee      uint64
 
 	WarpMessage *warp.UnsignedMessage
+	// WarpError is the reason the warp message included in the transaction
+	// was not verified (empty if it was verified or there is none). It is one
+	// of [ErrDisabledChainID], [ErrDuplicateWarpMessage], or
+	// [ErrWarpNotVerified] so that it is the same on every node.
+	WarpError []byte
 }
 
 func (r *Result) Size() int {
-	size := consts.BoolLen + codec.BytesLen(r.Output) + DimensionsLen + consts.Uint64Len
+	size := consts.BoolLen + codec.BytesLen(r.Error) + consts.ByteLen + DimensionsLen + consts.Uint64Len
+	for _, output := range r.Outputs {
+		size += codec.BytesLen(output)
+	}
+	size += consts.IntLen + codec.CummSize(r.Events)
 	if r.WarpMessage != nil {
 		size += codec.BytesLen(r.WarpMessage.Bytes())
 	} else {
 		size += codec.BytesLen(nil)
 	}
+	size += codec.BytesLen(r.WarpError)
 	return size
 }
 
 func (r *Result) Marshal(p *codec.Packer) error {
 	p.PackBool(r.Success)
-	p.PackBytes(r.Output)
+	p.PackBytes(r.Error)
+	if len(r.Outputs) > int(consts.MaxUint8) {
+		return ErrTooManyActions
+	}
+	p.PackByte(uint8(len(r.Outputs)))
+	for _, output := range r.Outputs {
+		p.PackBytes(output)
+	}
+	p.PackInt(len(r.Events))
+	for _, event := range r.Events {
+		event.Marshal(p)
+	}
 	p.PackFixedBytes(r.Consumed.Bytes())
 	p.PackUint64(r.Fee)
 	var warpBytes []byte
@@ -39,6 +67,7 @@ func (r *Result) Marshal(p *codec.Packer) error {
 		warpBytes = r.WarpMessage.Bytes()
 	}
 	p.PackBytes(warpBytes)
+	p.PackBytes(r.WarpError)
 	return nil
 }
 
@@ -58,10 +87,32 @@ func UnmarshalResult(p *codec.Packer) (*Result, error) {
 	result := &Result{
 		Success: p.UnpackBool(),
 	}
-	p.UnpackBytes(consts.MaxInt, false, &result.Output)
-	if len(result.Output) == 0 {
+	p.UnpackBytes(consts.MaxInt, false, &result.Error)
+	if len(result.Error) == 0 {
 		// Enforce object standardization
-		result.Output = nil
+		result.Error = nil
+	}
+	numOutputs := p.UnpackByte()
+	if numOutputs > 0 {
+		result.Outputs = make([][]byte, numOutputs)
+		for i := range result.Outputs {
+			p.UnpackBytes(consts.MaxInt, false, &result.Outputs[i])
+			if len(result.Outputs[i]) == 0 {
+				// Enforce object standardization
+				result.Outputs[i] = nil
+			}
+		}
+	}
+	numEvents := p.UnpackInt(false)
+	if numEvents > 0 {
+		result.Events = []*Event{} // don't preallocate all to avoid DoS
+		for i := 0; i < numEvents; i++ {
+			event, err := UnmarshalEvent(p)
+			if err != nil {
+				return nil, err
+			}
+			result.Events = append(result.Events, event)
+		}
 	}
 	consumedRaw := make([]byte, DimensionsLen)
 	p.UnpackFixedBytes(DimensionsLen, &consumedRaw)
@@ -80,6 +131,11 @@ func UnmarshalResult(p *codec.Packer) (*Result, error) {
 		}
 		result.WarpMessage = msg
 	}
+	p.UnpackBytes(consts.MaxInt, false, &result.WarpError)
+	if len(result.WarpError) == 0 {
+		// Enforce object standardization
+		result.WarpError = nil
+	}
 	return result, p.Err()
 }
 
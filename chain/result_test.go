@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func TestResultsMultipleOutputs(t *testing.T) {
+	require := require.New(t)
+
+	results := []*Result{
+		{
+			Success:  true,
+			Outputs:  [][]byte{{1}, nil, {2, 3}},
+			Consumed: Dimensions{1, 2, 3, 4, 5},
+			Fee:      10,
+		},
+		{
+			Error:    []byte("failed"),
+			Consumed: Dimensions{1, 1, 1, 1, 1},
+			Fee:      5,
+		},
+	}
+	b, err := MarshalResults(results)
+	require.NoError(err)
+	size := 0
+	for _, result := range results {
+		size += result.Size()
+	}
+	require.Len(b, consts.IntLen+size)
+
+	parsed, err := UnmarshalResults(b)
+	require.NoError(err)
+	require.Equal(results, parsed)
+
+	// Results can't have more outputs than a transaction can have actions
+	_, err = MarshalResults([]*Result{{Success: true, Outputs: make([][]byte, 256)}})
+	require.ErrorIs(err, ErrTooManyActions)
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ Auth = (*simulatedAuth)(nil)
+
+// simulatedAuth is a placeholder [Auth] for transactions that are simulated
+// before they are signed. It does not consume any units and always verifies.
+type simulatedAuth struct {
+	actor   codec.Address
+	sponsor codec.Address
+}
+
+func (a *simulatedAuth) GetTypeID() uint8 { return a.actor[0] }
+
+func (*simulatedAuth) ValidRange(Rules) (int64, int64) { return -1, -1 }
+
+func (*simulatedAuth) Marshal(*codec.Packer) {}
+
+func (*simulatedAuth) Size() int { return 0 }
+
+func (*simulatedAuth) ComputeUnits(Rules) uint64 { return 0 }
+
+func (*simulatedAuth) Verify(context.Context, []byte) error { return nil }
+
+func (a *simulatedAuth) Actor() codec.Address { return a.actor }
+
+func (a *simulatedAuth) Sponsor() codec.Address { return a.sponsor }
+
+// UnmarshalUnsignedTx parses the digest of a transaction (see
+// [Transaction.Digest]) that can be provided to [Transaction.Simulate] on
+// behalf of [actor] and [sponsor] (with the version of each type that is valid
+// at [timestamp], see [UnmarshalTx]).
+//
+// Because the transaction has no [Auth], the bandwidth and compute used by
+// [Auth] are not included in any simulated [Result].
+func UnmarshalUnsignedTx(
+	p *codec.Packer,
+	timestamp int64,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
+	actor codec.Address,
+	sponsor codec.Address,
+) (*Transaction, error) {
+	start := p.Offset()
+	tx, _, err := unmarshalDigest(p, timestamp, actionRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if actorType := actor[0]; !isAuthType(authRegistry, actorType) {
+		return nil, fmt.Errorf("%w: actorType (%d) is not a registered authType", ErrInvalidActor, actorType)
+	}
+	if sponsorType := sponsor[0]; !isAuthType(authRegistry, sponsorType) {
+		return nil, fmt.Errorf("%w: sponsorType (%d) is not a registered authType", ErrInvalidSponsor, sponsorType)
+	}
+	tx.Auth = &simulatedAuth{actor, sponsor}
+	tx.authWindow = codec.AlwaysValid
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	tx.digest = p.Bytes()[start:p.Offset()]
+	tx.bytes = tx.digest
+	tx.size = len(tx.bytes)
+	tx.id = utils.ToID(tx.bytes)
+	return tx, nil
+}
+
+// Simulate executes [t] on top of [im] (without modifying it) as if it were
+// included in a block at [timestamp] and returns its [Result] and all keys
+// it touched (in sorted order).
+//
+// [Auth.Verify] is never called, any warp message is treated as verified, and
+// [Base.MaxFee] is not enforced (so it can be set to the fee returned).
+func (t *Transaction) Simulate(
+	ctx context.Context,
+	feeManager *FeeManager,
+	sm StateManager,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+) (*Result, []string, error) {
+	stateKeys, err := t.StateKeys(sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	reads, storage, _, err := newFetcher(im, len(stateKeys)).fetch(ctx, stateKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	tsv := tstate.New(1).NewTrackedView(stateKeys, storage)
+	if err := t.preExecute(ctx, feeManager, sm, r, tsv, timestamp, false); err != nil {
+		return nil, nil, err
+	}
+	result, err := t.Execute(ctx, feeManager, reads, sm, r, tsv, timestamp, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	touched := tsv.TouchedKeys().List()
+	slices.Sort(touched)
+	return result, touched, nil
+}
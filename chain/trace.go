@@ -0,0 +1,145 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// TxTrace is a record of how a transaction was executed in a block. It is
+// only collected if [VM.GetTransactionTracing] is enabled.
+type TxTrace struct {
+	// Accesses contains every read and modification of a key (in order),
+	// including those of actions that were reverted.
+	Accesses []*tstate.Access
+
+	// Reads, Allocates, and Writes contain the number of chunks of each key
+	// that were charged for.
+	Reads     map[string]uint16
+	Allocates map[string]uint16
+	Writes    map[string]uint16
+
+	Consumed   Dimensions
+	UnitPrices Dimensions
+}
+
+func newTxTrace(
+	tsv *tstate.TStateView,
+	reads map[string]uint16,
+	result *Result,
+	feeManager *FeeManager,
+) *TxTrace {
+	allocates, writes := tsv.KeyOperations()
+	return &TxTrace{
+		Accesses:   tsv.Accesses(),
+		Reads:      maps.Clone(reads),
+		Allocates:  maps.Clone(allocates),
+		Writes:     maps.Clone(writes),
+		Consumed:   result.Consumed,
+		UnitPrices: feeManager.UnitPrices(),
+	}
+}
+
+// Fees returns the fee paid for each [Dimension].
+func (t *TxTrace) Fees() Dimensions {
+	var fees Dimensions
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		fees[i] = t.Consumed[i] * t.UnitPrices[i]
+	}
+	return fees
+}
+
+func (t *TxTrace) Marshal(p *codec.Packer) {
+	p.PackInt(len(t.Accesses))
+	for _, access := range t.Accesses {
+		p.PackByte(byte(access.Type))
+		p.PackBytes(access.Key)
+		packMaybe(p, access.Before)
+		packMaybe(p, access.After)
+	}
+	packChunks(p, t.Reads)
+	packChunks(p, t.Allocates)
+	packChunks(p, t.Writes)
+	p.PackFixedBytes(t.Consumed.Bytes())
+	p.PackFixedBytes(t.UnitPrices.Bytes())
+}
+
+func packMaybe(p *codec.Packer, v maybe.Maybe[[]byte]) {
+	p.PackBool(v.HasValue())
+	p.PackBytes(v.Value())
+}
+
+// packChunks packs [m] in key order (so the encoding is deterministic).
+func packChunks(p *codec.Packer, m map[string]uint16) {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	p.PackInt(len(keys))
+	for _, k := range keys {
+		p.PackString(k)
+		p.PackInt(int(m[k]))
+	}
+}
+
+func UnmarshalTxTrace(p *codec.Packer) (*TxTrace, error) {
+	t := &TxTrace{}
+	numAccesses := p.UnpackInt(false)
+	if numAccesses > 0 {
+		t.Accesses = []*tstate.Access{} // don't preallocate all to avoid DoS
+		for i := 0; i < numAccesses && p.Err() == nil; i++ {
+			access := &tstate.Access{Type: tstate.AccessType(p.UnpackByte())}
+			p.UnpackBytes(consts.MaxInt, false, &access.Key)
+			access.Before = unpackMaybe(p)
+			access.After = unpackMaybe(p)
+			t.Accesses = append(t.Accesses, access)
+		}
+	}
+	t.Reads = unpackChunks(p)
+	t.Allocates = unpackChunks(p)
+	t.Writes = unpackChunks(p)
+	consumedRaw := make([]byte, DimensionsLen)
+	p.UnpackFixedBytes(DimensionsLen, &consumedRaw)
+	consumed, err := UnpackDimensions(consumedRaw)
+	if err != nil {
+		return nil, err
+	}
+	t.Consumed = consumed
+	pricesRaw := make([]byte, DimensionsLen)
+	p.UnpackFixedBytes(DimensionsLen, &pricesRaw)
+	prices, err := UnpackDimensions(pricesRaw)
+	if err != nil {
+		return nil, err
+	}
+	t.UnitPrices = prices
+	return t, p.Err()
+}
+
+func unpackMaybe(p *codec.Packer) maybe.Maybe[[]byte] {
+	exists := p.UnpackBool()
+	var v []byte
+	p.UnpackBytes(consts.MaxInt, false, &v)
+	if !exists {
+		return maybe.Nothing[[]byte]()
+	}
+	if v == nil {
+		// Enforce object standardization
+		v = []byte{}
+	}
+	return maybe.Some(v)
+}
+
+func unpackChunks(p *codec.Packer) map[string]uint16 {
+	count := p.UnpackInt(false)
+	m := map[string]uint16{}
+	for i := 0; i < count && p.Err() == nil; i++ {
+		k := p.UnpackString(false)
+		m[k] = uint16(p.UnpackInt(false))
+	}
+	return m
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -33,9 +34,10 @@ type Transaction struct {
 	Base        *Base         `json:"base"`
 	WarpMessage *warp.Message `json:"warpMessage"`
 
-	// TODO: turn [Action] into an array (#335)
-	Action Action `json:"action"`
-	Auth   Auth   `json:"auth"`
+	// Actions are executed in order and atomically: if any [Action] fails,
+	// the state changes of all [Actions] are reverted (but the fee is still paid).
+	Actions []Action `json:"actions"`
+	Auth    Auth     `json:"auth"`
 
 	digest         []byte
 	bytes          []byte
@@ -48,6 +50,12 @@ type Transaction struct {
 	// warpID from the same sourceChainID to be accepted.
 	warpID    ids.ID
 	stateKeys set.Set[string]
+
+	// actionWindows and authWindow are the [codec.Window] of the registered
+	// version of each type that was used to parse the transaction (which is
+	// selected by the timestamp the transaction was parsed at).
+	actionWindows []codec.Window
+	authWindow    codec.Window
 }
 
 type WarpResult struct {
@@ -55,11 +63,11 @@ type WarpResult struct {
 	VerifyErr error
 }
 
-func NewTx(base *Base, wm *warp.Message, act Action) *Transaction {
+func NewTx(base *Base, wm *warp.Message, actions []Action) *Transaction {
 	return &Transaction{
 		Base:        base,
 		WarpMessage: wm,
-		Action:      act,
+		Actions:     actions,
 	}
 }
 
@@ -67,19 +75,19 @@ func (t *Transaction) Digest() ([]byte, error) {
 	if len(t.digest) > 0 {
 		return t.digest, nil
 	}
-	actionID := t.Action.GetTypeID()
 	var warpBytes []byte
 	if t.WarpMessage != nil {
 		warpBytes = t.WarpMessage.Bytes()
 	}
 	size := t.Base.Size() +
 		codec.BytesLen(warpBytes) +
-		consts.ByteLen + t.Action.Size()
+		actionsSize(t.Actions)
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 	t.Base.Marshal(p)
 	p.PackBytes(warpBytes)
-	p.PackByte(actionID)
-	t.Action.Marshal(p)
+	if err := marshalActions(p, t.Actions); err != nil {
+		return nil, err
+	}
 	return p.Bytes(), p.Err()
 }
 
@@ -109,7 +117,7 @@ func (t *Transaction) Sign(
 		return nil, err
 	}
 	p = codec.NewReader(p.Bytes(), consts.MaxInt)
-	return UnmarshalTx(p, actionRegistry, authRegistry)
+	return UnmarshalTx(p, time.Now().UnixMilli(), actionRegistry, authRegistry)
 }
 
 func (t *Transaction) Bytes() []byte { return t.bytes }
@@ -120,18 +128,51 @@ func (t *Transaction) ID() ids.ID { return t.id }
 
 func (t *Transaction) Expiry() int64 { return t.Base.Timestamp }
 
+func (t *Transaction) Nonce() uint64 { return t.Base.Nonce }
+
 func (t *Transaction) MaxFee() uint64 { return t.Base.MaxFee }
 
+// ActionID is the unique identifier passed to the [Action] at index [i] during
+// [StateKeys] and [Execute] (often used to derive the ID of newly created objects).
+//
+// The first [Action] is identified by the transaction ID, so a transaction with a
+// single [Action] behaves exactly as it would if [Actions] were not an array.
+func (t *Transaction) ActionID(i int) ids.ID {
+	return CreateActionID(t.id, i)
+}
+
+// CreateActionID derives the ID of the [Action] at index [i] of the transaction [txID].
+func CreateActionID(txID ids.ID, i int) ids.ID {
+	if i == 0 {
+		return txID
+	}
+	return txID.Prefix(uint64(i))
+}
+
+// outputsWarpMessage returns true if any [Action] produces a warp message.
+func (t *Transaction) outputsWarpMessage() bool {
+	for _, action := range t.Actions {
+		if action.OutputsWarpMessage() {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Transaction) StateKeys(sm StateManager) (set.Set[string], error) {
 	if t.stateKeys != nil {
 		return t.stateKeys, nil
 	}
 
 	// Verify the formatting of state keys passed by the controller
-	actionKeys := t.Action.StateKeys(t.Auth.Actor(), t.ID())
 	sponsorKeys := sm.SponsorStateKeys(t.Auth.Sponsor())
-	stateKeys := set.NewSet[string](len(actionKeys) + len(sponsorKeys))
-	for _, arr := range [][]string{actionKeys, sponsorKeys} {
+	stateKeys := set.NewSet[string](len(sponsorKeys))
+	allKeys := make([][]string, 0, len(t.Actions)+1)
+	for i, action := range t.Actions {
+		allKeys = append(allKeys, action.StateKeys(t.Auth.Actor(), t.ActionID(i)))
+	}
+	allKeys = append(allKeys, sponsorKeys)
+	for _, arr := range allKeys {
 		for _, k := range arr {
 			if !keys.Valid(k) {
 				return nil, ErrInvalidKeyValue
@@ -140,13 +181,18 @@ func (t *Transaction) StateKeys(sm StateManager) (set.Set[string], error) {
 		}
 	}
 
+	// Add the nonce of the sponsor (if enabled)
+	if k, ok := nonceKey(sm, t.Auth.Sponsor()); ok {
+		stateKeys.Add(string(k))
+	}
+
 	// Add keys used to manage warp operations
 	if t.WarpMessage != nil {
 		p := sm.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
 		k := keys.EncodeChunks(p, MaxIncomingWarpChunks)
 		stateKeys.Add(string(k))
 	}
-	if t.Action.OutputsWarpMessage() {
+	if t.outputsWarpMessage() {
 		p := sm.OutgoingWarpKeyPrefix(t.id)
 		k := keys.EncodeChunks(p, MaxOutgoingWarpChunks)
 		stateKeys.Add(string(k))
@@ -157,6 +203,25 @@ func (t *Transaction) StateKeys(sm StateManager) (set.Set[string], error) {
 	return stateKeys, nil
 }
 
+// ReplacementKeys are the [StateKeys] of [t] that are not used to pay fees (or
+// track the nonce of its sponsor). Every transaction from a sponsor touches its
+// fee keys, so only transactions that share any other key conflict with each
+// other.
+func (t *Transaction) ReplacementKeys(sm StateManager) (set.Set[string], error) {
+	stateKeys, err := t.StateKeys(sm)
+	if err != nil {
+		return nil, err
+	}
+	sponsorKeys := sm.SponsorStateKeys(t.Auth.Sponsor())
+	replacementKeys := set.NewSet[string](stateKeys.Len())
+	replacementKeys.Union(stateKeys)
+	replacementKeys.Remove(sponsorKeys...)
+	if k, ok := nonceKey(sm, t.Auth.Sponsor()); ok {
+		replacementKeys.Remove(string(k))
+	}
+	return replacementKeys, nil
+}
+
 // Sponsor is the [codec.Address] that pays fees for this transaction.
 func (t *Transaction) Sponsor() codec.Address { return t.Auth.Sponsor() }
 
@@ -165,13 +230,15 @@ func (t *Transaction) Sponsor() codec.Address { return t.Auth.Sponsor() }
 func (t *Transaction) MaxUnits(sm StateManager, r Rules) (Dimensions, error) {
 	// Cacluate max compute costs
 	maxComputeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
-	maxComputeUnitsOp.Add(t.Action.MaxComputeUnits(r))
+	for _, action := range t.Actions {
+		maxComputeUnitsOp.Add(action.MaxComputeUnits(r))
+	}
 	maxComputeUnitsOp.Add(t.Auth.ComputeUnits(r))
 	if t.WarpMessage != nil {
 		maxComputeUnitsOp.Add(r.GetBaseWarpComputeUnits())
 		maxComputeUnitsOp.MulAdd(uint64(t.numWarpSigners), r.GetWarpComputeUnitsPerSigner())
 	}
-	if t.Action.OutputsWarpMessage() {
+	if t.outputsWarpMessage() {
 		// Chunks later accounted for by call to [StateKeys]
 		maxComputeUnitsOp.Add(r.GetOutgoingWarpComputeUnits())
 	}
@@ -221,22 +288,69 @@ func (t *Transaction) MaxUnits(sm StateManager, r Rules) (Dimensions, error) {
 	return Dimensions{uint64(t.Size()), maxComputeUnits, reads, allocates, writes}, nil
 }
 
+// Priority is the effective fee per unit paid by [t] (its [MaxFee] divided
+// by the units it may consume). Transactions that cannot pay the fee required
+// at [unitPrices] have a priority of 0 (and are rejected by [PreExecuteWithMaxFee]).
+func (t *Transaction) Priority(sm StateManager, r Rules, unitPrices Dimensions) (uint64, error) {
+	maxUnits, err := t.MaxUnits(sm, r)
+	if err != nil {
+		return 0, err
+	}
+	requiredFee, err := MulSum(unitPrices, maxUnits)
+	if err != nil {
+		return 0, err
+	}
+	if t.Base.MaxFee < requiredFee {
+		return 0, nil
+	}
+	totalUnitsOp := math.NewUint64Operator(0)
+	for _, units := range maxUnits {
+		totalUnitsOp.Add(units)
+	}
+	totalUnits, err := totalUnitsOp.Value()
+	if err != nil {
+		return 0, err
+	}
+	if totalUnits == 0 {
+		return t.Base.MaxFee, nil
+	}
+	return t.Base.MaxFee / totalUnits, nil
+}
+
 // EstimateMaxUnits provides a pessimistic estimate of the cost to execute a transaction. This is
 // typically used during transaction construction.
-func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessage *warp.Message) (Dimensions, error) {
+//
+// If [nonces] is true, the estimate includes the cost of [Base.Nonce] and of the
+// nonce of the [Sponsor] (see [NonceManager]).
+func EstimateMaxUnits(
+	r Rules,
+	actions []Action,
+	authFactory AuthFactory,
+	warpMessage *warp.Message,
+	nonces bool,
+) (Dimensions, error) {
 	authBandwidth, authCompute := authFactory.MaxUnits()
-	bandwidth := BaseSize + consts.ByteLen + uint64(action.Size()) + consts.ByteLen + authBandwidth
-	actionStateKeysMaxChunks := action.StateKeysMaxChunks()
+	bandwidth := BaseSize + uint64(actionsSize(actions)) + consts.ByteLen + authBandwidth
 	sponsorStateKeyMaxChunks := r.GetSponsorStateKeysMaxChunks()
-	stateKeysMaxChunks := make([]uint16, 0, len(sponsorStateKeyMaxChunks)+len(actionStateKeysMaxChunks))
+	stateKeysMaxChunks := make([]uint16, 0, len(sponsorStateKeyMaxChunks)+1)
 	stateKeysMaxChunks = append(stateKeysMaxChunks, sponsorStateKeyMaxChunks...)
-	stateKeysMaxChunks = append(stateKeysMaxChunks, actionStateKeysMaxChunks...)
+	if nonces {
+		bandwidth += consts.Uint64Len
+		stateKeysMaxChunks = append(stateKeysMaxChunks, NonceChunks)
+	}
 
 	// Estimate compute costs
 	computeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
 	computeUnitsOp.Add(authCompute)
-	computeUnitsOp.Add(action.MaxComputeUnits(r))
-	if warpMessage != nil {
+	outputsWarp := false
+	for _, action := range actions {
+		stateKeysMaxChunks = append(stateKeysMaxChunks, action.StateKeysMaxChunks()...)
+		computeUnitsOp.Add(action.MaxComputeUnits(r))
+		outputsWarp = outputsWarp || action.OutputsWarpMessage()
+	}
+	if warpMessage == nil {
+		bandwidth += uint64(codec.BytesLen(nil))
+	} else {
 		bandwidth += uint64(codec.BytesLen(warpMessage.Bytes()))
 		stateKeysMaxChunks = append(stateKeysMaxChunks, MaxIncomingWarpChunks)
 		computeUnitsOp.Add(r.GetBaseWarpComputeUnits())
@@ -246,7 +360,7 @@ func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessa
 		}
 		computeUnitsOp.MulAdd(uint64(numSigners), r.GetWarpComputeUnitsPerSigner())
 	}
-	if action.OutputsWarpMessage() {
+	if outputsWarp {
 		stateKeysMaxChunks = append(stateKeysMaxChunks, MaxOutgoingWarpChunks)
 		computeUnitsOp.Add(r.GetOutgoingWarpComputeUnits())
 	}
@@ -261,7 +375,7 @@ func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessa
 	readsOp := math.NewUint64Operator(0)
 	allocatesOp := math.NewUint64Operator(0)
 	writesOp := math.NewUint64Operator(0)
-	for maxChunks := range stateKeysMaxChunks {
+	for _, maxChunks := range stateKeysMaxChunks {
 		// Compute key costs
 		readsOp.Add(r.GetStorageKeyReadUnits())
 		allocatesOp.Add(r.GetStorageKeyAllocateUnits())
@@ -294,23 +408,63 @@ func (t *Transaction) PreExecute(
 	r Rules,
 	im state.Immutable,
 	timestamp int64,
+) error {
+	return t.preExecute(ctx, feeManager, s, r, im, timestamp, false)
+}
+
+// PreExecuteWithMaxFee is [PreExecute] that also requires [Base.MaxFee] to
+// cover the fee of the max units [t] could use. It is only used when adding
+// transactions to the mempool and building blocks (blocks were never required
+// to enforce [Base.MaxFee], so verifying them must not either).
+func (t *Transaction) PreExecuteWithMaxFee(
+	ctx context.Context,
+	feeManager *FeeManager,
+	s StateManager,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+) error {
+	return t.preExecute(ctx, feeManager, s, r, im, timestamp, true)
+}
+
+// preExecute is [PreExecute] that only requires [Base.MaxFee] to cover the
+// fee of the max units [t] could use if [enforceMaxFee] is set.
+func (t *Transaction) preExecute(
+	ctx context.Context,
+	feeManager *FeeManager,
+	s StateManager,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+	enforceMaxFee bool,
 ) error {
 	if err := t.Base.Execute(r.ChainID(), r, timestamp); err != nil {
 		return err
 	}
-	start, end := t.Action.ValidRange(r)
-	if start >= 0 && timestamp < start {
-		return ErrActionNotActivated
+	if len(t.Actions) > int(r.GetMaxActionsPerTx()) {
+		return ErrTooManyActions
 	}
-	if end >= 0 && timestamp > end {
-		return ErrActionNotActivated
+	for i, action := range t.Actions {
+		if err := t.actionWindows[i].Verify(timestamp); err != nil {
+			return actionWindowErr(err, action.GetTypeID())
+		}
+		start, end := action.ValidRange(r)
+		if start >= 0 && timestamp < start {
+			return ErrActionNotActivated
+		}
+		if end >= 0 && timestamp > end {
+			return ErrActionDeprecated
+		}
+	}
+	if err := t.authWindow.Verify(timestamp); err != nil {
+		return authWindowErr(err, t.Auth.GetTypeID())
 	}
-	start, end = t.Auth.ValidRange(r)
+	start, end := t.Auth.ValidRange(r)
 	if start >= 0 && timestamp < start {
 		return ErrAuthNotActivated
 	}
 	if end >= 0 && timestamp > end {
-		return ErrAuthNotActivated
+		return ErrAuthDeprecated
 	}
 	maxUnits, err := t.MaxUnits(s, r)
 	if err != nil {
@@ -320,7 +474,16 @@ func (t *Transaction) PreExecute(
 	if err != nil {
 		return err
 	}
-	return s.CanDeduct(ctx, t.Auth.Sponsor(), im, maxFee)
+	if enforceMaxFee && maxFee > t.Base.MaxFee {
+		return fmt.Errorf("%w: required=%d max=%d", ErrMaxFeeTooLow, maxFee, t.Base.MaxFee)
+	}
+	if err := s.CanDeduct(ctx, t.Auth.Sponsor(), im, maxFee); err != nil {
+		return err
+	}
+
+	// Nonces are checked last, so a transaction that fails with [ErrNonceTooHigh]
+	// is otherwise valid (and can be held until the gap is filled).
+	return t.verifyNonce(ctx, s, im)
 }
 
 // Execute after knowing a transaction can pay a fee. Attempt
@@ -329,6 +492,9 @@ func (t *Transaction) PreExecute(
 // ColdRead/WarmRead size is based on the amount read BEFORE
 // the block begins.
 //
+// [warpErr] is the reason the warp message of [t] could not be verified (nil
+// if it was verified or [t] has no warp message).
+//
 // Invariant: [PreExecute] is called just before [Execute]
 func (t *Transaction) Execute(
 	ctx context.Context,
@@ -338,7 +504,7 @@ func (t *Transaction) Execute(
 	r Rules,
 	ts *tstate.TStateView,
 	timestamp int64,
-	warpVerified bool,
+	warpErr error,
 ) (*Result, error) {
 	// Always charge fee first (in case [Action] moves funds)
 	maxUnits, err := t.MaxUnits(s, r)
@@ -357,6 +523,13 @@ func (t *Transaction) Execute(
 		return nil, err
 	}
 
+	// Increment the nonce of the sponsor (if enabled) as soon as the fee is paid
+	if k, ok := nonceKey(s, t.Auth.Sponsor()); ok {
+		if err := incrementNonce(ctx, ts, k); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check warp message is not duplicate
 	if t.WarpMessage != nil {
 		p := s.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
@@ -365,16 +538,22 @@ func (t *Transaction) Execute(
 		switch {
 		case err == nil:
 			// Override all errors because warp message is a duplicate
-			warpVerified = false
+			warpErr = ErrDuplicateWarpMessage
 		case errors.Is(err, database.ErrNotFound):
 			// This means there are no conflicts
 		case err != nil:
 			// An error here can indicate there is an issue with the database or that
 			// the key was not properly specified.
-			return &Result{false, utils.ErrBytes(err), maxUnits, maxFee, nil}, nil
+			return &Result{false, utils.ErrBytes(err), nil, nil, maxUnits, maxFee, nil, nil}, nil
 		}
 	}
 
+	warpVerified := t.WarpMessage != nil && warpErr == nil
+	var warpErrBytes []byte
+	if t.WarpMessage != nil && warpErr != nil {
+		warpErrBytes = utils.ErrBytes(warpErr)
+	}
+
 	// We create a temp state checkpoint to ensure we don't commit failed actions to state.
 	actionStart := ts.OpIndex()
 	handleRevert := func(rerr error) (*Result, error) {
@@ -382,27 +561,57 @@ func (t *Transaction) Execute(
 		// are set when this function is defined. If any of them are
 		// modified later, they will not be used here.
 		ts.Rollback(ctx, actionStart)
-		return &Result{false, utils.ErrBytes(rerr), maxUnits, maxFee, nil}, nil
+		return &Result{false, utils.ErrBytes(rerr), nil, nil, maxUnits, maxFee, nil, warpErrBytes}, nil
 	}
-	success, actionCUs, output, warpMessage, err := t.Action.Execute(ctx, r, ts, timestamp, t.Auth.Actor(), t.id, warpVerified)
-	if err != nil {
-		return handleRevert(err)
-	}
-	if len(output) == 0 && output != nil {
-		// Enforce object standardization (this is a VM bug and we should fail
-		// fast)
-		return handleRevert(ErrInvalidObject)
+
+	// Execute all actions in order. If any action fails, all state changes made
+	// by previous actions are reverted.
+	var (
+		success     = true
+		errOutput   []byte
+		outputs     = make([][]byte, 0, len(t.Actions))
+		events      []*Event
+		actionCUs   = math.NewUint64Operator(0)
+		warpMessage *warp.UnsignedMessage
+	)
+	for i, action := range t.Actions {
+		actionSuccess, computeUnits, output, actionEvents, actionWarpMessage, err := action.Execute(ctx, r, ts, timestamp, t.Auth.Actor(), t.ActionID(i), warpVerified)
+		if err != nil {
+			return handleRevert(err)
+		}
+		if len(output) == 0 && output != nil {
+			// Enforce object standardization (this is a VM bug and we should fail
+			// fast)
+			return handleRevert(ErrInvalidObject)
+		}
+		actionCUs.Add(computeUnits)
+		if !actionSuccess {
+			success = false
+			errOutput = output
+			break
+		}
+
+		// Ensure constraints hold if successful
+		actionOutputsWarp := action.OutputsWarpMessage()
+		if (actionWarpMessage == nil && actionOutputsWarp) || (actionWarpMessage != nil && !actionOutputsWarp) {
+			return handleRevert(ErrInvalidObject)
+		}
+		if actionWarpMessage != nil {
+			// [UnmarshalTx] ensures at most one action outputs a warp message
+			warpMessage = actionWarpMessage
+		}
+		if err := verifyEvents(actionEvents); err != nil {
+			return handleRevert(err)
+		}
+		outputs = append(outputs, output)
+		events = append(events, actionEvents...)
 	}
-	outputsWarp := t.Action.OutputsWarpMessage()
 	if !success {
 		ts.Rollback(ctx, actionStart)
+		outputs = nil
+		events = nil
 		warpMessage = nil // warp messages can only be emitted on success
 	} else {
-		// Ensure constraints hold if successful
-		if (warpMessage == nil && outputsWarp) || (warpMessage != nil && !outputsWarp) {
-			return handleRevert(ErrInvalidObject)
-		}
-
 		// Store incoming warp messages in state by their ID to prevent replays
 		if t.WarpMessage != nil {
 			p := s.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
@@ -415,6 +624,11 @@ func (t *Transaction) Execute(
 		// Store newly created warp messages in state by their txID to ensure we can
 		// always sign for a message
 		if warpMessage != nil {
+			// Ensure the message can't be mistaken for an attestation of our state
+			if IsWarpStateRead(warpMessage.Payload) {
+				return handleRevert(ErrReservedWarpPayload)
+			}
+
 			// Enforce we are the source of our own messages
 			warpMessage.NetworkID = r.NetworkID()
 			warpMessage.SourceChainID = r.ChainID()
@@ -433,14 +647,18 @@ func (t *Transaction) Execute(
 	}
 
 	// Calculate units used
+	actionComputeUnits, err := actionCUs.Value()
+	if err != nil {
+		return handleRevert(err)
+	}
 	computeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
 	computeUnitsOp.Add(t.Auth.ComputeUnits(r))
-	computeUnitsOp.Add(actionCUs)
+	computeUnitsOp.Add(actionComputeUnits)
 	if t.WarpMessage != nil {
 		computeUnitsOp.Add(r.GetBaseWarpComputeUnits())
 		computeUnitsOp.MulAdd(uint64(t.numWarpSigners), r.GetWarpComputeUnitsPerSigner())
 	}
-	if success && outputsWarp {
+	if warpMessage != nil {
 		computeUnitsOp.Add(r.GetOutgoingWarpComputeUnits())
 	}
 	computeUnits, err := computeUnitsOp.Value()
@@ -520,12 +738,15 @@ func (t *Transaction) Execute(
 	}
 	return &Result{
 		Success: success,
-		Output:  output,
+		Error:   errOutput,
+		Outputs: outputs,
+		Events:  events,
 
 		Consumed: used,
 		Fee:      feeRequired,
 
 		WarpMessage: warpMessage,
+		WarpError:   warpErrBytes,
 	}, nil
 }
 
@@ -535,7 +756,6 @@ func (t *Transaction) Marshal(p *codec.Packer) error {
 		return p.Err()
 	}
 
-	actionID := t.Action.GetTypeID()
 	authID := t.Auth.GetTypeID()
 	t.Base.Marshal(p)
 	var warpBytes []byte
@@ -546,13 +766,121 @@ func (t *Transaction) Marshal(p *codec.Packer) error {
 		}
 	}
 	p.PackBytes(warpBytes)
-	p.PackByte(actionID)
-	t.Action.Marshal(p)
+	if err := marshalActions(p, t.Actions); err != nil {
+		return err
+	}
 	p.PackByte(authID)
 	t.Auth.Marshal(p)
 	return p.Err()
 }
 
+func actionsSize(actions []Action) int {
+	size := consts.ByteLen
+	for _, action := range actions {
+		size += consts.ByteLen + action.Size()
+	}
+	return size
+}
+
+func marshalActions(p *codec.Packer, actions []Action) error {
+	l := len(actions)
+	if l == 0 {
+		return ErrNoActions
+	}
+	if l > int(consts.MaxUint8) {
+		return ErrTooManyActions
+	}
+	p.PackByte(uint8(l))
+	for _, action := range actions {
+		p.PackByte(action.GetTypeID())
+		action.Marshal(p)
+	}
+	return p.Err()
+}
+
+// actionWindowErr converts an error from [codec.Window.Verify] for an
+// [Action] of [typeID].
+func actionWindowErr(err error, typeID uint8) error {
+	switch {
+	case errors.Is(err, codec.ErrTypeNotActivated):
+		return fmt.Errorf("%w: action %d", ErrActionNotActivated, typeID)
+	case errors.Is(err, codec.ErrTypeDeprecated):
+		return fmt.Errorf("%w: action %d", ErrActionDeprecated, typeID)
+	case errors.Is(err, codec.ErrUnknownType):
+		return fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, typeID)
+	default:
+		return err
+	}
+}
+
+// authWindowErr converts an error from [codec.Window.Verify] for an [Auth]
+// of [typeID].
+func authWindowErr(err error, typeID uint8) error {
+	switch {
+	case errors.Is(err, codec.ErrTypeNotActivated):
+		return fmt.Errorf("%w: auth %d", ErrAuthNotActivated, typeID)
+	case errors.Is(err, codec.ErrTypeDeprecated):
+		return fmt.Errorf("%w: auth %d", ErrAuthDeprecated, typeID)
+	case errors.Is(err, codec.ErrUnknownType):
+		return fmt.Errorf("%w: %d is unknown auth type", ErrInvalidObject, typeID)
+	default:
+		return err
+	}
+}
+
+// unmarshalActions parses the [Action]s of a transaction with the registered
+// version of each type that is valid at [timestamp].
+func unmarshalActions(
+	p *codec.Packer,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+	warpMessage *warp.Message,
+	timestamp int64,
+) ([]Action, []codec.Window, bool, error) {
+	numActions := p.UnpackByte()
+	if numActions == 0 {
+		return nil, nil, false, ErrNoActions
+	}
+	var (
+		actions        = make([]Action, 0, numActions)
+		windows        = make([]codec.Window, 0, numActions)
+		actionWarp     bool
+		actionsOutWarp int
+	)
+	for i := uint8(0); i < numActions; i++ {
+		actionType := p.UnpackByte()
+		unmarshalAction, expectsWarp, window, err := actionRegistry.LookupTimestamp(actionType, timestamp)
+		if err != nil {
+			return nil, nil, false, actionWindowErr(err, actionType)
+		}
+		if expectsWarp {
+			if warpMessage == nil {
+				return nil, nil, false, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
+			}
+			if actionWarp {
+				// Only a single action can consume the incoming warp message,
+				// otherwise it could be processed more than once.
+				return nil, nil, false, fmt.Errorf("%w: action %d", ErrTooManyWarpActions, actionType)
+			}
+			actionWarp = true
+		}
+		action, err := unmarshalAction(p, warpMessage)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("%w: could not unmarshal action", err)
+		}
+		if action.OutputsWarpMessage() {
+			// Outgoing warp messages are stored by txID, so only a single
+			// action can emit one.
+			actionsOutWarp++
+			if actionsOutWarp > 1 {
+				return nil, nil, false, fmt.Errorf("%w: action %d", ErrTooManyWarpActions, actionType)
+			}
+		}
+		actions = append(actions, action)
+		windows = append(windows, window)
+	}
+	return actions, windows, actionWarp, nil
+}
+
 func MarshalTxs(txs []*Transaction) ([]byte, error) {
 	if len(txs) == 0 {
 		return nil, ErrNoTxs
@@ -568,9 +896,12 @@ func MarshalTxs(txs []*Transaction) ([]byte, error) {
 	return p.Bytes(), p.Err()
 }
 
+// UnmarshalTxs parses a list of transactions that are not (yet) included in a
+// block (see [UnmarshalTx]).
 func UnmarshalTxs(
 	raw []byte,
 	initialCapacity int,
+	timestamp int64,
 	actionRegistry ActionRegistry,
 	authRegistry AuthRegistry,
 ) (map[uint8]int, []*Transaction, error) {
@@ -579,7 +910,7 @@ func UnmarshalTxs(
 	authCounts := map[uint8]int{}
 	txs := make([]*Transaction, 0, initialCapacity) // DoS to set size to txCount
 	for i := 0; i < txCount; i++ {
-		tx, err := UnmarshalTx(p, actionRegistry, authRegistry)
+		tx, err := UnmarshalTx(p, timestamp, actionRegistry, authRegistry)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -593,52 +924,34 @@ func UnmarshalTxs(
 	return authCounts, txs, p.Err()
 }
 
+func isAuthType(authRegistry *codec.TypeParser[Auth, *warp.Message, bool], typeID uint8) bool {
+	_, _, ok := authRegistry.LookupIndex(typeID)
+	return ok
+}
+
+// UnmarshalTx parses a transaction with the registered version of each
+// [Action] and [Auth] type that is valid at [timestamp]. This is the timestamp
+// of the block that includes the transaction or, if it is not included in a
+// block, the current time. If a transaction is parsed before it is included in
+// a block, the version of each type must still be valid at the timestamp of the
+// block it is executed in (see [Transaction.PreExecute]).
 func UnmarshalTx(
 	p *codec.Packer,
+	timestamp int64,
 	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
 	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
 ) (*Transaction, error) {
 	start := p.Offset()
-	base, err := UnmarshalBase(p)
-	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal base", err)
-	}
-	var warpBytes []byte
-	p.UnpackBytes(MaxWarpMessageSize, false, &warpBytes)
-	var warpMessage *warp.Message
-	var numWarpSigners int
-	if len(warpBytes) > 0 {
-		msg, err := warp.ParseMessage(warpBytes)
-		if err != nil {
-			return nil, fmt.Errorf("%w: could not unmarshal warp message", err)
-		}
-		if len(msg.Payload) == 0 {
-			return nil, ErrEmptyWarpPayload
-		}
-		warpMessage = msg
-		numSigners, err := msg.Signature.NumSigners()
-		if err != nil {
-			return nil, fmt.Errorf("%w: could not calculate number of warp signers", err)
-		}
-		numWarpSigners = numSigners
-	}
-	actionType := p.UnpackByte()
-	unmarshalAction, actionWarp, ok := actionRegistry.LookupIndex(actionType)
-	if !ok {
-		return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
-	}
-	if actionWarp && warpMessage == nil {
-		return nil, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
-	}
-	action, err := unmarshalAction(p, warpMessage)
+	tx, actionWarp, err := unmarshalDigest(p, timestamp, actionRegistry)
 	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal action", err)
+		return nil, err
 	}
+	warpMessage := tx.WarpMessage
 	digest := p.Offset()
 	authType := p.UnpackByte()
-	unmarshalAuth, authWarp, ok := authRegistry.LookupIndex(authType)
-	if !ok {
-		return nil, fmt.Errorf("%w: %d is unknown auth type", ErrInvalidObject, authType)
+	unmarshalAuth, authWarp, authWindow, err := authRegistry.LookupTimestamp(authType, timestamp)
+	if err != nil {
+		return nil, authWindowErr(err, authType)
 	}
 	if authWarp && warpMessage == nil {
 		return nil, fmt.Errorf("%w: auth %d", ErrExpectedWarpMessage, authType)
@@ -647,22 +960,26 @@ func UnmarshalTx(
 	if err != nil {
 		return nil, fmt.Errorf("%w: could not unmarshal auth", err)
 	}
-	if actorType := auth.Actor()[0]; actorType != authType {
-		return nil, fmt.Errorf("%w: actorType (%d) did not match authType (%d)", ErrInvalidActor, actorType, authType)
+	// [Auth] that wrap other [Auth] (like a sponsor wrapper) return the addresses
+	// of the [Auth] they wrap, so their [Actor] and [Sponsor] must be prefixed by
+	// the type of the wrapped [Auth] instead.
+	actorAuthType, sponsorAuthType := authType, authType
+	if wrapper, ok := auth.(WrapperAuth); ok {
+		actorAuthType, sponsorAuthType = wrapper.ActorTypeID(), wrapper.SponsorTypeID()
 	}
-	if sponsorType := auth.Sponsor()[0]; sponsorType != authType {
-		return nil, fmt.Errorf("%w: sponsorType (%d) did not match authType (%d)", ErrInvalidSponsor, sponsorType, authType)
+	if actorType := auth.Actor()[0]; actorType != actorAuthType {
+		return nil, fmt.Errorf("%w: actorType (%d) did not match authType (%d)", ErrInvalidActor, actorType, actorAuthType)
+	}
+	if sponsorType := auth.Sponsor()[0]; sponsorType != sponsorAuthType {
+		return nil, fmt.Errorf("%w: sponsorType (%d) did not match authType (%d)", ErrInvalidSponsor, sponsorType, sponsorAuthType)
 	}
 	warpExpected := actionWarp || authWarp
 	if !warpExpected && warpMessage != nil {
 		return nil, ErrUnexpectedWarpMessage
 	}
 
-	var tx Transaction
-	tx.Base = base
-	tx.Action = action
-	tx.WarpMessage = warpMessage
 	tx.Auth = auth
+	tx.authWindow = authWindow
 	if err := p.Err(); err != nil {
 		return nil, p.Err()
 	}
@@ -671,9 +988,52 @@ func UnmarshalTx(
 	tx.bytes = codecBytes[start:p.Offset()] // ensure errors handled before grabbing memory
 	tx.size = len(tx.bytes)
 	tx.id = utils.ToID(tx.bytes)
+	return tx, nil
+}
+
+// unmarshalDigest parses the unsigned portion of a transaction (everything
+// but [Auth]) and returns whether any [Action] requires a warp message.
+func unmarshalDigest(
+	p *codec.Packer,
+	timestamp int64,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+) (*Transaction, bool, error) {
+	base, err := UnmarshalBase(p)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: could not unmarshal base", err)
+	}
+	var warpBytes []byte
+	p.UnpackBytes(MaxWarpMessageSize, false, &warpBytes)
+	var warpMessage *warp.Message
+	var numWarpSigners int
+	if len(warpBytes) > 0 {
+		msg, err := warp.ParseMessage(warpBytes)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: could not unmarshal warp message", err)
+		}
+		if len(msg.Payload) == 0 {
+			return nil, false, ErrEmptyWarpPayload
+		}
+		warpMessage = msg
+		numSigners, err := msg.Signature.NumSigners()
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: could not calculate number of warp signers", err)
+		}
+		numWarpSigners = numSigners
+	}
+	actions, actionWindows, actionWarp, err := unmarshalActions(p, actionRegistry, warpMessage, timestamp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var tx Transaction
+	tx.Base = base
+	tx.Actions = actions
+	tx.actionWindows = actionWindows
+	tx.WarpMessage = warpMessage
 	if tx.WarpMessage != nil {
 		tx.numWarpSigners = numWarpSigners
 		tx.warpID = tx.WarpMessage.ID()
 	}
-	return &tx, nil
+	return &tx, actionWarp, nil
 }
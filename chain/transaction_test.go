@@ -0,0 +1,293 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	windowAction   uint8 = 0
+	alwaysAuth     uint8 = 0
+	windowAuth     uint8 = 1
+	validityWindow int64 = 60_000
+)
+
+func newWindowRegistries(t *testing.T, ctrl *gomock.Controller) (ActionRegistry, AuthRegistry) {
+	require := require.New(t)
+
+	action := NewMockAction(ctrl)
+	action.EXPECT().GetTypeID().Return(windowAction).AnyTimes()
+	action.EXPECT().OutputsWarpMessage().Return(false).AnyTimes()
+	action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	actionRegistry := codec.NewTypeParser[Action, *warp.Message]()
+	require.NoError(actionRegistry.RegisterWindow(
+		windowAction,
+		func(*codec.Packer, *warp.Message) (Action, error) { return action, nil },
+		false,
+		codec.Window{Start: 2_000, End: 5_000},
+	))
+
+	authRegistry := codec.NewTypeParser[Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(
+		alwaysAuth,
+		func(*codec.Packer, *warp.Message) (Auth, error) {
+			return newTypedAuth(ctrl, alwaysAuth, alwaysAuth, alwaysAuth), nil
+		},
+		false,
+	))
+	require.NoError(authRegistry.RegisterWindow(
+		windowAuth,
+		func(*codec.Packer, *warp.Message) (Auth, error) {
+			return newTypedAuth(ctrl, windowAuth, windowAuth, windowAuth), nil
+		},
+		false,
+		codec.Window{Start: 3_000, End: 4_000},
+	))
+	return actionRegistry, authRegistry
+}
+
+// newTypedAuth returns an [Auth] of [typeID] whose [Actor] and [Sponsor] are
+// prefixed by [actorType] and [sponsorType].
+func newTypedAuth(ctrl *gomock.Controller, typeID uint8, actorType uint8, sponsorType uint8) *MockAuth {
+	auth := NewMockAuth(ctrl)
+	auth.EXPECT().GetTypeID().Return(typeID).AnyTimes()
+	auth.EXPECT().Actor().Return(codec.Address{actorType}).AnyTimes()
+	auth.EXPECT().Sponsor().Return(codec.Address{sponsorType}).AnyTimes()
+	auth.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	return auth
+}
+
+// testWrapperAuth is an [Auth] that wraps an actor [Auth] of [actorType] and a
+// sponsor [Auth] of [sponsorType].
+type testWrapperAuth struct {
+	*MockAuth
+	actorType   uint8
+	sponsorType uint8
+}
+
+func (w *testWrapperAuth) ActorTypeID() uint8 {
+	return w.actorType
+}
+
+func (w *testWrapperAuth) SponsorTypeID() uint8 {
+	return w.sponsorType
+}
+
+func packWindowTx(chainID ids.ID, expiry int64, authType uint8) *codec.Packer {
+	p := codec.NewWriter(0, consts.NetworkSizeLimit)
+	(&Base{Timestamp: expiry, ChainID: chainID, MaxFee: 1}).Marshal(p)
+	p.PackBytes(nil) // warp message
+	p.PackByte(1)    // number of actions
+	p.PackByte(windowAction)
+	p.PackByte(authType)
+	return codec.NewReader(p.Bytes(), consts.NetworkSizeLimit)
+}
+
+func TestUnmarshalTxWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	actionRegistry, authRegistry := newWindowRegistries(t, ctrl)
+	chainID := ids.GenerateTestID()
+
+	// Types are looked up at the timestamp the transaction is parsed at (not
+	// its expiry)
+	tests := []struct {
+		name      string
+		timestamp int64
+		authType  uint8
+		err       error
+	}{
+		{
+			name:      "action not activated",
+			timestamp: 1_000,
+			authType:  alwaysAuth,
+			err:       ErrActionNotActivated,
+		},
+		{
+			name:      "action deprecated",
+			timestamp: 6_000,
+			authType:  alwaysAuth,
+			err:       ErrActionDeprecated,
+		},
+		{
+			name:      "auth not activated",
+			timestamp: 2_000,
+			authType:  windowAuth,
+			err:       ErrAuthNotActivated,
+		},
+		{
+			name:      "auth deprecated",
+			timestamp: 5_000,
+			authType:  windowAuth,
+			err:       ErrAuthDeprecated,
+		},
+		{
+			name:      "valid",
+			timestamp: 3_000,
+			authType:  windowAuth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalTx(packWindowTx(chainID, 10_000, tt.authType), tt.timestamp, actionRegistry, authRegistry)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestUnmarshalTxAuthType(t *testing.T) {
+	const wrapperAuth uint8 = 2
+
+	ctrl := gomock.NewController(t)
+	chainID := ids.GenerateTestID()
+
+	tests := []struct {
+		name string
+		auth Auth
+		err  error
+	}{
+		{
+			name: "valid",
+			auth: newTypedAuth(ctrl, alwaysAuth, alwaysAuth, alwaysAuth),
+		},
+		{
+			name: "actor type mismatch",
+			auth: newTypedAuth(ctrl, alwaysAuth, windowAuth, alwaysAuth),
+			err:  ErrInvalidActor,
+		},
+		{
+			name: "sponsor type mismatch",
+			auth: newTypedAuth(ctrl, alwaysAuth, alwaysAuth, windowAuth),
+			err:  ErrInvalidSponsor,
+		},
+		{
+			name: "wrapper with wrapped types",
+			auth: &testWrapperAuth{newTypedAuth(ctrl, wrapperAuth, alwaysAuth, windowAuth), alwaysAuth, windowAuth},
+		},
+		{
+			name: "wrapper actor type mismatch",
+			auth: &testWrapperAuth{newTypedAuth(ctrl, wrapperAuth, windowAuth, windowAuth), alwaysAuth, windowAuth},
+			err:  ErrInvalidActor,
+		},
+		{
+			name: "wrapper sponsor type mismatch",
+			auth: &testWrapperAuth{newTypedAuth(ctrl, wrapperAuth, alwaysAuth, wrapperAuth), alwaysAuth, windowAuth},
+			err:  ErrInvalidSponsor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			actionRegistry, _ := newWindowRegistries(t, ctrl)
+			authRegistry := codec.NewTypeParser[Auth, *warp.Message]()
+			require.NoError(authRegistry.Register(
+				tt.auth.GetTypeID(),
+				func(*codec.Packer, *warp.Message) (Auth, error) { return tt.auth, nil },
+				false,
+			))
+			_, err := UnmarshalTx(packWindowTx(chainID, 3_000, tt.auth.GetTypeID()), 3_000, actionRegistry, authRegistry)
+			require.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func TestPreExecuteWindow(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	actionRegistry, authRegistry := newWindowRegistries(t, ctrl)
+	chainID := ids.GenerateTestID()
+	r := NewMockRules(ctrl)
+	r.EXPECT().ChainID().Return(chainID).AnyTimes()
+	r.EXPECT().GetValidityWindow().Return(validityWindow).AnyTimes()
+	r.EXPECT().GetMaxActionsPerTx().Return(uint8(1)).AnyTimes()
+
+	// The transaction can be parsed because its types are valid when it is
+	// parsed, but it can't be executed before they activate
+	tx, err := UnmarshalTx(packWindowTx(chainID, 3_000, windowAuth), 3_000, actionRegistry, authRegistry)
+	require.NoError(err)
+	require.ErrorIs(tx.PreExecute(context.Background(), nil, nil, r, nil, 1_000), ErrActionNotActivated)
+	require.ErrorIs(tx.PreExecute(context.Background(), nil, nil, r, nil, 2_500), ErrAuthNotActivated)
+}
+
+func TestUnmarshalTxVersion(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	_, authRegistry := newWindowRegistries(t, ctrl)
+	chainID := ids.GenerateTestID()
+	r := NewMockRules(ctrl)
+	r.EXPECT().ChainID().Return(chainID).AnyTimes()
+	r.EXPECT().GetValidityWindow().Return(validityWindow).AnyTimes()
+	r.EXPECT().GetMaxActionsPerTx().Return(uint8(1)).AnyTimes()
+
+	// The decoder of [windowAction] changes at 3_000
+	versions := make([]*MockAction, 2)
+	actionRegistry := codec.NewTypeParser[Action, *warp.Message]()
+	for i, w := range []codec.Window{{Start: -1, End: 2_999}, {Start: 3_000, End: -1}} {
+		action := NewMockAction(ctrl)
+		action.EXPECT().OutputsWarpMessage().Return(false).AnyTimes()
+		action.EXPECT().GetTypeID().Return(windowAction).AnyTimes()
+		action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+		versions[i] = action
+		require.NoError(actionRegistry.RegisterWindow(
+			windowAction,
+			func(*codec.Packer, *warp.Message) (Action, error) { return action, nil },
+			false,
+			w,
+		))
+	}
+
+	// The version is selected by the timestamp of the block (not the expiry of
+	// the transaction, which is after the decoder changes)
+	before, err := UnmarshalTx(packWindowTx(chainID, 4_000, alwaysAuth), 2_000, actionRegistry, authRegistry)
+	require.NoError(err)
+	require.Same(versions[0], before.Actions[0])
+	after, err := UnmarshalTx(packWindowTx(chainID, 4_000, alwaysAuth), 3_000, actionRegistry, authRegistry)
+	require.NoError(err)
+	require.Same(versions[1], after.Actions[0])
+
+	// A transaction can't be executed on the other side of the change from
+	// where it was parsed
+	require.ErrorIs(before.PreExecute(context.Background(), nil, nil, r, nil, 3_500), ErrActionDeprecated)
+	require.ErrorIs(after.PreExecute(context.Background(), nil, nil, r, nil, 2_500), ErrActionNotActivated)
+}
+
+func TestBaseNonceEncoding(t *testing.T) {
+	require := require.New(t)
+
+	for _, nonce := range []uint64{0, 1, 1 << 40} {
+		b := &Base{Timestamp: 3_000, ChainID: ids.GenerateTestID(), MaxFee: 10, Nonce: nonce}
+		p := codec.NewWriter(b.Size(), consts.NetworkSizeLimit)
+		b.Marshal(p)
+		require.NoError(p.Err())
+		require.Len(p.Bytes(), b.Size())
+		if nonce == 0 {
+			// A nonce of 0 is not encoded
+			require.Equal(BaseSize, b.Size())
+		}
+
+		ub, err := UnmarshalBase(codec.NewReader(p.Bytes(), b.Size()))
+		require.NoError(err)
+		require.Equal(b, ub)
+	}
+
+	// A flagged nonce can't be 0 (each [Base] has a single encoding)
+	p := codec.NewWriter(BaseSize+consts.Uint64Len, consts.NetworkSizeLimit)
+	p.PackInt64(3_000 + nonceFlag)
+	p.PackID(ids.GenerateTestID())
+	p.PackUint64(10)
+	p.PackUint64(0)
+	_, err := UnmarshalBase(codec.NewReader(p.Bytes(), BaseSize+consts.Uint64Len))
+	require.ErrorIs(err, codec.ErrFieldNotPopulated)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"golang.org/x/exp/slices"
+)
+
+// WarpQuorum is the fraction of the stake weight of a source chain that must
+// sign a warp message for it to be verified.
+type WarpQuorum struct {
+	Num uint64 `json:"num"`
+	Den uint64 `json:"den"`
+}
+
+func (q *WarpQuorum) Verify() error {
+	if q.Num == 0 || q.Den == 0 || q.Num > q.Den {
+		return fmt.Errorf("%w: %d/%d", ErrInvalidWarpQuorum, q.Num, q.Den)
+	}
+	return nil
+}
+
+// WarpChainQuorum is the [WarpQuorum] required from a specific source chain.
+type WarpChainQuorum struct {
+	ChainID ids.ID `json:"chainID"`
+	WarpQuorum
+}
+
+// WarpPolicy determines which source chains warp messages are accepted from
+// and the [WarpQuorum] required from each of them. It can be returned by
+// [Rules.GetWarpConfig] with [WarpPolicy.Config].
+//
+// A chain is accepted if it is not in [Deny], it is in [Allow] (if [Allow] is
+// not empty), and it either has a quorum in [Chains] or [Default] is set.
+type WarpPolicy struct {
+	// Default is the quorum required from chains that are not in [Chains]. If
+	// nil, only messages from chains in [Chains] are accepted.
+	Default *WarpQuorum `json:"default,omitempty"`
+
+	// Chains overrides [Default] for specific source chains.
+	Chains []*WarpChainQuorum `json:"chains,omitempty"`
+
+	// Allow is the only source chains messages are accepted from (if not
+	// empty).
+	Allow []ids.ID `json:"allow,omitempty"`
+
+	// Deny is source chains that messages are never accepted from.
+	Deny []ids.ID `json:"deny,omitempty"`
+}
+
+// Verify ensures all quorums in [p] are valid and that no chain is both
+// allowed and denied.
+func (p *WarpPolicy) Verify() error {
+	if p.Default != nil {
+		if err := p.Default.Verify(); err != nil {
+			return fmt.Errorf("%w: default", err)
+		}
+	}
+	chains := set.NewSet[ids.ID](len(p.Chains))
+	for i, quorum := range p.Chains {
+		if quorum == nil {
+			return fmt.Errorf("%w: chains[%d] is null", ErrInvalidWarpQuorum, i)
+		}
+		if chains.Contains(quorum.ChainID) {
+			return fmt.Errorf("%w: %s", ErrDuplicateWarpChain, quorum.ChainID)
+		}
+		chains.Add(quorum.ChainID)
+		if err := quorum.Verify(); err != nil {
+			return fmt.Errorf("%w: %s", err, quorum.ChainID)
+		}
+	}
+	for _, chainID := range p.Deny {
+		if slices.Contains(p.Allow, chainID) {
+			return fmt.Errorf("%w: %s", ErrConflictingWarpPolicy, chainID)
+		}
+	}
+	return nil
+}
+
+// Config returns whether messages from [sourceChainID] are accepted and the
+// numerator and denominator of the quorum they must be signed by.
+func (p *WarpPolicy) Config(sourceChainID ids.ID) (bool, uint64, uint64) {
+	if slices.Contains(p.Deny, sourceChainID) {
+		return false, 0, 0
+	}
+	if len(p.Allow) > 0 && !slices.Contains(p.Allow, sourceChainID) {
+		return false, 0, 0
+	}
+	for _, quorum := range p.Chains {
+		if quorum.ChainID == sourceChainID {
+			return true, quorum.Num, quorum.Den
+		}
+	}
+	if p.Default == nil {
+		return false, 0, 0
+	}
+	return true, p.Default.Num, p.Default.Den
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpPolicyConfig(t *testing.T) {
+	var (
+		trusted   = ids.GenerateTestID()
+		untrusted = ids.GenerateTestID()
+		denied    = ids.GenerateTestID()
+		other     = ids.GenerateTestID()
+	)
+	tests := []struct {
+		name    string
+		policy  *WarpPolicy
+		chainID ids.ID
+		allowed bool
+		num     uint64
+		den     uint64
+	}{
+		{
+			name:    "empty policy",
+			policy:  &WarpPolicy{},
+			chainID: other,
+		},
+		{
+			name:    "default",
+			policy:  &WarpPolicy{Default: &WarpQuorum{4, 5}},
+			chainID: other,
+			allowed: true,
+			num:     4,
+			den:     5,
+		},
+		{
+			name: "chain override",
+			policy: &WarpPolicy{
+				Default: &WarpQuorum{4, 5},
+				Chains:  []*WarpChainQuorum{{trusted, WarpQuorum{1, 2}}},
+			},
+			chainID: trusted,
+			allowed: true,
+			num:     1,
+			den:     2,
+		},
+		{
+			name: "chain without default",
+			policy: &WarpPolicy{
+				Chains: []*WarpChainQuorum{{trusted, WarpQuorum{1, 2}}},
+			},
+			chainID: other,
+		},
+		{
+			name: "not allowed",
+			policy: &WarpPolicy{
+				Default: &WarpQuorum{4, 5},
+				Allow:   []ids.ID{trusted, untrusted},
+			},
+			chainID: other,
+		},
+		{
+			name: "allowed with default",
+			policy: &WarpPolicy{
+				Default: &WarpQuorum{4, 5},
+				Allow:   []ids.ID{trusted, untrusted},
+			},
+			chainID: untrusted,
+			allowed: true,
+			num:     4,
+			den:     5,
+		},
+		{
+			name: "denied",
+			policy: &WarpPolicy{
+				Default: &WarpQuorum{4, 5},
+				Chains:  []*WarpChainQuorum{{denied, WarpQuorum{1, 2}}},
+				Deny:    []ids.ID{denied},
+			},
+			chainID: denied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.NoError(tt.policy.Verify())
+			allowed, num, den := tt.policy.Config(tt.chainID)
+			require.Equal(tt.allowed, allowed)
+			require.Equal(tt.num, num)
+			require.Equal(tt.den, den)
+		})
+	}
+}
+
+func TestWarpPolicyVerify(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name   string
+		policy *WarpPolicy
+		err    error
+	}{
+		{
+			name:   "zero denominator",
+			policy: &WarpPolicy{Default: &WarpQuorum{0, 0}},
+			err:    ErrInvalidWarpQuorum,
+		},
+		{
+			name:   "numerator greater than denominator",
+			policy: &WarpPolicy{Chains: []*WarpChainQuorum{{chainID, WarpQuorum{3, 2}}}},
+			err:    ErrInvalidWarpQuorum,
+		},
+		{
+			name:   "nil chain",
+			policy: &WarpPolicy{Chains: []*WarpChainQuorum{nil}},
+			err:    ErrInvalidWarpQuorum,
+		},
+		{
+			name: "duplicate chain",
+			policy: &WarpPolicy{Chains: []*WarpChainQuorum{
+				{chainID, WarpQuorum{1, 2}},
+				{chainID, WarpQuorum{2, 3}},
+			}},
+			err: ErrDuplicateWarpChain,
+		},
+		{
+			name:   "allowed and denied",
+			policy: &WarpPolicy{Allow: []ids.ID{chainID}, Deny: []ids.ID{chainID}},
+			err:    ErrConflictingWarpPolicy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.policy.Verify(), tt.err)
+		})
+	}
+}
+
+func TestWarpPolicyJSON(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	policy := &WarpPolicy{
+		Default: &WarpQuorum{4, 5},
+		Chains:  []*WarpChainQuorum{{chainID, WarpQuorum{2, 3}}},
+		Deny:    []ids.ID{ids.GenerateTestID()},
+	}
+	b, err := json.Marshal(policy)
+	require.NoError(err)
+	var parsed WarpPolicy
+	require.NoError(json.Unmarshal(b, &parsed))
+	require.Equal(policy, &parsed)
+
+	// Null quorums are rejected (instead of dereferenced)
+	var null WarpPolicy
+	require.NoError(json.Unmarshal([]byte(`{"chains":[null]}`), &null))
+	require.ErrorIs(null.Verify(), ErrInvalidWarpQuorum)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// WarpStateReadPrefix is the prefix of the payload of every warp message that
+// attests to the state of a chain (rather than being emitted by an [Action]).
+//
+// [Action]s can't emit warp messages with this prefix, so a [WarpStateRead]
+// can never be mistaken for a message produced by a transaction.
+var WarpStateReadPrefix = []byte("hypersdk/warpStateRead")
+
+// WarpStateRead is the payload of a warp message in which validators of the
+// source chain attest to the values of [Keys] in its state.
+//
+// Like state proofs, values are read from the state committed to by the
+// accepted block at [Height] (which is the post-execution state of the block
+// at [Height]-1 because roots are deferred).
+type WarpStateRead struct {
+	Height uint64
+	// Timestamp is the timestamp of the block at [Height] (the values were
+	// current until that block was executed), so that [Action]s can reject
+	// stale reads.
+	Timestamp int64
+	Root      ids.ID
+	Keys      [][]byte
+	// Values contains the value of each key in [Keys] (nil if the key does
+	// not exist).
+	Values [][]byte
+}
+
+func (w *WarpStateRead) size() int {
+	size := len(WarpStateReadPrefix) + consts.Uint64Len + consts.Int64Len + consts.IDLen + consts.IntLen
+	for i, k := range w.Keys {
+		size += codec.BytesLen(k) + codec.BytesLen(w.Values[i])
+	}
+	return size
+}
+
+func (w *WarpStateRead) Marshal() ([]byte, error) {
+	if len(w.Keys) > MaxWarpStateReadKeys {
+		return nil, ErrTooManyWarpStateReadKeys
+	}
+	if len(w.Keys) != len(w.Values) {
+		return nil, fmt.Errorf("%w: %d keys but %d values", ErrInvalidObject, len(w.Keys), len(w.Values))
+	}
+	size := w.size()
+	if size > MaxWarpStateReadSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrWarpStateReadTooLarge, size, MaxWarpStateReadSize)
+	}
+	p := codec.NewWriter(size, MaxWarpStateReadSize)
+	p.PackFixedBytes(WarpStateReadPrefix)
+	p.PackUint64(w.Height)
+	p.PackInt64(w.Timestamp)
+	p.PackID(w.Root)
+	p.PackInt(len(w.Keys))
+	for i, k := range w.Keys {
+		p.PackBytes(k)
+		p.PackBytes(w.Values[i])
+	}
+	return p.Bytes(), p.Err()
+}
+
+// IsWarpStateRead returns true if [payload] is a [WarpStateRead].
+func IsWarpStateRead(payload []byte) bool {
+	return bytes.HasPrefix(payload, WarpStateReadPrefix)
+}
+
+func UnmarshalWarpStateRead(payload []byte) (*WarpStateRead, error) {
+	if !IsWarpStateRead(payload) {
+		return nil, ErrNotWarpStateRead
+	}
+	p := codec.NewReader(payload[len(WarpStateReadPrefix):], MaxWarpStateReadSize)
+	var w WarpStateRead
+	w.Height = p.UnpackUint64(false)
+	w.Timestamp = p.UnpackInt64(false)
+	p.UnpackID(false, &w.Root)
+	numKeys := p.UnpackInt(true)
+	if numKeys > MaxWarpStateReadKeys {
+		return nil, ErrTooManyWarpStateReadKeys
+	}
+	w.Keys = make([][]byte, numKeys)
+	w.Values = make([][]byte, numKeys)
+	for i := 0; i < numKeys; i++ {
+		p.UnpackBytes(MaxWarpStateReadSize, true, &w.Keys[i])
+		p.UnpackBytes(MaxWarpStateReadSize, false, &w.Values[i])
+		if len(w.Values[i]) == 0 {
+			// Enforce object standardization
+			w.Values[i] = nil
+		}
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return &w, nil
+}
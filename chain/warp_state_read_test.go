@@ -0,0 +1,62 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpStateRead(t *testing.T) {
+	require := require.New(t)
+
+	read := &WarpStateRead{
+		Height:    10,
+		Timestamp: 20_000,
+		Root:      ids.GenerateTestID(),
+		Keys:      [][]byte{{1, 2, 3}, {4, 5}},
+		Values:    [][]byte{{6}, nil},
+	}
+	payload, err := read.Marshal()
+	require.NoError(err)
+	require.True(IsWarpStateRead(payload))
+
+	parsed, err := UnmarshalWarpStateRead(payload)
+	require.NoError(err)
+	require.Equal(read, parsed)
+
+	// Payloads emitted by actions are not state reads
+	_, err = UnmarshalWarpStateRead([]byte("hello"))
+	require.ErrorIs(err, ErrNotWarpStateRead)
+
+	// Trailing bytes are rejected
+	_, err = UnmarshalWarpStateRead(append(payload, 0))
+	require.ErrorIs(err, ErrInvalidObject)
+}
+
+func TestWarpStateReadLimits(t *testing.T) {
+	require := require.New(t)
+
+	read := &WarpStateRead{
+		Keys:   make([][]byte, MaxWarpStateReadKeys+1),
+		Values: make([][]byte, MaxWarpStateReadKeys+1),
+	}
+	_, err := read.Marshal()
+	require.ErrorIs(err, ErrTooManyWarpStateReadKeys)
+
+	read = &WarpStateRead{
+		Keys:   [][]byte{{1}},
+		Values: [][]byte{make([]byte, MaxWarpStateReadSize)},
+	}
+	_, err = read.Marshal()
+	require.ErrorIs(err, ErrWarpStateReadTooLarge)
+
+	read = &WarpStateRead{
+		Keys: [][]byte{{1}},
+	}
+	_, err = read.Marshal()
+	require.ErrorIs(err, ErrInvalidObject)
+}
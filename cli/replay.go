@@ -0,0 +1,82 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"math"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+// Replay initializes [v] with the databases in [chainDataDir] (which must
+// belong to a stopped archive node) and re-executes the accepted blocks in
+// [start, end], printing the first divergence from what was accepted.
+//
+// [upgradeBytes] must be the upgrades of the chain (otherwise blocks after an
+// upgrade are executed with the wrong rules) and [configBytes] must enable
+// archive mode.
+func (h *Handler) Replay(
+	v *vm.VM,
+	chainDataDir string,
+	genesisBytes []byte,
+	upgradeBytes []byte,
+	configBytes []byte,
+	start uint64,
+	end uint64,
+) error {
+	ctx := context.Background()
+	rawNetworkID, err := h.PromptInt("networkID", math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	networkID := uint32(rawNetworkID)
+	chainID, err := h.PromptID("chainID")
+	if err != nil {
+		return err
+	}
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return err
+	}
+	snowCtx := &snow.Context{
+		NetworkID:      networkID,
+		ChainID:        chainID,
+		Log:            logging.NoLog{},
+		ChainDataDir:   chainDataDir,
+		Metrics:        metrics.NewOptionalGatherer(),
+		PublicKey:      bls.PublicFromSecretKey(sk),
+		WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+		ValidatorState: &validators.TestState{},
+	}
+	toEngine := make(chan common.Message, 1)
+	if err := v.Initialize(ctx, snowCtx, memdb.New(), genesisBytes, upgradeBytes, configBytes, toEngine, nil, nil); err != nil {
+		return err
+	}
+	defer func() {
+		_ = v.Shutdown(ctx)
+	}()
+
+	utils.Outf("{{yellow}}replaying blocks:{{/}} %d-%d\n", start, end)
+	div, err := v.Replay(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	if div == nil {
+		utils.Outf("{{green}}no divergence found{{/}}\n")
+		return nil
+	}
+	utils.Outf("{{red}}divergence found:{{/}} %s\n", div)
+	return nil
+}
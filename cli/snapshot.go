@@ -0,0 +1,136 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/snapshot"
+	"github.com/ava-labs/hypersdk/storage"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+// ExportSnapshot downloads a snapshot of the state at [height] (or the last
+// accepted height, if negative) from the selected chain to [path].
+func (h *Handler) ExportSnapshot(height int64, path string) error {
+	_, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	var pheight *uint64
+	if height >= 0 {
+		uheight := uint64(height)
+		pheight = &uheight
+	}
+	m, err := rpc.DownloadSnapshot(context.Background(), uris[0], pheight, f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}exported snapshot:{{/}} %s\n", path)
+	printManifest(m)
+	return nil
+}
+
+// ImportSnapshot initializes the databases in [chainDataDir] with the snapshot
+// at [path]. The node must be stopped and must have never run the chain.
+//
+// The snapshot is verified against the blocks accepted by the selected chain
+// (which must be served by a trusted node), so the child of the block in the
+// snapshot must already be accepted.
+func (h *Handler) ImportSnapshot(path string, chainDataDir string, getParser func(string, uint32, ids.ID) (chain.Parser, error)) error {
+	ctx := context.Background()
+	chainID, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	rcli := rpc.NewJSONRPCClient(uris[0])
+	networkID, _, _, err := rcli.Network(ctx)
+	if err != nil {
+		return err
+	}
+	parser, err := getParser(uris[0], networkID, chainID)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sr, err := snapshot.NewReader(f)
+	if err != nil {
+		return err
+	}
+	m := sr.Manifest()
+	if err := sr.Close(); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	genesis, err := rcli.GetBlockByHeight(ctx, 0)
+	if err != nil {
+		return err
+	}
+	blk, err := rcli.GetBlockByHeight(ctx, m.Height)
+	if err != nil {
+		return err
+	}
+	child, err := rcli.GetBlockByHeight(ctx, m.Height+1)
+	if err != nil {
+		return fmt.Errorf("unable to get child of %d (wait for it to be accepted): %w", m.Height, err)
+	}
+	checkpoint := &vm.SnapshotCheckpoint{
+		NetworkID: networkID,
+		ChainID:   chainID,
+		GenesisID: genesis.BlockID,
+		BlockID:   blk.BlockID,
+		StateRoot: child.Header.StateRoot,
+	}
+
+	vmDB, stateDB, metaDB, err := storage.New(chainDataDir, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = vmDB.Close()
+		_ = stateDB.Close()
+		_ = metaDB.Close()
+	}()
+	m, err = vm.ImportSnapshot(ctx, f, parser, checkpoint, vmDB, stateDB)
+	if err != nil {
+		return err
+	}
+	utils.Outf("{{green}}imported snapshot:{{/}} %s\n", chainDataDir)
+	printManifest(m)
+	return nil
+}
+
+func printManifest(m *snapshot.Manifest) {
+	utils.Outf(
+		"{{yellow}}chainID:{{/}} %s {{yellow}}height:{{/}} %d {{yellow}}blockID:{{/}} %s {{yellow}}root:{{/}} %s\n",
+		m.ChainID,
+		m.Height,
+		m.BlockID,
+		m.StateRoot,
+	)
+}
@@ -111,7 +111,7 @@ func (h *Handler) Spam(
 		return err
 	}
 	action := getTransfer(keys[0].Address, 0)
-	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), action, factory, nil)
+	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), []chain.Action{action}, factory, nil, false)
 	if err != nil {
 		return err
 	}
@@ -167,7 +167,7 @@ func (h *Handler) Spam(
 		accounts[i] = pk
 
 		// Send funds
-		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(pk.Address, distAmount), factory, feePerTx)
+		_, tx, err := cli.GenerateTransactionManual(parser, nil, []chain.Action{getTransfer(pk.Address, distAmount)}, factory, feePerTx)
 		if err != nil {
 			return err
 		}
@@ -186,7 +186,7 @@ func (h *Handler) Spam(
 		}
 		if !result.Success {
 			// Should never happen
-			return fmt.Errorf("%w: %s", ErrTxFailed, result.Output)
+			return fmt.Errorf("%w: %s", ErrTxFailed, result.Error)
 		}
 	}
 	var recipientFunc func() (*PrivateKey, error)
@@ -316,7 +316,7 @@ func (h *Handler) Spam(
 						if maxFee != nil {
 							fee = *maxFee
 						}
-						_, tx, err := issuer.c.GenerateTransactionManual(parser, nil, action, factory, fee, tm)
+						_, tx, err := issuer.c.GenerateTransactionManual(parser, nil, []chain.Action{action}, factory, fee, tm)
 						if err != nil {
 							utils.Outf("{{orange}}failed to generate tx:{{/}} %v\n", err)
 							continue
@@ -424,7 +424,7 @@ func (h *Handler) Spam(
 		if err != nil {
 			return err
 		}
-		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(key.Address, returnAmt), f, feePerTx)
+		_, tx, err := cli.GenerateTransactionManual(parser, nil, []chain.Action{getTransfer(key.Address, returnAmt)}, f, feePerTx)
 		if err != nil {
 			return err
 		}
@@ -446,7 +446,7 @@ func (h *Handler) Spam(
 		}
 		if !result.Success {
 			// Should never happen
-			return fmt.Errorf("%w: %s", ErrTxFailed, result.Output)
+			return fmt.Errorf("%w: %s", ErrTxFailed, result.Error)
 		}
 	}
 	utils.Outf(
@@ -492,7 +492,7 @@ func startIssuer(cctx context.Context, issuer *txIssuer) {
 				if result.Success {
 					confirmedTxs++
 				} else {
-					utils.Outf("{{orange}}on-chain tx failure:{{/}} %s %t\n", string(result.Output), result.Success)
+					utils.Outf("{{orange}}on-chain tx failure:{{/}} %s %t\n", string(result.Error), result.Success)
 				}
 			} else {
 				// We can't error match here because we receive it over the wire.
@@ -13,4 +13,8 @@ var (
 	ErrIncorrectHRP       = errors.New("incorrect hrp")
 	ErrInsufficientLength = errors.New("insufficient length")
 	ErrInvalidSize        = errors.New("invalid size")
+	ErrUnknownType        = errors.New("unknown type")
+	ErrInvalidWindow      = errors.New("invalid window")
+	ErrTypeNotActivated   = errors.New("type not activated")
+	ErrTypeDeprecated     = errors.New("type deprecated")
 )
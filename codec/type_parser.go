@@ -4,25 +4,56 @@
 package codec
 
 import (
+	"errors"
+
 	"github.com/ava-labs/hypersdk/consts"
 )
 
+// Window is the range of timestamps (in milliseconds, inclusive) that a
+// registration of a type is valid in. A negative [Start] or [End] is
+// unbounded.
+type Window struct {
+	Start int64
+	End   int64
+}
+
+// AlwaysValid is the [Window] of a type registered with [TypeParser.Register].
+var AlwaysValid = Window{-1, -1}
+
+// Verify returns [ErrTypeNotActivated] if [t] is before [w] and
+// [ErrTypeDeprecated] if [t] is after [w].
+func (w Window) Verify(t int64) error {
+	if w.Start >= 0 && t < w.Start {
+		return ErrTypeNotActivated
+	}
+	if w.End >= 0 && t > w.End {
+		return ErrTypeDeprecated
+	}
+	return nil
+}
+
+// overlaps returns true if there is any timestamp in both [w] and [o].
+func (w Window) overlaps(o Window) bool {
+	startsBeforeEnd := w.Start < 0 || o.End < 0 || w.Start <= o.End
+	endsAfterStart := w.End < 0 || o.Start < 0 || w.End >= o.Start
+	return startsBeforeEnd && endsAfterStart
+}
+
 type decoder[T any, X any, Y any] struct {
 	f func(*Packer, X) (T, error)
 	y Y
+	w Window
 }
 
 // The number of types is limited to 255.
 type TypeParser[T any, X any, Y any] struct {
-	typeToIndex    map[string]uint8
-	indexToDecoder map[uint8]*decoder[T, X, Y]
+	indexToDecoder map[uint8][]*decoder[T, X, Y]
 }
 
 // NewTypeParser returns an instance of a Typeparser with generic type [T].
 func NewTypeParser[T any, X any, Y bool]() *TypeParser[T, X, Y] {
 	return &TypeParser[T, X, Y]{
-		typeToIndex:    map[string]uint8{},
-		indexToDecoder: map[uint8]*decoder[T, X, Y]{},
+		indexToDecoder: map[uint8][]*decoder[T, X, Y]{},
 	}
 }
 
@@ -30,22 +61,62 @@ func NewTypeParser[T any, X any, Y bool]() *TypeParser[T, X, Y] {
 // the string representation of [o], and sets the decoder of that index to [f].
 // Returns an error if [o] has already been registered or the TypeParser is full.
 func (p *TypeParser[T, X, Y]) Register(id uint8, f func(*Packer, X) (T, error), y Y) error {
+	return p.RegisterWindow(id, f, y, AlwaysValid)
+}
+
+// RegisterWindow registers a version of type [id] that is only valid in [w].
+// Multiple versions of a type can be registered (to change its decoder or to
+// deprecate it) as long as their windows do not overlap.
+func (p *TypeParser[T, X, Y]) RegisterWindow(id uint8, f func(*Packer, X) (T, error), y Y, w Window) error {
 	if len(p.indexToDecoder) == int(consts.MaxUint8)+1 {
 		return ErrTooManyItems
 	}
-	if _, ok := p.indexToDecoder[id]; ok {
-		return ErrDuplicateItem
+	if w.Start >= 0 && w.End >= 0 && w.End < w.Start {
+		return ErrInvalidWindow
+	}
+	for _, d := range p.indexToDecoder[id] {
+		if d.w.overlaps(w) {
+			return ErrDuplicateItem
+		}
 	}
-	p.indexToDecoder[id] = &decoder[T, X, Y]{f, y}
+	p.indexToDecoder[id] = append(p.indexToDecoder[id], &decoder[T, X, Y]{f, y, w})
 	return nil
 }
 
 // LookupIndex returns the decoder function and success of lookup of [index]
-// from Typeparser [p].
+// from Typeparser [p]. If multiple versions of [index] are registered, the
+// most recently registered is returned.
 func (p *TypeParser[T, X, Y]) LookupIndex(index uint8) (func(*Packer, X) (T, error), Y, bool) {
-	d, ok := p.indexToDecoder[index]
+	ds, ok := p.indexToDecoder[index]
 	if ok {
+		d := ds[len(ds)-1]
 		return d.f, d.y, true
 	}
 	return nil, *new(Y), false
 }
+
+// LookupTimestamp returns the decoder function of the version of [index]
+// that is valid at [t] (and its [Window]). If no version is valid at [t],
+// [ErrTypeNotActivated] or [ErrTypeDeprecated] is returned.
+func (p *TypeParser[T, X, Y]) LookupTimestamp(
+	index uint8,
+	t int64,
+) (func(*Packer, X) (T, error), Y, Window, error) {
+	ds, ok := p.indexToDecoder[index]
+	if !ok {
+		return nil, *new(Y), Window{}, ErrUnknownType
+	}
+	var err error
+	for _, d := range ds {
+		verr := d.w.Verify(t)
+		if verr == nil {
+			return d.f, d.y, d.w, nil
+		}
+		if err == nil || errors.Is(verr, ErrTypeDeprecated) {
+			// Prefer reporting that the type was deprecated if any version
+			// ended before [t]
+			err = verr
+		}
+	}
+	return nil, *new(Y), Window{}, err
+}
@@ -94,3 +94,46 @@ func TestTypeParser(t *testing.T) {
 		require.ErrorIs(tp.Register(uint8(4), nil, true), ErrTooManyItems)
 	})
 }
+
+func TestTypeParserWindow(t *testing.T) {
+	require := require.New(t)
+	tp := NewTypeParser[Blah, any, bool]()
+
+	v1 := func(*Packer, any) (Blah, error) { return &Blah1{}, nil }
+	v2 := func(*Packer, any) (Blah, error) { return &Blah2{}, nil }
+	require.NoError(tp.RegisterWindow(0, v1, false, Window{-1, 99}))
+	require.NoError(tp.RegisterWindow(0, v2, true, Window{100, 199}))
+	require.ErrorIs(tp.RegisterWindow(0, v2, true, Window{150, -1}), ErrDuplicateItem)
+	require.ErrorIs(tp.RegisterWindow(0, v2, true, Window{300, 200}), ErrInvalidWindow)
+	require.ErrorIs(tp.Register(0, v2, true), ErrDuplicateItem)
+
+	// Versions are selected by timestamp
+	f, b, w, err := tp.LookupTimestamp(0, 99)
+	require.NoError(err)
+	require.False(b)
+	require.Equal(Window{-1, 99}, w)
+	res, err := f(nil, nil)
+	require.NoError(err)
+	require.Equal("blah1", res.Bark())
+	f, b, w, err = tp.LookupTimestamp(0, 100)
+	require.NoError(err)
+	require.True(b)
+	require.Equal(Window{100, 199}, w)
+	res, err = f(nil, nil)
+	require.NoError(err)
+	require.Equal("blah2", res.Bark())
+
+	// Types are rejected outside of their windows
+	_, _, _, err = tp.LookupTimestamp(0, 200)
+	require.ErrorIs(err, ErrTypeDeprecated)
+	_, _, _, err = tp.LookupTimestamp(1, 200)
+	require.ErrorIs(err, ErrUnknownType)
+	require.NoError(tp.RegisterWindow(1, v1, false, Window{100, -1}))
+	_, _, _, err = tp.LookupTimestamp(1, 99)
+	require.ErrorIs(err, ErrTypeNotActivated)
+
+	// The most recent version is returned without a timestamp
+	_, b, ok := tp.LookupIndex(0)
+	require.True(ok)
+	require.True(b)
+}
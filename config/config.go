@@ -20,9 +20,13 @@ func (c *Config) GetLogLevel() logging.Level                { return logging.Inf
 func (c *Config) GetAuthVerificationCores() int             { return 1 }
 func (c *Config) GetRootGenerationCores() int               { return 1 }
 func (c *Config) GetTransactionExecutionCores() int         { return 1 }
+func (c *Config) GetOptimisticTransactionExecution() bool   { return false }
 func (c *Config) GetMempoolSize() int                       { return 2_048 }
 func (c *Config) GetMempoolSponsorSize() int                { return 32 }
 func (c *Config) GetMempoolExemptSponsors() []codec.Address { return nil }
+func (c *Config) GetMempoolFeeOrdering() bool               { return false }
+func (c *Config) GetTransactionIndexing() bool              { return false }
+func (c *Config) GetTransactionTracing() bool               { return false }
 func (c *Config) GetStreamingBacklogSize() int              { return 1024 }
 func (c *Config) GetIntermediateNodeCacheSize() int         { return 4 * units.GiB }
 func (c *Config) GetStateIntermediateWriteBufferSize() int  { return 32 * units.MiB }
@@ -36,7 +40,11 @@ func (c *Config) GetParsedBlockCacheSize() int     { return 128 }
 func (c *Config) GetStateHistoryLength() int       { return 256 }
 func (c *Config) GetAcceptedBlockWindowCache() int { return 128 }    // 256MB at 2MB blocks
 func (c *Config) GetAcceptedBlockWindow() int      { return 50_000 } // ~3.5hr with 250ms block time (100GB at 2MB)
-func (c *Config) GetStateSyncMinBlocks() uint64    { return 768 }    // set to max int for archive nodes to ensure no skips
+func (c *Config) GetStateSyncMinBlocks() uint64    { return 768 }    // ignored by archive nodes (never state sync)
+func (c *Config) GetArchive() bool                 { return false }
+func (c *Config) GetAdminAPIEnabled() bool         { return false }
+func (c *Config) GetSnapshotAPIEnabled() bool      { return false }
+func (c *Config) GetWarpStateReadAPIEnabled() bool { return false }
 func (c *Config) GetAcceptorSize() int             { return 64 }
 
 func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
@@ -47,3 +55,9 @@ func (c *Config) GetTargetBuildDuration() time.Duration  { return 100 * time.Mil
 func (c *Config) GetProcessingBuildSkip() int            { return 16 }
 func (c *Config) GetTargetGossipDuration() time.Duration { return 20 * time.Millisecond }
 func (c *Config) GetBlockCompactionFrequency() int       { return 32 } // 64 MB of deletion if 2 MB blocks
+
+func (c *Config) GetWarpMaxOutstanding() int                 { return 8 }
+func (c *Config) GetWarpMaxRetries() int                     { return 10 }
+func (c *Config) GetWarpMinGatherInterval() time.Duration    { return 30 * time.Minute }
+func (c *Config) GetWarpAggregationQuorum() (uint64, uint64) { return 67, 100 }
+func (c *Config) GetWarpMaxPendingAge() time.Duration        { return 24 * time.Hour }
@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/upgrade"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Burn)(nil)
+
+// Burn is only valid once the [BurnUpgrade] is active.
+type Burn struct {
+	// Value is removed from the balance of the actor.
+	Value uint64 `json:"value"`
+}
+
+func (*Burn) GetTypeID() uint8 {
+	return mconsts.BurnID
+}
+
+func (*Burn) StateKeys(actor codec.Address, _ ids.ID) []string {
+	return []string{string(storage.BalanceKey(actor))}
+}
+
+func (*Burn) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks}
+}
+
+func (*Burn) OutputsWarpMessage() bool {
+	return false
+}
+
+func (b *Burn) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
+	if b.Value == 0 {
+		return false, 1, OutputValueZero, nil, nil, nil
+	}
+	if err := storage.SubBalance(ctx, mu, actor, b.Value); err != nil {
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
+	}
+	return true, 1, nil, nil, nil, nil
+}
+
+func (*Burn) MaxComputeUnits(chain.Rules) uint64 {
+	return BurnComputeUnits
+}
+
+func (*Burn) Size() int {
+	return consts.Uint64Len
+}
+
+func (b *Burn) Marshal(p *codec.Packer) {
+	p.PackUint64(b.Value)
+}
+
+func UnmarshalBurn(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var burn Burn
+	burn.Value = p.UnpackUint64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return &burn, nil
+}
+
+func (*Burn) ValidRange(r chain.Rules) (int64, int64) {
+	return upgrade.ValidRange(r, BurnUpgrade)
+}
@@ -3,4 +3,11 @@
 
 package actions
 
-const TransferComputeUnits = 1
+const (
+	TransferComputeUnits = 1
+	BurnComputeUnits     = 1
+)
+
+// BurnUpgrade is the name of the upgrade that activates [Burn] (it is never
+// valid if the upgrade is not scheduled).
+const BurnUpgrade = "burn"
@@ -0,0 +1,8 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import "errors"
+
+var ErrUnexpectedEvent = errors.New("unexpected event")
@@ -0,0 +1,44 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	TransferEvent uint8 = 0
+
+	transferEventDataLen = codec.AddressLen + consts.Uint64Len
+)
+
+// [Address] is the recipient and [Data] is the sender and the amount
+// transferred.
+func transferEvent(from codec.Address, to codec.Address, value uint64) *chain.Event {
+	p := codec.NewWriter(transferEventDataLen, transferEventDataLen)
+	p.PackAddress(from)
+	p.PackUint64(value)
+	return &chain.Event{
+		Type:    TransferEvent,
+		Address: to,
+		Data:    p.Bytes(),
+	}
+}
+
+// UnmarshalTransferEvent returns the sender and amount of a [TransferEvent].
+func UnmarshalTransferEvent(e *chain.Event) (codec.Address, uint64, error) {
+	if e.Type != TransferEvent {
+		return codec.EmptyAddress, 0, ErrUnexpectedEvent
+	}
+	p := codec.NewReader(e.Data, transferEventDataLen)
+	var from codec.Address
+	p.UnpackAddress(&from)
+	value := p.UnpackUint64(true)
+	if !p.Empty() {
+		return codec.EmptyAddress, 0, chain.ErrInvalidObject
+	}
+	return from, value, p.Err()
+}
@@ -17,7 +17,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action       = (*Transfer)(nil)
+	_ chain.Participants = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -31,6 +34,10 @@ func (*Transfer) GetTypeID() uint8 {
 	return mconsts.TransferID
 }
 
+func (t *Transfer) Participants() []codec.Address {
+	return []codec.Address{t.To}
+}
+
 func (t *Transfer) StateKeys(actor codec.Address, _ ids.ID) []string {
 	return []string{
 		string(storage.BalanceKey(actor)),
@@ -54,17 +61,17 @@ func (t *Transfer) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if t.Value == 0 {
-		return false, 1, OutputValueZero, nil, nil
+		return false, 1, OutputValueZero, nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, t.Value); err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, t.To, t.Value, true); err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, 1, nil, nil, nil
+	return true, 1, nil, []*chain.Event{transferEvent(actor, t.To, t.Value)}, nil, nil
 }
 
 func (*Transfer) MaxComputeUnits(chain.Rules) uint64 {
@@ -4,13 +4,22 @@
 package auth
 
 import (
+	hauth "github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/vm"
 )
 
 func Engines() map[uint8]vm.AuthEngine {
+	// Only ed25519 batch verification is supported
+	ed25519Engine := &ED25519AuthEngine{}
+	multisigEngine := hauth.NewMultisigEngine()
 	return map[uint8]vm.AuthEngine{
-		// Only ed25519 batch verification is supported
-		consts.ED25519ID: &ED25519AuthEngine{},
+		consts.ED25519ID:  ed25519Engine,
+		consts.MultisigID: multisigEngine,
+		// Wrapped signatures are batch verified with the same engines
+		consts.SponsorWrapperID: hauth.NewSponsorWrapperEngine(map[uint8]vm.AuthEngine{
+			consts.ED25519ID:  ed25519Engine,
+			consts.MultisigID: multisigEngine,
+		}),
 	}
 }
@@ -6,6 +6,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/spf13/cobra"
@@ -52,10 +53,10 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.Transfer{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.Transfer{
 			To:    recipient,
 			Value: amount,
-		}, cli, bcli, ws, factory, true)
+		}}, cli, bcli, ws, factory, true)
 		return err
 	},
 }
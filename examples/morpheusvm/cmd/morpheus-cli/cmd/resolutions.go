@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -22,14 +23,14 @@ import (
 
 // sendAndWait may not be used concurrently
 func sendAndWait(
-	ctx context.Context, warpMsg *warp.Message, action chain.Action, cli *rpc.JSONRPCClient,
+	ctx context.Context, warpMsg *warp.Message, acts []chain.Action, cli *rpc.JSONRPCClient,
 	bcli *brpc.JSONRPCClient, ws *rpc.WebSocketClient, factory chain.AuthFactory, printStatus bool,
 ) (bool, ids.ID, error) { //nolint:unparam
 	parser, err := bcli.Parser(ctx)
 	if err != nil {
 		return false, ids.Empty, err
 	}
-	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, action, factory)
+	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, acts, factory)
 	if err != nil {
 		return false, ids.Empty, err
 	}
@@ -58,22 +59,30 @@ func sendAndWait(
 }
 
 func handleTx(tx *chain.Transaction, result *chain.Result) {
-	summaryStr := string(result.Output)
+	summaryStr := string(result.Error)
 	actor := tx.Auth.Actor()
 	status := "❌"
 	if result.Success {
 		status = "✅"
-		switch action := tx.Action.(type) { //nolint:gocritic
-		case *actions.Transfer:
-			summaryStr = fmt.Sprintf("%s %s -> %s", utils.FormatBalance(action.Value, consts.Decimals), consts.Symbol, codec.MustAddressBech32(consts.HRP, action.To))
+		summaries := make([]string, 0, len(tx.Actions))
+		for _, act := range tx.Actions {
+			var summary string
+			switch action := act.(type) {
+			case *actions.Transfer:
+				summary = fmt.Sprintf("%s %s -> %s", utils.FormatBalance(action.Value, consts.Decimals), consts.Symbol, codec.MustAddressBech32(consts.HRP, action.To))
+			case *actions.Burn:
+				summary = fmt.Sprintf("%s %s burned", utils.FormatBalance(action.Value, consts.Decimals), consts.Symbol)
+			}
+			summaries = append(summaries, summary)
 		}
+		summaryStr = strings.Join(summaries, "; ")
 	}
 	utils.Outf(
 		"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}summary (%s):{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
 		status,
 		tx.ID(),
 		codec.MustAddressBech32(consts.HRP, actor),
-		reflect.TypeOf(tx.Action),
+		actionTypes(tx.Actions),
 		summaryStr,
 		float64(result.Fee)/float64(tx.Base.MaxFee)*100,
 		utils.FormatBalance(result.Fee, consts.Decimals),
@@ -81,3 +90,11 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 		cli.ParseDimensions(result.Consumed),
 	)
 }
+
+func actionTypes(acts []chain.Action) string {
+	types := make([]string, len(acts))
+	for i, act := range acts {
+		types[i] = reflect.TypeOf(act).String()
+	}
+	return strings.Join(types, ", ")
+}
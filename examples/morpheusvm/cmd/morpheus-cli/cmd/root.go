@@ -37,6 +37,8 @@ var (
 	prometheusData        string
 	startPrometheus       bool
 	maxFee                int64
+	snapshotHeight        int64
+	upgradeFile           string
 
 	rootCmd = &cobra.Command{
 		Use:        "morpheus-cli",
@@ -54,6 +56,7 @@ func init() {
 		actionCmd,
 		spamCmd,
 		prometheusCmd,
+		snapshotCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -132,6 +135,12 @@ func init() {
 		false,
 		"hide txs",
 	)
+	replayChainCmd.PersistentFlags().StringVar(
+		&upgradeFile,
+		"upgrade-file",
+		"",
+		"upgrade file of the chain (required to replay blocks after an upgrade)",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
@@ -139,6 +148,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		replayChainCmd,
 	)
 
 	// actions
@@ -203,6 +213,18 @@ func init() {
 	prometheusCmd.AddCommand(
 		generatePrometheusCmd,
 	)
+
+	// snapshot
+	exportSnapshotCmd.PersistentFlags().Int64Var(
+		&snapshotHeight,
+		"height",
+		-1,
+		"height to export (defaults to last accepted)",
+	)
+	snapshotCmd.AddCommand(
+		exportSnapshotCmd,
+		importSnapshotCmd,
+	)
 }
 
 func Execute() error {
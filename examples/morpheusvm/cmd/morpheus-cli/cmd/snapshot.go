@@ -0,0 +1,51 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+
+	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use: "snapshot",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var exportSnapshotCmd = &cobra.Command{
+	Use: "export [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ExportSnapshot(snapshotHeight, args[0])
+	},
+}
+
+var importSnapshotCmd = &cobra.Command{
+	Use: "import [path] [chain data dir]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ImportSnapshot(args[0], args[1], func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
+			cli := brpc.NewJSONRPCClient(uri, networkID, chainID)
+			return cli.Parser(context.TODO())
+		})
+	},
+}
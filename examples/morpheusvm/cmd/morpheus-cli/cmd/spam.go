@@ -106,10 +106,10 @@ var runSpamCmd = &cobra.Command{
 					if err != nil {
 						return err
 					}
-					_, _, err = sendAndWait(ictx, nil, &actions.Transfer{
+					_, _, err = sendAndWait(ictx, nil, []chain.Action{&actions.Transfer{
 						To:    priv.Address,
 						Value: count, // prevent duplicate txs
-					}, cli, bclient, wclient, factory, false)
+					}}, cli, bclient, wclient, factory, false)
 					return err
 				}
 			},
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/ava-labs/hypersdk/vm"
 
@@ -33,9 +34,25 @@ type Config struct {
 	*config.Config
 
 	// Concurrency
-	AuthVerificationCores     int `json:"authVerificationCores"`
-	RootGenerationCores       int `json:"rootGenerationCores"`
-	TransactionExecutionCores int `json:"transactionExecutionCores"`
+	AuthVerificationCores          int  `json:"authVerificationCores"`
+	RootGenerationCores            int  `json:"rootGenerationCores"`
+	TransactionExecutionCores      int  `json:"transactionExecutionCores"`
+	OptimisticTransactionExecution bool `json:"optimisticTransactionExecution"`
+
+	// Gossip
+	GossipMaxSize       int   `json:"gossipMaxSize"`
+	GossipProposerDiff  int   `json:"gossipProposerDiff"`
+	GossipProposerDepth int   `json:"gossipProposerDepth"`
+	NoGossipBuilderDiff int   `json:"noGossipBuilderDiff"`
+	VerifyTimeout       int64 `json:"verifyTimeout"`
+
+	// Gossip peer limits
+	GossipPeerRate           int   `json:"gossipPeerRate"`
+	GossipPeerBurst          int   `json:"gossipPeerBurst"`
+	GossipPeerFaultPercent   int   `json:"gossipPeerFaultPercent"`
+	GossipPeerMaxFaults      int   `json:"gossipPeerMaxFaults"`
+	GossipPeerIgnoreDuration int64 `json:"gossipPeerIgnoreDuration"`
+	GossipMaxPeers           int   `json:"gossipMaxPeers"`
 
 	// Tracing
 	TraceEnabled    bool    `json:"traceEnabled"`
@@ -51,6 +68,9 @@ type Config struct {
 	MempoolSize           int      `json:"mempoolSize"`
 	MempoolSponsorSize    int      `json:"mempoolSponsorSize"`
 	MempoolExemptSponsors []string `json:"mempoolExemptSponsors"`
+	MempoolFeeOrdering    bool     `json:"mempoolFeeOrdering"`
+	TransactionIndexing   bool     `json:"transactionIndexing"`
+	TransactionTracing    bool     `json:"transactionTracing"`
 
 	// Misc
 	VerifyAuth        bool          `json:"verifyAuth"`
@@ -61,6 +81,30 @@ type Config struct {
 	// State Sync
 	StateSyncServerDelay time.Duration `json:"stateSyncServerDelay"` // for testing
 
+	// Warp
+	WarpMaxOutstanding       int           `json:"warpMaxOutstanding"`
+	WarpMaxRetries           int           `json:"warpMaxRetries"`
+	WarpMinGatherInterval    time.Duration `json:"warpMinGatherInterval"`
+	WarpAggregationQuorumNum uint64        `json:"warpAggregationQuorumNum"`
+	WarpAggregationQuorumDen uint64        `json:"warpAggregationQuorumDen"`
+	WarpMaxPendingAge        time.Duration `json:"warpMaxPendingAge"`
+
+	// Archive retains all blocks, results, and state history (and disables
+	// state sync). It can only be enabled on a new node.
+	Archive bool `json:"archive"`
+
+	// AdminAPIEnabled serves operator-only RPCs (like gossip peer scores).
+	// It should not be exposed publicly.
+	AdminAPIEnabled bool `json:"adminAPIEnabled"`
+
+	// SnapshotAPIEnabled serves snapshots of state (which are expensive to
+	// generate) to anyone that can reach the node.
+	SnapshotAPIEnabled bool `json:"snapshotAPIEnabled"`
+
+	// WarpStateReadAPIEnabled serves attested reads of state (which request
+	// signatures from every validator) to anyone that can reach the node.
+	WarpStateReadAPIEnabled bool `json:"warpStateReadAPIEnabled"`
+
 	loaded               bool
 	nodeID               ids.NodeID
 	parsedExemptSponsors []codec.Address
@@ -75,6 +119,18 @@ func New(nodeID ids.NodeID, b []byte) (*Config, error) {
 		}
 		c.loaded = true
 	}
+	if c.WarpAggregationQuorumNum == 0 || c.WarpAggregationQuorumDen == 0 ||
+		c.WarpAggregationQuorumNum > c.WarpAggregationQuorumDen {
+		return nil, fmt.Errorf(
+			"%w: %d/%d",
+			ErrInvalidQuorum,
+			c.WarpAggregationQuorumNum,
+			c.WarpAggregationQuorumDen,
+		)
+	}
+	if c.WarpMaxOutstanding <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMaxOutstanding, c.WarpMaxOutstanding)
+	}
 
 	// Parse any exempt sponsors (usually used when a single account is
 	// broadcasting many txs at once)
@@ -91,12 +147,37 @@ func New(nodeID ids.NodeID, b []byte) (*Config, error) {
 
 func (c *Config) setDefault() {
 	c.LogLevel = c.Config.GetLogLevel()
+	gcfg := gossiper.DefaultProposerConfig()
+	c.GossipMaxSize = gcfg.GossipMaxSize
+	c.GossipProposerDiff = gcfg.GossipProposerDiff
+	c.GossipProposerDepth = gcfg.GossipProposerDepth
+	c.NoGossipBuilderDiff = gcfg.NoGossipBuilderDiff
+	c.VerifyTimeout = gcfg.VerifyTimeout
+	c.GossipPeerRate = gcfg.GossipPeerRate
+	c.GossipPeerBurst = gcfg.GossipPeerBurst
+	c.GossipPeerFaultPercent = gcfg.GossipPeerFaultPercent
+	c.GossipPeerMaxFaults = gcfg.GossipPeerMaxFaults
+	c.GossipPeerIgnoreDuration = gcfg.GossipPeerIgnoreDuration
+	c.GossipMaxPeers = gcfg.GossipMaxPeers
 	c.AuthVerificationCores = c.Config.GetAuthVerificationCores()
 	c.RootGenerationCores = c.Config.GetRootGenerationCores()
 	c.TransactionExecutionCores = c.Config.GetTransactionExecutionCores()
+	c.OptimisticTransactionExecution = c.Config.GetOptimisticTransactionExecution()
 	c.MempoolSize = c.Config.GetMempoolSize()
 	c.MempoolSponsorSize = c.Config.GetMempoolSponsorSize()
+	c.MempoolFeeOrdering = c.Config.GetMempoolFeeOrdering()
+	c.TransactionIndexing = c.Config.GetTransactionIndexing()
+	c.TransactionTracing = c.Config.GetTransactionTracing()
 	c.StateSyncServerDelay = c.Config.GetStateSyncServerDelay()
+	c.WarpMaxOutstanding = c.Config.GetWarpMaxOutstanding()
+	c.WarpMaxRetries = c.Config.GetWarpMaxRetries()
+	c.WarpMinGatherInterval = c.Config.GetWarpMinGatherInterval()
+	c.WarpAggregationQuorumNum, c.WarpAggregationQuorumDen = c.Config.GetWarpAggregationQuorum()
+	c.WarpMaxPendingAge = c.Config.GetWarpMaxPendingAge()
+	c.Archive = c.Config.GetArchive()
+	c.AdminAPIEnabled = c.Config.GetAdminAPIEnabled()
+	c.SnapshotAPIEnabled = c.Config.GetSnapshotAPIEnabled()
+	c.WarpStateReadAPIEnabled = c.Config.GetWarpStateReadAPIEnabled()
 	c.StreamingBacklogSize = c.Config.GetStreamingBacklogSize()
 	c.VerifyAuth = c.Config.GetVerifyAuth()
 	c.StoreTransactions = defaultStoreTransactions
@@ -107,9 +188,13 @@ func (c *Config) GetTestMode() bool                         { return c.TestMode
 func (c *Config) GetAuthVerificationCores() int             { return c.AuthVerificationCores }
 func (c *Config) GetRootGenerationCores() int               { return c.RootGenerationCores }
 func (c *Config) GetTransactionExecutionCores() int         { return c.TransactionExecutionCores }
+func (c *Config) GetOptimisticTransactionExecution() bool   { return c.OptimisticTransactionExecution }
 func (c *Config) GetMempoolSize() int                       { return c.MempoolSize }
 func (c *Config) GetMempoolSponsorSize() int                { return c.MempoolSponsorSize }
 func (c *Config) GetMempoolExemptSponsors() []codec.Address { return c.parsedExemptSponsors }
+func (c *Config) GetMempoolFeeOrdering() bool               { return c.MempoolFeeOrdering }
+func (c *Config) GetTransactionIndexing() bool              { return c.TransactionIndexing }
+func (c *Config) GetTransactionTracing() bool               { return c.TransactionTracing }
 func (c *Config) GetTraceConfig() *trace.Config {
 	return &trace.Config{
 		Enabled:         c.TraceEnabled,
@@ -120,7 +205,20 @@ func (c *Config) GetTraceConfig() *trace.Config {
 	}
 }
 func (c *Config) GetStateSyncServerDelay() time.Duration { return c.StateSyncServerDelay }
-func (c *Config) GetStreamingBacklogSize() int           { return c.StreamingBacklogSize }
+func (c *Config) GetWarpMaxOutstanding() int             { return c.WarpMaxOutstanding }
+func (c *Config) GetWarpMaxRetries() int                 { return c.WarpMaxRetries }
+func (c *Config) GetWarpMinGatherInterval() time.Duration {
+	return c.WarpMinGatherInterval
+}
+func (c *Config) GetWarpAggregationQuorum() (uint64, uint64) {
+	return c.WarpAggregationQuorumNum, c.WarpAggregationQuorumDen
+}
+func (c *Config) GetWarpMaxPendingAge() time.Duration { return c.WarpMaxPendingAge }
+func (c *Config) GetArchive() bool                    { return c.Archive }
+func (c *Config) GetAdminAPIEnabled() bool            { return c.AdminAPIEnabled }
+func (c *Config) GetSnapshotAPIEnabled() bool         { return c.SnapshotAPIEnabled }
+func (c *Config) GetWarpStateReadAPIEnabled() bool    { return c.WarpStateReadAPIEnabled }
+func (c *Config) GetStreamingBacklogSize() int        { return c.StreamingBacklogSize }
 func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 	if len(c.ContinuousProfilerDir) == 0 {
 		return &profiler.Config{Enabled: false}
@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import "errors"
+
+var (
+	ErrInvalidQuorum         = errors.New("invalid warp aggregation quorum")
+	ErrInvalidMaxOutstanding = errors.New("warpMaxOutstanding must be positive")
+)
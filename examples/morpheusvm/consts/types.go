@@ -6,9 +6,12 @@ package consts
 const (
 	// Action TypeIDs
 	TransferID uint8 = 0
+	BurnID     uint8 = 1
 
 	// Auth TypeIDs
-	ED25519ID   uint8 = 0
-	SECP256R1ID uint8 = 1
-	BLSID       uint8 = 2
+	ED25519ID        uint8 = 0
+	SECP256R1ID      uint8 = 1
+	BLSID            uint8 = 2
+	SponsorWrapperID uint8 = 3
+	MultisigID       uint8 = 4
 )
@@ -37,7 +37,7 @@ type Controller struct {
 	snowCtx      *snow.Context
 	genesis      *genesis.Genesis
 	config       *config.Config
-	stateManager *storage.StateManager
+	stateManager chain.StateManager
 
 	metrics *metrics
 
@@ -70,7 +70,6 @@ func (c *Controller) Initialize(
 ) {
 	c.inner = inner
 	c.snowCtx = snowCtx
-	c.stateManager = &storage.StateManager{}
 
 	// Instantiate metrics
 	var err error
@@ -95,6 +94,11 @@ func (c *Controller) Initialize(
 		)
 	}
 	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
+	if c.genesis.Nonces {
+		c.stateManager = &storage.NonceStateManager{}
+	} else {
+		c.stateManager = &storage.StateManager{}
+	}
 
 	// Create DBs
 	blockDB, stateDB, metaDB, err := hstorage.New(snowCtx.ChainDataDir, gatherer)
@@ -118,6 +122,18 @@ func (c *Controller) Initialize(
 	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
 
 	// Create builder and gossiper
+	gcfg := gossiper.DefaultProposerConfig()
+	gcfg.GossipMaxSize = c.config.GossipMaxSize
+	gcfg.GossipProposerDiff = c.config.GossipProposerDiff
+	gcfg.GossipProposerDepth = c.config.GossipProposerDepth
+	gcfg.NoGossipBuilderDiff = c.config.NoGossipBuilderDiff
+	gcfg.VerifyTimeout = c.config.VerifyTimeout
+	gcfg.GossipPeerRate = c.config.GossipPeerRate
+	gcfg.GossipPeerBurst = c.config.GossipPeerBurst
+	gcfg.GossipPeerFaultPercent = c.config.GossipPeerFaultPercent
+	gcfg.GossipPeerMaxFaults = c.config.GossipPeerMaxFaults
+	gcfg.GossipPeerIgnoreDuration = c.config.GossipPeerIgnoreDuration
+	gcfg.GossipMaxPeers = c.config.GossipMaxPeers
 	var (
 		build  builder.Builder
 		gossip gossiper.Gossiper
@@ -125,10 +141,9 @@ func (c *Controller) Initialize(
 	if c.config.TestMode {
 		c.inner.Logger().Info("running build and gossip in test mode")
 		build = builder.NewManual(inner)
-		gossip = gossiper.NewManual(inner)
+		gossip = gossiper.NewManual(inner, gcfg)
 	} else {
 		build = builder.NewTime(inner)
-		gcfg := gossiper.DefaultProposerConfig()
 		gossip, err = gossiper.NewProposer(inner, gcfg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
@@ -167,8 +182,11 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				return err
 			}
 		}
-		if result.Success {
-			switch tx.Action.(type) { //nolint:gocritic
+		if !result.Success {
+			continue
+		}
+		for _, act := range tx.Actions {
+			switch act.(type) { //nolint:gocritic
 			case *actions.Transfer:
 				c.metrics.transfer.Inc()
 			}
@@ -27,6 +27,17 @@ func (c *Controller) Tracer() trace.Tracer {
 	return c.inner.Tracer()
 }
 
+// readState returns a [storage.ReadState] for the post-execution state of the
+// accepted block at [height] (or the latest state if [height] is nil).
+func (c *Controller) readState(height *uint64) storage.ReadState {
+	if height == nil {
+		return c.inner.ReadState
+	}
+	return func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+		return c.inner.ReadStateAt(ctx, *height, keys)
+	}
+}
+
 func (c *Controller) GetTransaction(
 	ctx context.Context,
 	txID ids.ID,
@@ -37,6 +48,7 @@ func (c *Controller) GetTransaction(
 func (c *Controller) GetBalanceFromState(
 	ctx context.Context,
 	acct codec.Address,
+	height *uint64,
 ) (uint64, error) {
-	return storage.GetBalanceFromState(ctx, c.inner.ReadState, acct)
+	return storage.GetBalanceFromState(ctx, c.readState(height), acct)
 }
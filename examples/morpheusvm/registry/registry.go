@@ -9,6 +9,8 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 
+	hauth "github.com/ava-labs/hypersdk/auth"
+
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/auth"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
@@ -23,11 +25,14 @@ func init() {
 	errs.Add(
 		// When registering new actions, ALWAYS make sure to append at the end.
 		consts.ActionRegistry.Register((&actions.Transfer{}).GetTypeID(), actions.UnmarshalTransfer, false),
+		consts.ActionRegistry.Register((&actions.Burn{}).GetTypeID(), actions.UnmarshalBurn, false),
 
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519, false),
 		consts.AuthRegistry.Register((&auth.SECP256R1{}).GetTypeID(), auth.UnmarshalSECP256R1, false),
 		consts.AuthRegistry.Register((&auth.BLS{}).GetTypeID(), auth.UnmarshalBLS, false),
+		consts.AuthRegistry.Register(consts.SponsorWrapperID, hauth.NewSponsorWrapperParser(consts.SponsorWrapperID, consts.AuthRegistry), false),
+		consts.AuthRegistry.Register(consts.MultisigID, hauth.NewMultisigParser(consts.MultisigID), false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -50,6 +50,9 @@ func (cli *JSONRPCClient) Genesis(ctx context.Context) (*genesis.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := resp.Genesis.SetUpgrades(resp.Upgrades); err != nil {
+		return nil, err
+	}
 	cli.g = resp.Genesis
 	return resp.Genesis, nil
 }
@@ -74,12 +77,22 @@ func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64,
 }
 
 func (cli *JSONRPCClient) Balance(ctx context.Context, addr string) (uint64, error) {
+	return cli.balance(ctx, addr, nil)
+}
+
+// BalanceAt returns the balance of [addr] as of the accepted block at [height].
+func (cli *JSONRPCClient) BalanceAt(ctx context.Context, addr string, height uint64) (uint64, error) {
+	return cli.balance(ctx, addr, &height)
+}
+
+func (cli *JSONRPCClient) balance(ctx context.Context, addr string, height *uint64) (uint64, error) {
 	resp := new(BalanceReply)
 	err := cli.requester.SendRequest(
 		ctx,
 		"balance",
 		&BalanceArgs{
 			Address: addr,
+			Height:  height,
 		},
 		resp,
 	)
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/upgrade"
 )
 
 type JSONRPCServer struct {
@@ -23,11 +24,13 @@ func NewJSONRPCServer(c Controller) *JSONRPCServer {
 }
 
 type GenesisReply struct {
-	Genesis *genesis.Genesis `json:"genesis"`
+	Genesis  *genesis.Genesis `json:"genesis"`
+	Upgrades upgrade.Schedule `json:"upgrades"`
 }
 
 func (j *JSONRPCServer) Genesis(_ *http.Request, _ *struct{}, reply *GenesisReply) (err error) {
 	reply.Genesis = j.c.Genesis()
+	reply.Upgrades = reply.Genesis.Upgrades()
 	return nil
 }
 
@@ -62,6 +65,10 @@ func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) erro
 
 type BalanceArgs struct {
 	Address string `json:"address"`
+
+	// Height is the accepted block to read state at (the latest state is
+	// read if not provided).
+	Height *uint64 `json:"height,omitempty"`
 }
 
 type BalanceReply struct {
@@ -76,7 +83,7 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	if err != nil {
 		return err
 	}
-	balance, err := j.c.GetBalanceFromState(ctx, addr)
+	balance, err := j.c.GetBalanceFromState(ctx, addr, args.Height)
 	if err != nil {
 		return err
 	}
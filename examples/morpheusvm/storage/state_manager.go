@@ -12,10 +12,22 @@ import (
 	"github.com/ava-labs/hypersdk/state"
 )
 
-var _ (chain.StateManager) = (*StateManager)(nil)
+var (
+	_ (chain.StateManager) = (*StateManager)(nil)
+	_ (chain.NonceManager) = (*NonceStateManager)(nil)
+)
 
 type StateManager struct{}
 
+// NonceStateManager is a [StateManager] that enables account nonces.
+type NonceStateManager struct {
+	StateManager
+}
+
+func (*NonceStateManager) NonceKeyPrefix(addr codec.Address) []byte {
+	return NonceKeyPrefix(addr)
+}
+
 func (*StateManager) HeightKey() []byte {
 	return HeightKey()
 }
@@ -36,6 +36,8 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x3/ (hypersdk-fee)
 // 0x4/ (hypersdk-incoming warp)
 // 0x5/ (hypersdk-outgoing warp)
+// 0x6/ (hypersdk-nonce)
+//   -> [owner] => nonce
 
 const (
 	// metaDB
@@ -48,6 +50,7 @@ const (
 	feePrefix          = 0x3
 	incomingWarpPrefix = 0x4
 	outgoingWarpPrefix = 0x5
+	noncePrefix        = 0x6
 )
 
 const BalanceChunks uint16 = 1
@@ -272,3 +275,10 @@ func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
 	copy(k[1:], txID[:])
 	return k
 }
+
+func NonceKeyPrefix(addr codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen)
+	k[0] = noncePrefix
+	copy(k[1:], addr[:])
+	return k
+}
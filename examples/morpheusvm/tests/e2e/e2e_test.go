@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
@@ -425,10 +426,10 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    aother,
 					Value: sendAmount,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -718,10 +719,10 @@ func generateBlocks(
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    aother,
 				Value: 1,
-			},
+			}},
 			factory,
 		)
 		if failOnError {
@@ -787,10 +788,10 @@ func acceptTransaction(cli *rpc.JSONRPCClient, lcli *lrpc.JSONRPCClient) {
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    aother,
 				Value: 1,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -4,11 +4,15 @@
 package integration_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -38,8 +42,11 @@ import (
 	hbls "github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/lightclient"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/snapshot"
+	hstorage "github.com/ava-labs/hypersdk/storage"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
 
@@ -49,6 +56,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/controller"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
 	lrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+	lstorage "github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 )
 
 var (
@@ -225,7 +233,8 @@ var _ = ginkgo.BeforeSuite(func() {
 			genesisBytes,
 			nil,
 			[]byte(
-				`{"parallelism":3, "testMode":true, "logLevel":"debug"}`,
+				// instances[1] is an archive node
+				fmt.Sprintf(`{"parallelism":3, "testMode":true, "logLevel":"debug", "archive":%t, "transactionTracing":true, "snapshotAPIEnabled":true, "adminAPIEnabled":true}`, i == 1),
 			),
 			toEngine,
 			nil,
@@ -336,10 +345,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 100_000, // must be more than StateLockup
-				},
+				}},
 				factory,
 			)
 			transferTxRoot = transferTx
@@ -356,6 +365,36 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
 		})
 
+		ginkgo.By("inspect mempool", func() {
+			ctx := context.Background()
+			txIDs, next, err := instances[0].cli.GetMempoolTransactions(ctx, ids.Empty, 10)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(txIDs).Should(gomega.Equal([]ids.ID{transferTxRoot.ID()}))
+			gomega.Ω(next).Should(gomega.Equal(ids.Empty))
+
+			found, tx, err := instances[0].cli.GetMempoolTransaction(ctx, transferTxRoot.ID())
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(found).Should(gomega.BeTrue())
+			gomega.Ω(tx.Sponsor).Should(gomega.Equal(addr))
+			gomega.Ω(tx.Actions).Should(gomega.HaveLen(1))
+			gomega.Ω(tx.Actions[0].TypeID).Should(gomega.Equal((&actions.Transfer{}).GetTypeID()))
+			found, _, err = instances[0].cli.GetMempoolTransaction(ctx, ids.GenerateTestID())
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(found).Should(gomega.BeFalse())
+
+			txs, err := instances[0].cli.GetMempoolSponsorTransactions(ctx, addr, 0)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(txs).Should(gomega.HaveLen(1))
+			gomega.Ω(txs[0].ID).Should(gomega.Equal(transferTxRoot.ID()))
+
+			sponsors, maxSponsorSize, err := instances[0].cli.GetMempoolSponsors(ctx, 0)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(maxSponsorSize).Should(gomega.BeNumerically(">", 0))
+			gomega.Ω(sponsors).Should(gomega.HaveLen(1))
+			gomega.Ω(sponsors[0].Sponsor).Should(gomega.Equal(addr))
+			gomega.Ω(sponsors[0].Items).Should(gomega.Equal(1))
+		})
+
 		ginkgo.By("send gossip from node 0 to 1", func() {
 			err := instances[0].vm.Gossiper().Force(context.TODO())
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -369,10 +408,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 					MaxFee:    1000,
 				},
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 110,
-				},
+				}},
 			)
 			// Must do manual construction to avoid `tx.Sign` error (would fail with
 			// 0 timestamp)
@@ -426,7 +465,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			results := blk.(*chain.StatelessBlock).Results()
 			gomega.Ω(results).Should(gomega.HaveLen(1))
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-			gomega.Ω(results[0].Output).Should(gomega.BeNil())
+			gomega.Ω(results[0].Error).Should(gomega.BeNil())
+			gomega.Ω(results[0].Outputs).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Outputs[0]).Should(gomega.BeNil())
 
 			// Unit explanation
 			//
@@ -435,19 +476,19 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// read: 2 keys reads, 1 had 0 chunks
 			// allocate: 1 key created with 1 chunk
 			// write: 2 keys modified (new + old)
-			transferTxConsumed := chain.Dimensions{191, 7, 12, 25, 26}
+			transferTxConsumed := chain.Dimensions{192, 7, 12, 25, 26}
 			gomega.Ω(results[0].Consumed).Should(gomega.Equal(transferTxConsumed))
 
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(261)))
+			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(262)))
 		})
 
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].lcli.Balance(context.Background(), addrStr)
 			gomega.Ω(err).To(gomega.BeNil())
-			gomega.Ω(balance).To(gomega.Equal(uint64(9899739)))
+			gomega.Ω(balance).To(gomega.Equal(uint64(9899738)))
 			balance2, err := instances[1].lcli.Balance(context.Background(), addrStr2)
 			gomega.Ω(err).To(gomega.BeNil())
 			gomega.Ω(balance2).To(gomega.Equal(uint64(100000)))
@@ -462,10 +503,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 101,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -482,13 +523,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// read: 2 keys reads, 1 chunk each
 			// allocate: 0 key created
 			// write: 2 key modified
-			transferTxConsumed := chain.Dimensions{191, 7, 14, 0, 26}
+			transferTxConsumed := chain.Dimensions{192, 7, 14, 0, 26}
 			gomega.Ω(results[0].Consumed).Should(gomega.Equal(transferTxConsumed))
 
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(238)))
+			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(239)))
 
 			balance2, err := instances[1].lcli.Balance(context.Background(), addrStr2)
 			gomega.Ω(err).To(gomega.BeNil())
@@ -503,10 +544,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 102,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -515,10 +556,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 103,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -527,10 +568,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr3,
 					Value: 104,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -539,10 +580,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr3,
 					Value: 105,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -567,12 +608,12 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// allocate: 0 key created
 			// write: 2 key modified
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-			transferTxConsumed := chain.Dimensions{191, 7, 14, 0, 26}
+			transferTxConsumed := chain.Dimensions{192, 7, 14, 0, 26}
 			gomega.Ω(results[0].Consumed).Should(gomega.Equal(transferTxConsumed))
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(238)))
+			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(239)))
 
 			// Unit explanation
 			//
@@ -582,12 +623,12 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// allocate: 0 key created
 			// write: 2 keys modified
 			gomega.Ω(results[1].Success).Should(gomega.BeTrue())
-			transferTxConsumed = chain.Dimensions{191, 7, 14, 0, 26}
+			transferTxConsumed = chain.Dimensions{192, 7, 14, 0, 26}
 			gomega.Ω(results[1].Consumed).Should(gomega.Equal(transferTxConsumed))
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[1].Fee).Should(gomega.Equal(uint64(238)))
+			gomega.Ω(results[1].Fee).Should(gomega.Equal(uint64(239)))
 
 			// Unit explanation
 			//
@@ -597,12 +638,12 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// allocate: 1 key created (1 chunk)
 			// write: 2 key modified (1 chunk), both previously modified
 			gomega.Ω(results[2].Success).Should(gomega.BeTrue())
-			transferTxConsumed = chain.Dimensions{191, 7, 12, 25, 26}
+			transferTxConsumed = chain.Dimensions{192, 7, 12, 25, 26}
 			gomega.Ω(results[2].Consumed).Should(gomega.Equal(transferTxConsumed))
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[2].Fee).Should(gomega.Equal(uint64(261)))
+			gomega.Ω(results[2].Fee).Should(gomega.Equal(uint64(262)))
 
 			// Unit explanation
 			//
@@ -612,12 +653,12 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// allocate: 0 key created
 			// write: 2 keys modified (1 chunk)
 			gomega.Ω(results[3].Success).Should(gomega.BeTrue())
-			transferTxConsumed = chain.Dimensions{191, 7, 12, 0, 26}
+			transferTxConsumed = chain.Dimensions{192, 7, 12, 0, 26}
 			gomega.Ω(results[3].Consumed).Should(gomega.Equal(transferTxConsumed))
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[3].Fee).Should(gomega.Equal(uint64(236)))
+			gomega.Ω(results[3].Fee).Should(gomega.Equal(uint64(237)))
 
 			// Check end balance
 			balance2, err := instances[1].lcli.Balance(context.Background(), addrStr2)
@@ -639,10 +680,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 200,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -653,10 +694,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 201,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -682,10 +723,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr2,
 					Value: 203,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -773,7 +814,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -789,7 +830,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		blk, lresults, prices, err := cli.ListenBlock(context.TODO(), parser)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(len(blk.Txs)).Should(gomega.Equal(1))
-		tx := blk.Txs[0].Action.(*actions.Transfer)
+		tx := blk.Txs[0].Actions[0].(*actions.Transfer)
 		gomega.Ω(tx.Value).To(gomega.Equal(uint64(1)))
 		gomega.Ω(lresults).Should(gomega.Equal(results))
 		gomega.Ω(prices).Should(gomega.Equal(chain.Dimensions{1, 1, 1, 1, 1}))
@@ -821,7 +862,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -870,10 +911,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    r1addr,
 					Value: 2000,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -895,10 +936,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr,
 					Value: 100,
-				},
+				}},
 				r1factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -910,6 +951,454 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		})
 	})
 
+	ginkgo.It("reads balances at previous heights", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		otherStr := codec.MustAddressBech32(lconsts.HRP, auth.NewED25519Address(other.PublicKey()))
+		start := instances[0].vm.LastAcceptedBlock().Height()
+
+		parser, err := instances[0].lcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    auth.NewED25519Address(other.PublicKey()),
+				Value: 500,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		end := instances[0].vm.LastAcceptedBlock().Height()
+		gomega.Ω(end).Should(gomega.Equal(start + 1))
+
+		balance, err := instances[0].lcli.BalanceAt(context.Background(), otherStr, start)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+		balance, err = instances[0].lcli.BalanceAt(context.Background(), otherStr, end)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(500)))
+
+		// Sender balance at [start] includes the transfer and fee
+		prev, err := instances[0].lcli.BalanceAt(context.Background(), addrStr, start)
+		gomega.Ω(err).Should(gomega.BeNil())
+		curr, err := instances[0].lcli.Balance(context.Background(), addrStr)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(prev - curr).Should(gomega.Equal(500 + results[0].Fee))
+
+		// Heights that have not been accepted can't be read
+		_, err = instances[0].lcli.BalanceAt(context.Background(), otherStr, end+1)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(vm.ErrHeightNotAccepted.Error()))
+	})
+
+	ginkgo.It("reads balances at any height on archive nodes", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		otherStr := codec.MustAddressBech32(lconsts.HRP, auth.NewED25519Address(other.PublicKey()))
+		genesisBalance, err := instances[1].lcli.BalanceAt(context.Background(), addrStr, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(genesisBalance).Should(gomega.Equal(uint64(10_000_000)))
+
+		parser, err := instances[1].lcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[1].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    auth.NewED25519Address(other.PublicKey()),
+				Value: 700,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[1])
+		results := accept(false)
+		gomega.Ω(results).ShouldNot(gomega.BeEmpty()) // may include previously gossiped txs
+		for _, result := range results {
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+		}
+		end := instances[1].vm.LastAcceptedBlock().Height()
+
+		balance, err := instances[1].lcli.BalanceAt(context.Background(), otherStr, end-1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+		balance, err = instances[1].lcli.BalanceAt(context.Background(), otherStr, end)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(700)))
+		genesisBalance, err = instances[1].lcli.BalanceAt(context.Background(), addrStr, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(genesisBalance).Should(gomega.Equal(uint64(10_000_000)))
+
+		_, err = instances[1].lcli.BalanceAt(context.Background(), otherStr, end+1)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(vm.ErrHeightNotAccepted.Error()))
+	})
+
+	ginkgo.It("replays accepted blocks", func() {
+		height := instances[1].vm.LastAcceptedBlock().Height()
+		gomega.Ω(height).Should(gomega.BeNumerically(">", 1))
+		div, err := instances[1].vm.Replay(context.Background(), 1, height)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(div).Should(gomega.BeNil())
+
+		_, err = instances[1].vm.Replay(context.Background(), height, height+1)
+		gomega.Ω(err).Should(gomega.MatchError(vm.ErrInvalidReplayRange))
+
+		// Blocks in the retained state history can be replayed on
+		// running non-archive nodes over the admin API
+		hd, err := instances[0].vm.CreateHandlers(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		adminServer := httptest.NewServer(hd[rpc.AdminJSONRPCEndpoint])
+		defer adminServer.Close()
+		acli := rpc.NewAdminJSONRPCClient(adminServer.URL)
+		div, err = acli.Replay(context.Background(), 1, height)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(div).Should(gomega.BeNil())
+		_, err = acli.Replay(context.Background(), height, height+1)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(vm.ErrInvalidReplayRange.Error())))
+	})
+
+	ginkgo.It("exports and imports state snapshots", func() {
+		hd, err := instances[0].vm.CreateHandlers(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		snapshotServer := httptest.NewServer(hd[rpc.SnapshotEndpoint])
+		defer snapshotServer.Close()
+		var buf bytes.Buffer
+		m, err := rpc.DownloadSnapshot(context.Background(), snapshotServer.URL, nil, &buf)
+		gomega.Ω(err).Should(gomega.BeNil())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(m.Height).Should(gomega.Equal(blk.Height()))
+		gomega.Ω(m.BlockID).Should(gomega.Equal(blk.ID()))
+		gomega.Ω(m.Height).Should(gomega.BeNumerically(">", 1))
+
+		// The root of the snapshot is only committed to by the child of [blk]
+		parser, err := instances[0].lcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    auth.NewED25519Address(other.PublicKey()),
+				Value: 1,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		expectBlk(instances[0])(false)
+		genesisBlk, err := instances[0].cli.GetBlockByHeight(context.Background(), 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		child, err := instances[0].cli.GetBlockByHeight(context.Background(), m.Height+1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		checkpoint := &vm.SnapshotCheckpoint{
+			NetworkID: networkID,
+			ChainID:   instances[0].chainID,
+			GenesisID: genesisBlk.BlockID,
+			BlockID:   blk.ID(),
+			StateRoot: child.Header.StateRoot,
+		}
+
+		// Snapshots that don't match the checkpoint are rejected
+		for _, tt := range []struct {
+			modify func(*vm.SnapshotCheckpoint)
+			err    error
+		}{
+			{func(c *vm.SnapshotCheckpoint) { c.NetworkID++ }, vm.ErrUnexpectedChain},
+			{func(c *vm.SnapshotCheckpoint) { c.ChainID = ids.GenerateTestID() }, vm.ErrUnexpectedChain},
+			{func(c *vm.SnapshotCheckpoint) { c.GenesisID = ids.GenerateTestID() }, vm.ErrUnexpectedBlock},
+			{func(c *vm.SnapshotCheckpoint) { c.BlockID = ids.GenerateTestID() }, vm.ErrUnexpectedBlock},
+			{func(c *vm.SnapshotCheckpoint) { c.StateRoot = ids.GenerateTestID() }, vm.ErrUnexpectedStateRoot},
+		} {
+			invalid := *checkpoint
+			tt.modify(&invalid)
+			_, err = vm.ImportSnapshot(context.Background(), bytes.NewReader(buf.Bytes()), parser, &invalid, memdb.New(), memdb.New())
+			gomega.Ω(err).Should(gomega.MatchError(tt.err))
+		}
+
+		// Blocks must link back to the checkpoint
+		rewrite := func(modify func(*snapshot.Record) bool) []byte {
+			sr, err := snapshot.NewReader(bytes.NewReader(buf.Bytes()))
+			gomega.Ω(err).Should(gomega.BeNil())
+			var out bytes.Buffer
+			sw, err := snapshot.NewWriter(&out, sr.Manifest())
+			gomega.Ω(err).Should(gomega.BeNil())
+			for {
+				record, err := sr.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				gomega.Ω(err).Should(gomega.BeNil())
+				if !modify(record) {
+					continue
+				}
+				switch record.Type {
+				case snapshot.BlockRecord:
+					gomega.Ω(sw.WriteBlock(record.Height, record.Block)).Should(gomega.BeNil())
+				case snapshot.KeyValueRecord:
+					gomega.Ω(sw.WriteKeyValue(record.Key, record.Value)).Should(gomega.BeNil())
+				}
+			}
+			gomega.Ω(sw.Close()).Should(gomega.BeNil())
+			return out.Bytes()
+		}
+		missingParent := rewrite(func(r *snapshot.Record) bool {
+			return r.Type != snapshot.BlockRecord || r.Height != m.Height-1
+		})
+		_, err = vm.ImportSnapshot(context.Background(), bytes.NewReader(missingParent), parser, checkpoint, memdb.New(), memdb.New())
+		gomega.Ω(err).Should(gomega.MatchError(vm.ErrMissingBlock))
+		wrongParent := rewrite(func(r *snapshot.Record) bool {
+			if r.Type == snapshot.BlockRecord && r.Height == m.Height-1 {
+				// Replace the parent with a different block at the same height
+				parent, err := chain.UnmarshalBlock(r.Block, parser)
+				gomega.Ω(err).Should(gomega.BeNil())
+				parent.Tmstmp++
+				r.Block, err = parent.Marshal()
+				gomega.Ω(err).Should(gomega.BeNil())
+			}
+			return true
+		})
+		_, err = vm.ImportSnapshot(context.Background(), bytes.NewReader(wrongParent), parser, checkpoint, memdb.New(), memdb.New())
+		gomega.Ω(err).Should(gomega.MatchError(vm.ErrUnexpectedBlock))
+
+		// Import into the databases of a new node
+		dname, err := os.MkdirTemp("", "snapshot-chainData")
+		gomega.Ω(err).Should(gomega.BeNil())
+		defer os.RemoveAll(dname)
+		vmDB, stateDB, metaDB, err := hstorage.New(dname, nil)
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = vm.ImportSnapshot(context.Background(), bytes.NewReader(buf.Bytes()), parser, checkpoint, vmDB, stateDB)
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = vm.ImportSnapshot(context.Background(), bytes.NewReader(buf.Bytes()), parser, checkpoint, vmDB, stateDB)
+		gomega.Ω(err).Should(gomega.MatchError(vm.ErrDatabaseNotEmpty))
+		gomega.Ω(vmDB.Close()).Should(gomega.BeNil())
+		gomega.Ω(stateDB.Close()).Should(gomega.BeNil())
+		gomega.Ω(metaDB.Close()).Should(gomega.BeNil())
+
+		// Start the node from the snapshot
+		sk, err := bls.NewSecretKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		snowCtx := &snow.Context{
+			NetworkID:      networkID,
+			ChainID:        instances[0].chainID,
+			NodeID:         ids.GenerateTestNodeID(),
+			Log:            logging.NoLog{},
+			ChainDataDir:   dname,
+			Metrics:        metrics.NewOptionalGatherer(),
+			PublicKey:      bls.PublicFromSecretKey(sk),
+			WarpSigner:     warp.NewSigner(sk, networkID, instances[0].chainID),
+			ValidatorState: &validators.TestState{},
+		}
+		v := controller.New()
+		gomega.Ω(v.Initialize(
+			context.Background(),
+			snowCtx,
+			memdb.New(),
+			genesisBytes,
+			nil,
+			[]byte(`{"testMode":true}`),
+			make(chan common.Message, 1),
+			nil,
+			&appSender{instances: instances},
+		)).Should(gomega.BeNil())
+		defer func() {
+			gomega.Ω(v.Shutdown(context.Background())).Should(gomega.BeNil())
+		}()
+		v.ForceReady()
+		gomega.Ω(v.LastAcceptedBlock().ID()).Should(gomega.Equal(m.BlockID))
+
+		hd, err = v.CreateHandlers(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(hd).ShouldNot(gomega.HaveKey(rpc.SnapshotEndpoint)) // disabled by default
+		lserver := httptest.NewServer(hd[lrpc.JSONRPCEndpoint])
+		defer lserver.Close()
+		lcli := lrpc.NewJSONRPCClient(lserver.URL, networkID, instances[0].chainID)
+		expected, err := instances[0].lcli.BalanceAt(context.Background(), addrStr, m.Height)
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := lcli.Balance(context.Background(), addrStr)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(expected))
+	})
+
+	ginkgo.It("proves state against block headers", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		g, err := instances[0].lcli.Genesis(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		keys := [][]byte{
+			lstorage.BalanceKey(addr),
+			lstorage.BalanceKey(auth.NewED25519Address(other.PublicKey())),
+		}
+
+		// The root in [blk] is the post-execution state of its parent
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Height()).Should(gomega.BeNumerically(">", 0))
+		balance, err := instances[0].lcli.BalanceAt(context.Background(), addrStr, blk.Height()-1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		values, err := instances[0].cli.GetVerifiedState(context.Background(), blk.StatefulBlock, g.GetStateBranchFactor(), keys)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(values).Should(gomega.HaveLen(2))
+		gomega.Ω(values[0].HasValue()).Should(gomega.BeTrue())
+		gomega.Ω(binary.BigEndian.Uint64(values[0].Value())).Should(gomega.Equal(balance))
+		gomega.Ω(values[1].IsNothing()).Should(gomega.BeTrue())
+
+		// Proofs don't verify against a different root
+		reply, err := instances[0].cli.GetStateProof(context.Background(), blk.Height(), keys)
+		gomega.Ω(err).Should(gomega.BeNil())
+		header := *blk.StatefulBlock
+		header.StateRoot = ids.GenerateTestID()
+		reply.StateRoot = header.StateRoot
+		_, err = rpc.VerifyStateProof(context.Background(), &header, g.GetStateBranchFactor(), keys, reply)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(rpc.ErrInvalidProof.Error())))
+
+		// Heights that have not been accepted can't be proven
+		_, err = instances[0].cli.GetStateProof(context.Background(), blk.Height()+1, keys)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+	})
+
+	ginkgo.It("follows headers with a light client", func() {
+		g, err := instances[0].lcli.Genesis(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].lcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		checkpoint := instances[0].vm.LastAcceptedBlock()
+		lc, err := lightclient.New(instances[0].cli, parser, g.GetStateBranchFactor(), checkpoint.StatefulBlock, 16)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Follow accepted blocks
+		ws, err := rpc.NewWebSocketClient(instances[0].WebSocketServer.URL, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		gomega.Ω(err).Should(gomega.BeNil())
+		ctx, cancel := context.WithCancel(context.Background())
+		followErr := make(chan error, 1)
+		go func() {
+			followErr <- lc.Follow(ctx, ws)
+		}()
+		time.Sleep(2 * pubsub.MaxMessageWait)
+
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		otherAddr := auth.NewED25519Address(other.PublicKey())
+		for i := 0; i < 2; i++ {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    otherAddr,
+					Value: uint64(100 + i), // avoid duplicate txs
+				}},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		}
+		gomega.Eventually(func() uint64 {
+			return lc.Latest().Hght
+		}, 5*time.Second).Should(gomega.Equal(checkpoint.Height() + 2))
+		cancel()
+		gomega.Ω(<-followErr).Should(gomega.MatchError(context.Canceled))
+		gomega.Ω(ws.Close()).Should(gomega.BeNil())
+
+		// Read verified state after each transfer
+		key := [][]byte{lstorage.BalanceKey(otherAddr)}
+		values, err := lc.GetStateAt(context.Background(), checkpoint.Height(), key)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(values[0].IsNothing()).Should(gomega.BeTrue())
+		height, values, err := lc.GetState(context.Background(), key)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(height).Should(gomega.Equal(checkpoint.Height() + 1))
+		gomega.Ω(binary.BigEndian.Uint64(values[0].Value())).Should(gomega.Equal(uint64(100)))
+
+		// The child of the latest header is not known yet
+		_, err = lc.GetStateAt(context.Background(), checkpoint.Height()+2, key)
+		gomega.Ω(err).Should(gomega.MatchError(lightclient.ErrUnknownHeader))
+	})
+
+	ginkgo.It("serves accepted blocks with decoded txs", func() {
+		parser, err := instances[0].lcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs).ShouldNot(gomega.BeEmpty())
+
+		reply, err := instances[0].cli.GetBlockByHeight(context.Background(), blk.Height())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reply.BlockID).Should(gomega.Equal(blk.ID()))
+		gomega.Ω(reply.Header.StateRoot).Should(gomega.Equal(blk.StateRoot))
+		gomega.Ω(reply.Txs).Should(gomega.HaveLen(len(blk.Txs)))
+		gomega.Ω(reply.Results).Should(gomega.HaveLen(len(blk.Txs)))
+		for i, tx := range reply.Txs {
+			gomega.Ω(tx.ID).Should(gomega.Equal(blk.Txs[i].ID()))
+			gomega.Ω(tx.Actions[0].TypeID).Should(gomega.Equal((&actions.Transfer{}).GetTypeID()))
+			gomega.Ω(reply.Results[i].Success).Should(gomega.BeTrue())
+		}
+		decoded, err := chain.UnmarshalBlock(reply.Bytes, parser)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(decoded.Hght).Should(gomega.Equal(blk.Height()))
+
+		byID, err := instances[0].cli.GetBlockByID(context.Background(), blk.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(byID.Header.Height).Should(gomega.Equal(blk.Height()))
+
+		blocks, err := instances[0].cli.GetBlockRange(context.Background(), 0, 2*rpc.MaxBlockRange)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(len(blocks)).Should(gomega.BeNumerically("<=", rpc.MaxBlockRange))
+		for i, b := range blocks {
+			gomega.Ω(b.Header.Height).Should(gomega.Equal(uint64(i)))
+			gomega.Ω(b.Results).Should(gomega.HaveLen(len(b.Txs)))
+		}
+
+		_, err = instances[0].cli.GetBlockByHeight(context.Background(), blk.Height()+1)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+		_, err = instances[0].cli.GetBlockByID(context.Background(), ids.GenerateTestID())
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+	})
+
+	ginkgo.It("traces accepted transactions", func() {
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs).ShouldNot(gomega.BeEmpty())
+		result := blk.Results()[0]
+
+		found, trace, err := instances[0].cli.TraceTx(context.Background(), blk.Txs[0].ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(found).Should(gomega.BeTrue())
+		gomega.Ω(trace.Height).Should(gomega.Equal(blk.Height()))
+		gomega.Ω(trace.Consumed).Should(gomega.Equal(result.Consumed))
+		var fee uint64
+		for _, f := range trace.Fees {
+			fee += f
+		}
+		gomega.Ω(fee).Should(gomega.Equal(result.Fee))
+		gomega.Ω(trace.Reads).ShouldNot(gomega.BeEmpty())
+		gomega.Ω(trace.Writes).ShouldNot(gomega.BeEmpty())
+		var inserts int
+		for _, access := range trace.Accesses {
+			if access.Type == "insert" {
+				gomega.Ω(access.After).ShouldNot(gomega.Equal(access.Before))
+				inserts++
+			}
+		}
+		gomega.Ω(inserts).Should(gomega.BeNumerically(">", 0))
+
+		found, _, err = instances[0].cli.TraceTx(context.Background(), ids.GenerateTestID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(found).Should(gomega.BeFalse())
+	})
+
 	ginkgo.It("sends tokens between ed25519 and bls addresses", func() {
 		r1priv, err := hbls.GeneratePrivateKey()
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -924,10 +1413,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    r1addr,
 					Value: 2000,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -949,10 +1438,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    addr,
 					Value: 100,
-				},
+				}},
 				r1factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -963,6 +1452,255 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 		})
 	})
+
+	ginkgo.It("verifies blocks across an upgrade", func() {
+		// The upgrade is scheduled in the past, so blocks on both sides of it
+		// can be built with explicit timestamps
+		upgradeTime := time.Now().Add(-time.Minute).Truncate(time.Second).UnixMilli()
+		upgradeBytes := []byte(fmt.Sprintf(
+			`{"upgrades":[{"name":%q,"timestamp":%d,"params":{"maxActionsPerTx":8}}]}`,
+			actions.BurnUpgrade, upgradeTime,
+		))
+
+		// nodes[0] builds each block and nodes[1] verifies it
+		chainID := ids.GenerateTestID()
+		nodes := make([]*vm.VM, 2)
+		for i := range nodes {
+			sk, err := bls.NewSecretKey()
+			gomega.Ω(err).Should(gomega.BeNil())
+			dname, err := os.MkdirTemp("", "upgrade-chainData")
+			gomega.Ω(err).Should(gomega.BeNil())
+			defer os.RemoveAll(dname)
+			snowCtx := &snow.Context{
+				NetworkID:      networkID,
+				ChainID:        chainID,
+				NodeID:         ids.GenerateTestNodeID(),
+				Log:            logging.NoLog{},
+				ChainDataDir:   dname,
+				Metrics:        metrics.NewOptionalGatherer(),
+				PublicKey:      bls.PublicFromSecretKey(sk),
+				WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+				ValidatorState: &validators.TestState{},
+			}
+			v := controller.New()
+			gomega.Ω(v.Initialize(
+				context.Background(),
+				snowCtx,
+				memdb.New(),
+				genesisBytes,
+				upgradeBytes,
+				[]byte(`{"testMode":true}`),
+				make(chan common.Message, 1),
+				nil,
+				&appSender{instances: instances},
+			)).Should(gomega.BeNil())
+			defer func() {
+				gomega.Ω(v.Shutdown(context.Background())).Should(gomega.BeNil())
+			}()
+			v.ForceReady()
+			nodes[i] = v
+		}
+		gomega.Ω(nodes[0].Rules(upgradeTime - 1).GetMaxActionsPerTx()).Should(gomega.Equal(gen.MaxActionsPerTx))
+		gomega.Ω(nodes[0].Rules(upgradeTime).GetMaxActionsPerTx()).Should(gomega.Equal(uint8(8)))
+
+		newTx := func(acts ...chain.Action) *chain.Transaction {
+			tx, err := chain.NewTx(
+				&chain.Base{
+					Timestamp: upgradeTime + 10*consts.MillisecondsPerSecond,
+					ChainID:   chainID,
+					MaxFee:    1_000_000,
+				},
+				nil,
+				acts,
+			).Sign(factory, lconsts.ActionRegistry, lconsts.AuthRegistry)
+			gomega.Ω(err).Should(gomega.BeNil())
+			return tx
+		}
+		newTransfers := func(n int, value uint64) []chain.Action {
+			transfers := make([]chain.Action, n)
+			for i := range transfers {
+				transfers[i] = &actions.Transfer{To: addr2, Value: value}
+			}
+			return transfers
+		}
+
+		// process builds a block at [tmstmp] with [tx] on nodes[0] and
+		// verifies (and accepts, if valid) it on every node.
+		process := func(tmstmp int64, tx *chain.Transaction) error {
+			ctx := context.Background()
+			parent := nodes[0].LastAcceptedBlock()
+			blk := chain.NewBlock(nodes[0], parent, tmstmp)
+			blk.Txs = []*chain.Transaction{tx}
+			db, err := nodes[0].State()
+			gomega.Ω(err).Should(gomega.BeNil())
+			blk.StateRoot, err = db.GetMerkleRoot(ctx)
+			gomega.Ω(err).Should(gomega.BeNil())
+			view, err := parent.View(ctx, false)
+			gomega.Ω(err).Should(gomega.BeNil())
+			r := nodes[0].Rules(tmstmp)
+			// If [tx] is invalid, the block is rejected before its events are
+			// checked
+			result, _, err := tx.Simulate(ctx, chain.NewFeeManager(nil), nodes[0].StateManager(), r, view, tmstmp)
+			if err == nil {
+				blk.EventsRoot, err = chain.EventsRoot([]*chain.Result{result})
+				gomega.Ω(err).Should(gomega.BeNil())
+			}
+			source, err := blk.Marshal()
+			gomega.Ω(err).Should(gomega.BeNil())
+
+			var verr error
+			for i, node := range nodes {
+				sblk, err := node.ParseBlock(ctx, source)
+				gomega.Ω(err).Should(gomega.BeNil())
+				err = sblk.Verify(ctx)
+				if i == 0 {
+					verr = err
+				} else {
+					// All nodes must agree on the validity of the block
+					gomega.Ω(fmt.Sprint(err)).Should(gomega.Equal(fmt.Sprint(verr)))
+				}
+				if err != nil {
+					continue
+				}
+				gomega.Ω(sblk.Accept(ctx)).Should(gomega.BeNil())
+				results := sblk.(*chain.StatelessBlock).Results()
+				gomega.Ω(results).Should(gomega.HaveLen(1))
+				gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+				gomega.Ω(node.LastAcceptedBlock().Tmstmp).Should(gomega.Equal(tmstmp))
+			}
+			return verr
+		}
+
+		ginkgo.By("reject actions that are not activated before the upgrade", func() {
+			err := process(upgradeTime-consts.MillisecondsPerSecond, newTx(&actions.Burn{Value: 1}))
+			gomega.Ω(err).Should(gomega.MatchError(chain.ErrActionNotActivated))
+		})
+
+		ginkgo.By("accept a block before the upgrade", func() {
+			gomega.Ω(process(upgradeTime-consts.MillisecondsPerSecond, newTx(newTransfers(9, 1)...))).Should(gomega.BeNil())
+		})
+
+		ginkgo.By("reject txs that are invalid after the upgrade", func() {
+			err := process(upgradeTime, newTx(newTransfers(9, 2)...))
+			gomega.Ω(err).Should(gomega.MatchError(chain.ErrTooManyActions))
+		})
+
+		ginkgo.By("accept a block after the upgrade", func() {
+			acts := append([]chain.Action{&actions.Burn{Value: 1}}, newTransfers(7, 2)...)
+			gomega.Ω(process(upgradeTime, newTx(acts...))).Should(gomega.BeNil())
+		})
+	})
+
+	ginkgo.It("orders transactions by nonce", func() {
+		ctx := context.Background()
+		ngen := *gen
+		ngen.Nonces = true
+		ngenesisBytes, err := json.Marshal(&ngen)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		sk, err := bls.NewSecretKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		dname, err := os.MkdirTemp("", "nonce-chainData")
+		gomega.Ω(err).Should(gomega.BeNil())
+		defer os.RemoveAll(dname)
+		chainID := ids.GenerateTestID()
+		snowCtx := &snow.Context{
+			NetworkID:      networkID,
+			ChainID:        chainID,
+			NodeID:         ids.GenerateTestNodeID(),
+			Log:            logging.NoLog{},
+			ChainDataDir:   dname,
+			Metrics:        metrics.NewOptionalGatherer(),
+			PublicKey:      bls.PublicFromSecretKey(sk),
+			WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+			ValidatorState: &validators.TestState{},
+		}
+		toEngine := make(chan common.Message, 1)
+		v := controller.New()
+		gomega.Ω(v.Initialize(
+			ctx,
+			snowCtx,
+			memdb.New(),
+			ngenesisBytes,
+			nil,
+			[]byte(`{"testMode":true}`),
+			toEngine,
+			nil,
+			&appSender{instances: instances},
+		)).Should(gomega.BeNil())
+		defer func() {
+			gomega.Ω(v.Shutdown(context.Background())).Should(gomega.BeNil())
+		}()
+		v.ForceReady()
+
+		newTx := func(nonce uint64, value uint64) *chain.Transaction {
+			tx, err := chain.NewTx(
+				&chain.Base{
+					Timestamp: hutils.UnixRMilli(-1, 10*consts.MillisecondsPerSecond),
+					ChainID:   chainID,
+					MaxFee:    1_000_000,
+					Nonce:     nonce,
+				},
+				nil,
+				[]chain.Action{&actions.Transfer{To: addr2, Value: value}},
+			).Sign(factory, lconsts.ActionRegistry, lconsts.AuthRegistry)
+			gomega.Ω(err).Should(gomega.BeNil())
+			return tx
+		}
+		tx0, tx1 := newTx(0, 1), newTx(1, 1)
+
+		ginkgo.By("submit a transaction before the one that fills its nonce gap", func() {
+			// The VM is ready once it has processed the forced sync
+			gomega.Eventually(func() error {
+				_, err := v.GetNonce(ctx, addr)
+				return err
+			}).Should(gomega.BeNil())
+			for _, err := range v.Submit(ctx, true, []*chain.Transaction{tx1, tx0}) {
+				gomega.Ω(err).Should(gomega.BeNil())
+			}
+			nonce, err := v.GetNonce(ctx, addr)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(nonce).Should(gomega.Equal(uint64(2)))
+		})
+
+		ginkgo.By("execute the transactions in nonce order", func() {
+			gomega.Ω(v.Builder().Force(ctx)).Should(gomega.BeNil())
+			<-toEngine
+			blk, err := v.BuildBlock(ctx)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(blk.Verify(ctx)).Should(gomega.BeNil())
+			gomega.Ω(v.SetPreference(ctx, blk.ID())).Should(gomega.BeNil())
+			gomega.Ω(blk.Accept(ctx)).Should(gomega.BeNil())
+
+			sblk := blk.(*chain.StatelessBlock)
+			gomega.Ω(sblk.Txs).Should(gomega.HaveLen(2))
+			gomega.Ω(sblk.Txs[0].ID()).Should(gomega.Equal(tx0.ID()))
+			gomega.Ω(sblk.Txs[1].ID()).Should(gomega.Equal(tx1.ID()))
+			results := sblk.Results()
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+			gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+
+			// A nonce of 0 is not encoded
+			gomega.Ω(results[1].Consumed[chain.Bandwidth]).Should(gomega.Equal(results[0].Consumed[chain.Bandwidth] + consts.Uint64Len))
+
+			// The estimate includes the nonce of the sponsor
+			r := v.Rules(sblk.Tmstmp)
+			maxUnits, err := chain.EstimateMaxUnits(r, tx1.Actions, factory, nil, true)
+			gomega.Ω(err).Should(gomega.BeNil())
+			for i := range maxUnits {
+				gomega.Ω(maxUnits[i]).Should(gomega.BeNumerically(">=", results[1].Consumed[i]))
+			}
+		})
+
+		ginkgo.By("reject nonces that are too low or leave a gap", func() {
+			errs := v.Submit(ctx, true, []*chain.Transaction{newTx(1, 2), newTx(3, 2)})
+			gomega.Ω(errs[0]).Should(gomega.MatchError(chain.ErrNonceTooLow))
+			gomega.Ω(errs[1]).Should(gomega.MatchError(chain.ErrNonceTooHigh))
+			nonce, err := v.GetNonce(ctx, addr)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(nonce).Should(gomega.Equal(uint64(2)))
+		})
+	})
 })
 
 func expectBlk(i instance) func(bool) []*chain.Result {
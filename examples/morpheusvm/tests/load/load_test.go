@@ -410,7 +410,7 @@ var _ = ginkgo.Describe("load tests vm", func() {
 				for _, result := range blk.Results() {
 					if !result.Success {
 						unitPrices, _ := instances[0].cli.UnitPrices(context.Background(), false)
-						fmt.Println("tx failed", "unit prices:", unitPrices, "consumed:", result.Consumed, "fee:", result.Fee, "output:", string(result.Output))
+						fmt.Println("tx failed", "unit prices:", unitPrices, "consumed:", result.Consumed, "fee:", result.Fee, "error:", string(result.Error))
 					}
 					gomega.Ω(result.Success).Should(gomega.BeTrue())
 				}
@@ -542,10 +542,10 @@ func issueSimpleTx(
 			MaxFee:    maxFee,
 		},
 		nil,
-		&actions.Transfer{
+		[]chain.Action{&actions.Transfer{
 			To:    to,
 			Value: amount,
-		},
+		}},
 	)
 	tx, err := tx.Sign(factory, consts.ActionRegistry, consts.AuthRegistry)
 	gomega.Ω(err).To(gomega.BeNil())
@@ -55,28 +55,28 @@ func (b *BurnAsset) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if b.Value == 0 {
-		return false, BurnComputeUnits, OutputValueZero, nil, nil
+		return false, BurnComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, b.Asset, b.Value); err != nil {
-		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	exists, symbol, decimals, metadata, supply, owner, warp, err := storage.GetAsset(ctx, mu, b.Asset)
 	if err != nil {
-		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, BurnComputeUnits, OutputAssetMissing, nil, nil
+		return false, BurnComputeUnits, OutputAssetMissing, nil, nil, nil
 	}
 	newSupply, err := smath.Sub(supply, b.Value)
 	if err != nil {
-		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.SetAsset(ctx, mu, b.Asset, symbol, decimals, metadata, newSupply, owner, warp); err != nil {
-		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, BurnComputeUnits, nil, nil, nil
+	return true, BurnComputeUnits, nil, nil, nil, nil
 }
 
 func (*BurnAsset) MaxComputeUnits(chain.Rules) uint64 {
@@ -0,0 +1,130 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*CheckRemoteBalance)(nil)
+
+// CheckRemoteBalance succeeds only if a [chain.WarpStateRead] from another
+// tokenvm attests that [Owner] held at least [Min] of [Asset] on it. Nothing is
+// bridged, so it can be combined with other actions in the same transaction to
+// make them conditional on the remote balance.
+//
+// Reads older than the validity window (measured from the timestamp of the
+// block that executes the transaction) are rejected.
+type CheckRemoteBalance struct {
+	// Owner is the account whose balance is checked on the source chain.
+	Owner codec.Address `json:"owner"`
+
+	// Asset is the ID of the asset on the source chain.
+	Asset ids.ID `json:"asset"`
+
+	// Min is the smallest balance that passes the check.
+	Min uint64 `json:"min"`
+
+	// read is parsed from the inner *warp.Message
+	read *chain.WarpStateRead
+}
+
+func (*CheckRemoteBalance) GetTypeID() uint8 {
+	return checkRemoteBalanceID
+}
+
+func (*CheckRemoteBalance) StateKeys(codec.Address, ids.ID) []string {
+	return []string{}
+}
+
+func (*CheckRemoteBalance) StateKeysMaxChunks() []uint16 {
+	return []uint16{}
+}
+
+func (*CheckRemoteBalance) OutputsWarpMessage() bool {
+	return false
+}
+
+func (c *CheckRemoteBalance) Execute(
+	_ context.Context,
+	rules chain.Rules,
+	_ state.Mutable,
+	timestamp int64,
+	_ codec.Address,
+	_ ids.ID,
+	warpVerified bool,
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
+	if !warpVerified {
+		return false, CheckRemoteBalanceComputeUnits, OutputWarpVerificationFailed, nil, nil, nil
+	}
+	if timestamp-c.read.Timestamp > rules.GetValidityWindow() {
+		return false, CheckRemoteBalanceComputeUnits, OutputStaleRead, nil, nil, nil
+	}
+	key := storage.BalanceKey(c.Owner, c.Asset)
+	for i, k := range c.read.Keys {
+		if !bytes.Equal(k, key) {
+			continue
+		}
+		var balance uint64
+		switch v := c.read.Values[i]; len(v) {
+		case 0:
+			// The account does not hold [Asset]
+		case consts.Uint64Len:
+			balance = binary.BigEndian.Uint64(v)
+		default:
+			return false, CheckRemoteBalanceComputeUnits, OutputInvalidBalance, nil, nil, nil
+		}
+		if balance < c.Min {
+			return false, CheckRemoteBalanceComputeUnits, OutputInsufficientBalance, nil, nil, nil
+		}
+		return true, CheckRemoteBalanceComputeUnits, nil, nil, nil, nil
+	}
+	return false, CheckRemoteBalanceComputeUnits, OutputBalanceNotRead, nil, nil, nil
+}
+
+func (*CheckRemoteBalance) MaxComputeUnits(chain.Rules) uint64 {
+	return CheckRemoteBalanceComputeUnits
+}
+
+func (*CheckRemoteBalance) Size() int {
+	return codec.AddressLen + consts.IDLen + consts.Uint64Len
+}
+
+func (c *CheckRemoteBalance) Marshal(p *codec.Packer) {
+	p.PackAddress(c.Owner)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Min)
+}
+
+func UnmarshalCheckRemoteBalance(p *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var check CheckRemoteBalance
+	p.UnpackAddress(&check.Owner)
+	p.UnpackID(false, &check.Asset) // empty ID is the native asset
+	check.Min = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	read, err := chain.UnmarshalWarpStateRead(wm.Payload)
+	if err != nil {
+		return nil, err
+	}
+	check.read = read
+	return &check, nil
+}
+
+func (*CheckRemoteBalance) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -54,27 +54,27 @@ func (c *CloseOrder) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	exists, _, _, out, _, remaining, owner, err := storage.GetOrder(ctx, mu, c.Order)
 	if err != nil {
-		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, CloseOrderComputeUnits, OutputOrderMissing, nil, nil
+		return false, CloseOrderComputeUnits, OutputOrderMissing, nil, nil, nil
 	}
 	if owner != actor {
-		return false, CloseOrderComputeUnits, OutputUnauthorized, nil, nil
+		return false, CloseOrderComputeUnits, OutputUnauthorized, nil, nil, nil
 	}
 	if out != c.Out {
-		return false, CloseOrderComputeUnits, OutputWrongOut, nil, nil
+		return false, CloseOrderComputeUnits, OutputWrongOut, nil, nil, nil
 	}
 	if err := storage.DeleteOrder(ctx, mu, c.Order); err != nil {
-		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, actor, c.Out, remaining, true); err != nil {
-		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, CloseOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, CloseOrderComputeUnits, nil, nil, nil
+	return true, CloseOrderComputeUnits, nil, nil, nil, nil
 }
 
 func (*CloseOrder) MaxComputeUnits(chain.Rules) uint64 {
@@ -14,6 +14,8 @@ const (
 	fillOrderID   uint8 = 6
 	mintAssetID   uint8 = 7
 	transferID    uint8 = 8
+
+	checkRemoteBalanceID uint8 = 9
 )
 
 const (
@@ -29,6 +31,8 @@ const (
 	MintAssetComputeUnits   = 2
 	TransferComputeUnits    = 1
 
+	CheckRemoteBalanceComputeUnits = 5
+
 	MaxSymbolSize   = 8
 	MaxMemoSize     = 256
 	MaxMetadataSize = 256
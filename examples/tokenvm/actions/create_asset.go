@@ -28,9 +28,9 @@ func (*CreateAsset) GetTypeID() uint8 {
 	return createAssetID
 }
 
-func (*CreateAsset) StateKeys(_ codec.Address, txID ids.ID) []string {
+func (*CreateAsset) StateKeys(_ codec.Address, actionID ids.ID) []string {
 	return []string{
-		string(storage.AssetKey(txID)),
+		string(storage.AssetKey(actionID)),
 	}
 }
 
@@ -48,30 +48,30 @@ func (c *CreateAsset) Execute(
 	mu state.Mutable,
 	_ int64,
 	actor codec.Address,
-	txID ids.ID,
+	actionID ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if len(c.Symbol) == 0 {
-		return false, CreateAssetComputeUnits, OutputSymbolEmpty, nil, nil
+		return false, CreateAssetComputeUnits, OutputSymbolEmpty, nil, nil, nil
 	}
 	if len(c.Symbol) > MaxSymbolSize {
-		return false, CreateAssetComputeUnits, OutputSymbolTooLarge, nil, nil
+		return false, CreateAssetComputeUnits, OutputSymbolTooLarge, nil, nil, nil
 	}
 	if c.Decimals > MaxDecimals {
-		return false, CreateAssetComputeUnits, OutputDecimalsTooLarge, nil, nil
+		return false, CreateAssetComputeUnits, OutputDecimalsTooLarge, nil, nil, nil
 	}
 	if len(c.Metadata) == 0 {
-		return false, CreateAssetComputeUnits, OutputMetadataEmpty, nil, nil
+		return false, CreateAssetComputeUnits, OutputMetadataEmpty, nil, nil, nil
 	}
 	if len(c.Metadata) > MaxMetadataSize {
-		return false, CreateAssetComputeUnits, OutputMetadataTooLarge, nil, nil
+		return false, CreateAssetComputeUnits, OutputMetadataTooLarge, nil, nil, nil
 	}
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetAsset(ctx, mu, txID, c.Symbol, c.Decimals, c.Metadata, 0, actor, false); err != nil {
-		return false, CreateAssetComputeUnits, utils.ErrBytes(err), nil, nil
+	if err := storage.SetAsset(ctx, mu, actionID, c.Symbol, c.Decimals, c.Metadata, 0, actor, false); err != nil {
+		return false, CreateAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, CreateAssetComputeUnits, nil, nil, nil
+	return true, CreateAssetComputeUnits, nil, nil, nil, nil
 }
 
 func (*CreateAsset) MaxComputeUnits(chain.Rules) uint64 {
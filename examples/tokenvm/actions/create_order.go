@@ -51,10 +51,10 @@ func (*CreateOrder) GetTypeID() uint8 {
 	return createOrderID
 }
 
-func (c *CreateOrder) StateKeys(actor codec.Address, txID ids.ID) []string {
+func (c *CreateOrder) StateKeys(actor codec.Address, actionID ids.ID) []string {
 	return []string{
 		string(storage.BalanceKey(actor, c.Out)),
-		string(storage.OrderKey(txID)),
+		string(storage.OrderKey(actionID)),
 	}
 }
 
@@ -72,31 +72,31 @@ func (c *CreateOrder) Execute(
 	mu state.Mutable,
 	_ int64,
 	actor codec.Address,
-	txID ids.ID,
+	actionID ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if c.In == c.Out {
-		return false, CreateOrderComputeUnits, OutputSameInOut, nil, nil
+		return false, CreateOrderComputeUnits, OutputSameInOut, nil, nil, nil
 	}
 	if c.InTick == 0 {
-		return false, CreateOrderComputeUnits, OutputInTickZero, nil, nil
+		return false, CreateOrderComputeUnits, OutputInTickZero, nil, nil, nil
 	}
 	if c.OutTick == 0 {
-		return false, CreateOrderComputeUnits, OutputOutTickZero, nil, nil
+		return false, CreateOrderComputeUnits, OutputOutTickZero, nil, nil, nil
 	}
 	if c.Supply == 0 {
-		return false, CreateOrderComputeUnits, OutputSupplyZero, nil, nil
+		return false, CreateOrderComputeUnits, OutputSupplyZero, nil, nil, nil
 	}
 	if c.Supply%c.OutTick != 0 {
-		return false, CreateOrderComputeUnits, OutputSupplyMisaligned, nil, nil
+		return false, CreateOrderComputeUnits, OutputSupplyMisaligned, nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, c.Out, c.Supply); err != nil {
-		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	if err := storage.SetOrder(ctx, mu, txID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor); err != nil {
-		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil
+	if err := storage.SetOrder(ctx, mu, actionID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor); err != nil {
+		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, CreateOrderComputeUnits, nil, nil, nil
+	return true, CreateOrderComputeUnits, nil, nil, nil, nil
 }
 
 func (*CreateOrder) MaxComputeUnits(chain.Rules) uint64 {
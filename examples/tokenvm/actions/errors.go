@@ -5,4 +5,8 @@ package actions
 
 import "errors"
 
-var ErrNoSwapToFill = errors.New("no swap to fill")
+var (
+	ErrNoSwapToFill    = errors.New("no swap to fill")
+	ErrUnexpectedEvent = errors.New("unexpected event")
+	ErrWarpStateRead   = errors.New("warp state read can't be imported")
+)
@@ -67,52 +67,52 @@ func (e *ExportAsset) executeReturn(
 	mu state.Mutable,
 	actor codec.Address,
 	txID ids.ID,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, metadata, supply, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, ExportAssetComputeUnits, OutputAssetMissing, nil, nil
+		return false, ExportAssetComputeUnits, OutputAssetMissing, nil, nil, nil
 	}
 	if !isWarp {
-		return false, ExportAssetComputeUnits, OutputNotWarpAsset, nil, nil
+		return false, ExportAssetComputeUnits, OutputNotWarpAsset, nil, nil, nil
 	}
 	allowedDestination, err := ids.ToID(metadata[consts.IDLen:])
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if allowedDestination != e.Destination {
-		return false, ExportAssetComputeUnits, OutputWrongDestination, nil, nil
+		return false, ExportAssetComputeUnits, OutputWrongDestination, nil, nil, nil
 	}
 	newSupply, err := smath.Sub(supply, e.Value)
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	newSupply, err = smath.Sub(newSupply, e.Reward)
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if newSupply > 0 {
 		if err := storage.SetAsset(ctx, mu, e.Asset, symbol, decimals, metadata, newSupply, codec.EmptyAddress, true); err != nil {
-			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	} else {
 		if err := storage.DeleteAsset(ctx, mu, e.Asset); err != nil {
-			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	}
 	if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Value); err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if e.Reward > 0 {
 		if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Reward); err != nil {
-			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	}
 	originalAsset, err := ids.ToID(metadata[:consts.IDLen])
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	wt := &WarpTransfer{
 		To:                 e.To,
@@ -131,13 +131,13 @@ func (e *ExportAsset) executeReturn(
 	}
 	payload, err := wt.Marshal()
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	wm := &warp.UnsignedMessage{
 		// NetworkID + SourceChainID is populated by hypersdk
 		Payload: payload,
 	}
-	return true, ExportAssetComputeUnits, nil, wm, nil
+	return true, ExportAssetComputeUnits, nil, nil, wm, nil
 }
 
 func (e *ExportAsset) executeLoan(
@@ -145,30 +145,30 @@ func (e *ExportAsset) executeLoan(
 	mu state.Mutable,
 	actor codec.Address,
 	txID ids.ID,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, _, _, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, ExportAssetComputeUnits, OutputAssetMissing, nil, nil
+		return false, ExportAssetComputeUnits, OutputAssetMissing, nil, nil, nil
 	}
 	if isWarp {
 		// Cannot export an asset if it was warped in and not returning
-		return false, ExportAssetComputeUnits, OutputWarpAsset, nil, nil
+		return false, ExportAssetComputeUnits, OutputWarpAsset, nil, nil, nil
 	}
 	if err := storage.AddLoan(ctx, mu, e.Asset, e.Destination, e.Value); err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Value); err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if e.Reward > 0 {
 		if err := storage.AddLoan(ctx, mu, e.Asset, e.Destination, e.Reward); err != nil {
-			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 		if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Reward); err != nil {
-			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	}
 	wt := &WarpTransfer{
@@ -188,13 +188,13 @@ func (e *ExportAsset) executeLoan(
 	}
 	payload, err := wt.Marshal()
 	if err != nil {
-		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	wm := &warp.UnsignedMessage{
 		// NetworkID + SourceChainID is populated by hypersdk
 		Payload: payload,
 	}
-	return true, ExportAssetComputeUnits, nil, wm, nil
+	return true, ExportAssetComputeUnits, nil, nil, wm, nil
 }
 
 func (e *ExportAsset) Execute(
@@ -205,14 +205,14 @@ func (e *ExportAsset) Execute(
 	actor codec.Address,
 	txID ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if e.Value == 0 {
-		return false, ExportAssetComputeUnits, OutputValueZero, nil, nil
+		return false, ExportAssetComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	if e.Destination == ids.Empty {
 		// This would result in multiplying balance export by whoever imports the
 		// transaction.
-		return false, ExportAssetComputeUnits, OutputAnycast, nil, nil
+		return false, ExportAssetComputeUnits, OutputAnycast, nil, nil, nil
 	}
 	// TODO: check if destination is ourselves
 	if e.Return {
@@ -18,7 +18,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*FillOrder)(nil)
+var (
+	_ chain.Action       = (*FillOrder)(nil)
+	_ chain.Participants = (*FillOrder)(nil)
+)
 
 type FillOrder struct {
 	// [Order] is the OrderID you wish to close.
@@ -44,6 +47,10 @@ func (*FillOrder) GetTypeID() uint8 {
 	return fillOrderID
 }
 
+func (f *FillOrder) Participants() []codec.Address {
+	return []codec.Address{f.Owner}
+}
+
 func (f *FillOrder) StateKeys(actor codec.Address, _ ids.ID) []string {
 	return []string{
 		string(storage.OrderKey(f.Order)),
@@ -69,39 +76,39 @@ func (f *FillOrder) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	exists, in, inTick, out, outTick, remaining, owner, err := storage.GetOrder(ctx, mu, f.Order)
 	if err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, NoFillOrderComputeUnits, OutputOrderMissing, nil, nil
+		return false, NoFillOrderComputeUnits, OutputOrderMissing, nil, nil, nil
 	}
 	if owner != f.Owner {
-		return false, NoFillOrderComputeUnits, OutputWrongOwner, nil, nil
+		return false, NoFillOrderComputeUnits, OutputWrongOwner, nil, nil, nil
 	}
 	if in != f.In {
-		return false, NoFillOrderComputeUnits, OutputWrongIn, nil, nil
+		return false, NoFillOrderComputeUnits, OutputWrongIn, nil, nil, nil
 	}
 	if out != f.Out {
-		return false, NoFillOrderComputeUnits, OutputWrongOut, nil, nil
+		return false, NoFillOrderComputeUnits, OutputWrongOut, nil, nil, nil
 	}
 	if f.Value == 0 {
 		// This should be guarded via [Unmarshal] but we check anyways.
-		return false, NoFillOrderComputeUnits, OutputValueZero, nil, nil
+		return false, NoFillOrderComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	if f.Value%inTick != 0 {
-		return false, NoFillOrderComputeUnits, OutputValueMisaligned, nil, nil
+		return false, NoFillOrderComputeUnits, OutputValueMisaligned, nil, nil, nil
 	}
 	// Determine amount of [Out] counterparty will receive if the trade is
 	// successful.
 	outputAmount, err := smath.Mul64(outTick, f.Value/inTick)
 	if err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if outputAmount == 0 {
 		// This should never happen because [f.Value] > 0
-		return false, NoFillOrderComputeUnits, OutputInsufficientOutput, nil, nil
+		return false, NoFillOrderComputeUnits, OutputInsufficientOutput, nil, nil, nil
 	}
 	var (
 		inputAmount    = f.Value
@@ -127,32 +134,32 @@ func (f *FillOrder) Execute(
 	}
 	if inputAmount == 0 {
 		// Don't allow free trades (can happen due to refund rounding)
-		return false, NoFillOrderComputeUnits, OutputInsufficientInput, nil, nil
+		return false, NoFillOrderComputeUnits, OutputInsufficientInput, nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, f.In, inputAmount); err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, f.Owner, f.In, inputAmount, true); err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, actor, f.Out, outputAmount, true); err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if shouldDelete {
 		if err := storage.DeleteOrder(ctx, mu, f.Order); err != nil {
-			return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	} else {
 		if err := storage.SetOrder(ctx, mu, f.Order, in, inTick, out, outTick, orderRemaining, owner); err != nil {
-			return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+			return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 		}
 	}
 	or := &OrderResult{In: inputAmount, Out: outputAmount, Remaining: orderRemaining}
 	output, err := or.Marshal()
 	if err != nil {
-		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, FillOrderComputeUnits, output, nil, nil
+	return true, FillOrderComputeUnits, output, nil, nil, nil
 }
 
 func (*FillOrder) MaxComputeUnits(chain.Rules) uint64 {
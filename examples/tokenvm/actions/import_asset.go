@@ -200,15 +200,15 @@ func (i *ImportAsset) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	warpVerified bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if !warpVerified {
-		return false, ImportAssetComputeUnits, OutputWarpVerificationFailed, nil, nil
+		return false, ImportAssetComputeUnits, OutputWarpVerificationFailed, nil, nil, nil
 	}
 	if i.warpTransfer.DestinationChainID != r.ChainID() {
-		return false, ImportAssetComputeUnits, OutputInvalidDestination, nil, nil
+		return false, ImportAssetComputeUnits, OutputInvalidDestination, nil, nil, nil
 	}
 	if i.warpTransfer.Value == 0 {
-		return false, ImportAssetComputeUnits, OutputValueZero, nil, nil
+		return false, ImportAssetComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	var output []byte
 	if i.warpTransfer.Return {
@@ -217,17 +217,17 @@ func (i *ImportAsset) Execute(
 		output = i.executeMint(ctx, mu, actor)
 	}
 	if len(output) > 0 {
-		return false, ImportAssetComputeUnits, output, nil, nil
+		return false, ImportAssetComputeUnits, output, nil, nil, nil
 	}
 	if i.warpTransfer.SwapIn == 0 {
 		// We are ensured that [i.Fill] is false here because of logic in unmarshal
-		return true, ImportAssetComputeUnits, nil, nil, nil
+		return true, ImportAssetComputeUnits, nil, nil, nil, nil
 	}
 	if !i.Fill {
 		if i.warpTransfer.SwapExpiry > t {
-			return false, ImportAssetComputeUnits, OutputMustFill, nil, nil
+			return false, ImportAssetComputeUnits, OutputMustFill, nil, nil, nil
 		}
-		return true, ImportAssetComputeUnits, nil, nil, nil
+		return true, ImportAssetComputeUnits, nil, nil, nil, nil
 	}
 	// TODO: charge more if swap is performed
 	var assetIn ids.ID
@@ -237,18 +237,18 @@ func (i *ImportAsset) Execute(
 		assetIn = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
 	}
 	if err := storage.SubBalance(ctx, mu, i.warpTransfer.To, assetIn, i.warpTransfer.SwapIn); err != nil {
-		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, actor, assetIn, i.warpTransfer.SwapIn, true); err != nil {
-		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
-		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, i.warpTransfer.To, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut, true); err != nil {
-		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, ImportAssetComputeUnits, nil, nil, nil
+	return true, ImportAssetComputeUnits, nil, nil, nil, nil
 }
 
 func (*ImportAsset) MaxComputeUnits(chain.Rules) uint64 {
@@ -275,6 +275,9 @@ func UnmarshalImportAsset(p *codec.Packer, wm *warp.Message) (chain.Action, erro
 		return nil, err
 	}
 	imp.warpMessage = wm
+	if chain.IsWarpStateRead(wm.Payload) {
+		return nil, ErrWarpStateRead
+	}
 	imp.warpTransfer, err = UnmarshalWarpTransfer(imp.warpMessage.Payload)
 	if err != nil {
 		return nil, err
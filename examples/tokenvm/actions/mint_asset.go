@@ -35,6 +35,10 @@ func (*MintAsset) GetTypeID() uint8 {
 	return mintAssetID
 }
 
+func (m *MintAsset) Participants() []codec.Address {
+	return []codec.Address{m.To}
+}
+
 func (m *MintAsset) StateKeys(codec.Address, ids.ID) []string {
 	return []string{
 		string(storage.AssetKey(m.Asset)),
@@ -58,37 +62,37 @@ func (m *MintAsset) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if m.Asset == ids.Empty {
-		return false, MintAssetComputeUnits, OutputAssetIsNative, nil, nil
+		return false, MintAssetComputeUnits, OutputAssetIsNative, nil, nil, nil
 	}
 	if m.Value == 0 {
-		return false, MintAssetComputeUnits, OutputValueZero, nil, nil
+		return false, MintAssetComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	exists, symbol, decimals, metadata, supply, owner, isWarp, err := storage.GetAsset(ctx, mu, m.Asset)
 	if err != nil {
-		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if !exists {
-		return false, MintAssetComputeUnits, OutputAssetMissing, nil, nil
+		return false, MintAssetComputeUnits, OutputAssetMissing, nil, nil, nil
 	}
 	if isWarp {
-		return false, MintAssetComputeUnits, OutputWarpAsset, nil, nil
+		return false, MintAssetComputeUnits, OutputWarpAsset, nil, nil, nil
 	}
 	if owner != actor {
-		return false, MintAssetComputeUnits, OutputWrongOwner, nil, nil
+		return false, MintAssetComputeUnits, OutputWrongOwner, nil, nil, nil
 	}
 	newSupply, err := smath.Add64(supply, m.Value)
 	if err != nil {
-		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.SetAsset(ctx, mu, m.Asset, symbol, decimals, metadata, newSupply, actor, isWarp); err != nil {
-		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, m.To, m.Asset, m.Value, true); err != nil {
-		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, MintAssetComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, MintAssetComputeUnits, nil, nil, nil
+	return true, MintAssetComputeUnits, nil, nil, nil, nil
 }
 
 func (*MintAsset) MaxComputeUnits(chain.Rules) uint64 {
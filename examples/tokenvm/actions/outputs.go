@@ -37,4 +37,8 @@ var (
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
 	OutputInvalidDestination     = []byte("invalid destination")
+	OutputBalanceNotRead         = []byte("balance not read")
+	OutputStaleRead              = []byte("state read is stale")
+	OutputInvalidBalance         = []byte("invalid balance")
+	OutputInsufficientBalance    = []byte("insufficient balance")
 )
@@ -16,7 +16,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action       = (*Transfer)(nil)
+	_ chain.Participants = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -36,6 +39,10 @@ func (*Transfer) GetTypeID() uint8 {
 	return transferID
 }
 
+func (t *Transfer) Participants() []codec.Address {
+	return []codec.Address{t.To}
+}
+
 func (t *Transfer) StateKeys(actor codec.Address, _ ids.ID) []string {
 	return []string{
 		string(storage.BalanceKey(actor, t.Asset)),
@@ -59,21 +66,21 @@ func (t *Transfer) Execute(
 	actor codec.Address,
 	_ ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if t.Value == 0 {
-		return false, TransferComputeUnits, OutputValueZero, nil, nil
+		return false, TransferComputeUnits, OutputValueZero, nil, nil, nil
 	}
 	if len(t.Memo) > MaxMemoSize {
-		return false, CreateAssetComputeUnits, OutputMemoTooLarge, nil, nil
+		return false, CreateAssetComputeUnits, OutputMemoTooLarge, nil, nil, nil
 	}
 	if err := storage.SubBalance(ctx, mu, actor, t.Asset, t.Value); err != nil {
-		return false, TransferComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, TransferComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
 	// TODO: allow sender to configure whether they will pay to create
 	if err := storage.AddBalance(ctx, mu, t.To, t.Asset, t.Value, true); err != nil {
-		return false, TransferComputeUnits, utils.ErrBytes(err), nil, nil
+		return false, TransferComputeUnits, utils.ErrBytes(err), nil, nil, nil
 	}
-	return true, TransferComputeUnits, nil, nil, nil
+	return true, TransferComputeUnits, nil, []*chain.Event{transferEvent(actor, t.To, t.Asset, t.Value)}, nil, nil
 }
 
 func (*Transfer) MaxComputeUnits(chain.Rules) uint64 {
@@ -3,15 +3,23 @@
 
 package auth
 
-import "github.com/ava-labs/hypersdk/vm"
+import (
+	hauth "github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/vm"
+)
 
 // Note: Registry will error during initialization if a duplicate ID is assigned. We explicitly assign IDs to avoid accidental remapping.
 const (
-	ed25519ID uint8 = 0
+	ed25519ID        uint8 = 0
+	sponsorWrapperID uint8 = 1
 )
 
 func Engines() map[uint8]vm.AuthEngine {
+	ed25519Engine := &ED25519AuthEngine{}
 	return map[uint8]vm.AuthEngine{
-		ed25519ID: &ED25519AuthEngine{},
+		ed25519ID: ed25519Engine,
+		sponsorWrapperID: hauth.NewSponsorWrapperEngine(map[uint8]vm.AuthEngine{
+			ed25519ID: ed25519Engine,
+		}),
 	}
 }
@@ -0,0 +1,32 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+
+	hauth "github.com/ava-labs/hypersdk/auth"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+// NewSponsorWrapper wraps [actor] and [sponsor] so that [sponsor] pays the fees
+// of a transaction authorized by [actor].
+func NewSponsorWrapper(actor chain.Auth, sponsor chain.Auth) *hauth.SponsorWrapper {
+	return hauth.NewSponsorWrapper(sponsorWrapperID, actor, sponsor)
+}
+
+func UnmarshalSponsorWrapper(p *codec.Packer, wm *warp.Message) (chain.Auth, error) {
+	return hauth.NewSponsorWrapperParser(sponsorWrapperID, consts.AuthRegistry)(p, wm)
+}
+
+func NewSponsorWrapperFactory(
+	actor chain.AuthFactory,
+	sponsor chain.AuthFactory,
+	sponsorAddr codec.Address,
+) *hauth.SponsorWrapperFactory {
+	return hauth.NewSponsorWrapperFactory(sponsorWrapperID, actor, sponsor, sponsorAddr)
+}
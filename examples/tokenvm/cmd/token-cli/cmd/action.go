@@ -77,11 +77,11 @@ var fundFaucetCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if _, _, err = sendAndWait(ctx, nil, &actions.Transfer{
+		if _, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.Transfer{
 			To:    addr,
 			Asset: ids.Empty,
 			Value: amount,
-		}, cli, scli, tcli, factory, true); err != nil {
+		}}, cli, scli, tcli, factory, true); err != nil {
 			return err
 		}
 		hutils.Outf("{{green}}funded faucet:{{/}} %s\n", faucetAddress)
@@ -127,11 +127,11 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.Transfer{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.Transfer{
 			To:    recipient,
 			Asset: assetID,
 			Value: amount,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -170,11 +170,11 @@ var createAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.CreateAsset{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.CreateAsset{
 			Symbol:   []byte(symbol),
 			Decimals: uint8(decimals), // already constrain above to prevent overflow
 			Metadata: []byte(metadata),
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -239,11 +239,11 @@ var mintAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.MintAsset{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.MintAsset{
 			Asset: assetID,
 			To:    recipient,
 			Value: amount,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -276,10 +276,10 @@ var closeOrderCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.CloseOrder{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.CloseOrder{
 			Order: orderID,
 			Out:   outAssetID,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -363,13 +363,13 @@ var createOrderCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		_, _, err = sendAndWait(ctx, nil, &actions.CreateOrder{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.CreateOrder{
 			In:      inAssetID,
 			InTick:  inTick,
 			Out:     outAssetID,
 			OutTick: outTick,
 			Supply:  supply,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -480,13 +480,13 @@ var fillOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		_, _, err = sendAndWait(ctx, nil, &actions.FillOrder{
+		_, _, err = sendAndWait(ctx, nil, []chain.Action{&actions.FillOrder{
 			Order: order.ID,
 			Owner: owner,
 			In:    inAssetID,
 			Out:   outAssetID,
 			Value: value,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		return err
 	},
 }
@@ -595,9 +595,9 @@ func performImport(
 	}
 
 	// Generate transaction
-	_, _, err = sendAndWait(ctx, msg, &actions.ImportAsset{
+	_, _, err = sendAndWait(ctx, msg, []chain.Action{&actions.ImportAsset{
 		Fill: fill,
-	}, dcli, dscli, dtcli, factory, true)
+	}}, dcli, dscli, dtcli, factory, true)
 	return err
 }
 
@@ -729,7 +729,7 @@ var exportAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		success, txID, err := sendAndWait(ctx, nil, &actions.ExportAsset{
+		success, txID, err := sendAndWait(ctx, nil, []chain.Action{&actions.ExportAsset{
 			To:          recipient,
 			Asset:       assetID,
 			Value:       amount,
@@ -740,7 +740,7 @@ var exportAssetCmd = &cobra.Command{
 			SwapOut:     swapOut,
 			SwapExpiry:  swapExpiry,
 			Destination: destination,
-		}, cli, scli, tcli, factory, true)
+		}}, cli, scli, tcli, factory, true)
 		if err != nil {
 			return err
 		}
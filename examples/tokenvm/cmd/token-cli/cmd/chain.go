@@ -6,11 +6,14 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 )
 
@@ -78,3 +81,37 @@ var watchChainCmd = &cobra.Command{
 		})
 	},
 }
+
+var replayChainCmd = &cobra.Command{
+	Use: "replay [chain data dir] [genesis path] [start] [end]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 4 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		genesisBytes, err := os.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		start, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		end, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return err
+		}
+		var upgradeBytes []byte
+		if len(upgradeFile) > 0 {
+			upgradeBytes, err = os.ReadFile(upgradeFile)
+			if err != nil {
+				return err
+			}
+		}
+		// Archive mode must stay enabled, otherwise the node's state history
+		// would be discarded
+		return handler.Root().Replay(controller.New(), args[0], genesisBytes, upgradeBytes, []byte(`{"archive":true}`), start, end)
+	},
+}
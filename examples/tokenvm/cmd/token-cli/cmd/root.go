@@ -38,6 +38,8 @@ var (
 	startPrometheus       bool
 	maxFee                int64
 	numCores              int
+	snapshotHeight        int64
+	upgradeFile           string
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -55,6 +57,7 @@ func init() {
 		actionCmd,
 		spamCmd,
 		prometheusCmd,
+		snapshotCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -140,6 +143,12 @@ func init() {
 		false,
 		"hide txs",
 	)
+	replayChainCmd.PersistentFlags().StringVar(
+		&upgradeFile,
+		"upgrade-file",
+		"",
+		"upgrade file of the chain (required to replay blocks after an upgrade)",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
@@ -147,6 +156,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		replayChainCmd,
 	)
 
 	// actions
@@ -224,6 +234,18 @@ func init() {
 	prometheusCmd.AddCommand(
 		generatePrometheusCmd,
 	)
+
+	// snapshot
+	exportSnapshotCmd.PersistentFlags().Int64Var(
+		&snapshotHeight,
+		"height",
+		-1,
+		"height to export (defaults to last accepted)",
+	)
+	snapshotCmd.AddCommand(
+		exportSnapshotCmd,
+		importSnapshotCmd,
+	)
 }
 
 func Execute() error {
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
@@ -122,11 +123,11 @@ func (m *Manager) sendFunds(ctx context.Context, destination codec.Address, amou
 	if err != nil {
 		return ids.Empty, 0, err
 	}
-	submit, tx, maxFee, err := m.cli.GenerateTransaction(ctx, parser, nil, &actions.Transfer{
+	submit, tx, maxFee, err := m.cli.GenerateTransaction(ctx, parser, nil, []chain.Action{&actions.Transfer{
 		To:    destination,
 		Asset: ids.Empty,
 		Value: amount,
-	}, m.factory)
+	}}, m.factory)
 	if err != nil {
 		return ids.Empty, 0, err
 	}
@@ -0,0 +1,49 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+type Config struct {
+	// SourceRPC is the URI of the chain that exports assets and DestinationRPC
+	// is the URI of the chain that assets are imported to.
+	SourceRPC      string `json:"sourceRPC"`
+	DestinationRPC string `json:"destinationRPC"`
+
+	// PrivateKeyBytes is the key of the account that pays fees for (and
+	// collects rewards from) imports on the destination.
+	PrivateKeyBytes []byte `json:"privateKeyBytes"`
+
+	// CheckpointPath is the file where relaying progress is stored.
+	CheckpointPath string `json:"checkpointPath"`
+
+	// StartHeight is the first source block to relay exports from if there
+	// is no checkpoint (0 starts from the next block accepted).
+	StartHeight uint64 `json:"startHeight"`
+
+	// QuorumNum and QuorumDen are the fraction of source stake weight that
+	// must sign an export before it is imported. This should be at least the
+	// quorum the destination requires from the source (imports that are not
+	// verified by the destination are retried with the signatures gathered by
+	// then).
+	QuorumNum uint64 `json:"quorumNum"`
+	QuorumDen uint64 `json:"quorumDen"`
+}
+
+func (c *Config) PrivateKey() ed25519.PrivateKey {
+	return ed25519.PrivateKey(c.PrivateKeyBytes)
+}
+
+func (c *Config) Address() codec.Address {
+	return auth.NewED25519Address(c.PrivateKey().PublicKey())
+}
+
+func (c *Config) AddressBech32() string {
+	return codec.MustAddressBech32(consts.HRP, c.Address())
+}
@@ -0,0 +1,412 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/config"
+	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"go.uber.org/zap"
+)
+
+const retryInterval = 1 * time.Second
+
+var ErrInvalidQuorum = errors.New("invalid quorum")
+
+// Checkpoint is the progress of a [Relayer], which is persisted to disk after
+// each export is relayed.
+type Checkpoint struct {
+	// Height is the last source block that all exports were relayed from.
+	Height uint64 `json:"height"`
+	// Relayed are the exports (by txID) from the block after [Height] that
+	// have already been relayed.
+	Relayed []ids.ID `json:"relayed"`
+}
+
+// Relayer imports all assets exported from a source chain to a destination
+// chain (paying fees and collecting rewards with a single account).
+type Relayer struct {
+	log    logging.Logger
+	config *config.Config
+
+	scli  *rpc.JSONRPCClient
+	stcli *trpc.JSONRPCClient
+	dcli  *rpc.JSONRPCClient
+	dtcli *trpc.JSONRPCClient
+
+	sourceChainID      ids.ID
+	destinationChainID ids.ID
+	factory            *auth.ED25519Factory
+
+	// next is the next source block to relay exports from (0 if unknown)
+	next    uint64
+	relayed []ids.ID
+}
+
+func New(logger logging.Logger, c *config.Config) (*Relayer, error) {
+	if c.QuorumNum == 0 || c.QuorumDen == 0 || c.QuorumNum > c.QuorumDen {
+		return nil, ErrInvalidQuorum
+	}
+	ctx := context.TODO()
+	scli := rpc.NewJSONRPCClient(c.SourceRPC)
+	networkID, _, sourceChainID, err := scli.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dcli := rpc.NewJSONRPCClient(c.DestinationRPC)
+	dnetworkID, _, destinationChainID, err := dcli.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &Relayer{
+		log:                logger,
+		config:             c,
+		scli:               scli,
+		stcli:              trpc.NewJSONRPCClient(c.SourceRPC, networkID, sourceChainID),
+		dcli:               dcli,
+		dtcli:              trpc.NewJSONRPCClient(c.DestinationRPC, dnetworkID, destinationChainID),
+		sourceChainID:      sourceChainID,
+		destinationChainID: destinationChainID,
+		factory:            auth.NewED25519Factory(c.PrivateKey()),
+		next:               c.StartHeight,
+	}
+
+	// Resume from checkpoint (if it exists)
+	b, err := os.ReadFile(c.CheckpointPath)
+	switch {
+	case err == nil:
+		var checkpoint Checkpoint
+		if err := json.Unmarshal(b, &checkpoint); err != nil {
+			return nil, err
+		}
+		r.next = checkpoint.Height + 1
+		r.relayed = checkpoint.Relayed
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, err
+	}
+	r.log.Info("relayer initialized",
+		zap.Stringer("source", sourceChainID),
+		zap.Stringer("destination", destinationChainID),
+		zap.String("address", c.AddressBech32()),
+		zap.Uint64("next height", r.next),
+	)
+	return r, nil
+}
+
+// Run relays exports until [ctx] is cancelled.
+func (r *Relayer) Run(ctx context.Context) error {
+	parser, err := r.stcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	for ctx.Err() == nil { // handle WS client failure
+		if err := r.listen(ctx, parser); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			r.log.Warn("unable to relay exports", zap.Error(err))
+		}
+		if err := sleep(ctx, retryInterval); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (r *Relayer) listen(ctx context.Context, parser chain.Parser) error {
+	scli, err := rpc.NewWebSocketClient(r.config.SourceRPC, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	if err != nil {
+		return err
+	}
+	defer scli.Close()
+	if err := scli.RegisterBlocks(); err != nil {
+		return err
+	}
+
+	// Relay any blocks accepted since our last checkpoint
+	if r.next > 0 {
+		_, height, _, err := r.scli.Accepted(ctx)
+		if err != nil {
+			return err
+		}
+		if err := r.backfill(ctx, height+1); err != nil {
+			return err
+		}
+	}
+
+	for ctx.Err() == nil {
+		blk, results, _, err := scli.ListenBlock(ctx, parser)
+		if err != nil {
+			return err
+		}
+		if r.next == 0 {
+			r.next = blk.Hght
+		}
+		if blk.Hght < r.next {
+			// Already relayed
+			continue
+		}
+		if err := r.backfill(ctx, blk.Hght); err != nil {
+			return err
+		}
+		txIDs := make([]ids.ID, len(blk.Txs))
+		for i, tx := range blk.Txs {
+			txIDs[i] = tx.ID()
+		}
+		if err := r.relayBlock(ctx, blk.Hght, txIDs, results); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// backfill relays all blocks from [r.next] to [end] (exclusive) that we
+// didn't receive over the block stream.
+func (r *Relayer) backfill(ctx context.Context, end uint64) error {
+	for r.next < end {
+		blk, err := r.scli.GetBlockByHeight(ctx, r.next)
+		if err != nil {
+			return err
+		}
+		if blk.Results == nil {
+			// The node accepted this block during state sync
+			r.log.Warn("skipping block without results", zap.Uint64("height", r.next))
+			if err := r.checkpoint(r.next, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		txIDs := make([]ids.ID, len(blk.Txs))
+		for i, tx := range blk.Txs {
+			txIDs[i] = tx.ID
+		}
+		if err := r.relayBlock(ctx, r.next, txIDs, blk.Results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relayBlock relays all exports to the destination from the source block at
+// [height].
+func (r *Relayer) relayBlock(ctx context.Context, height uint64, txIDs []ids.ID, results []*chain.Result) error {
+	for i, result := range results {
+		if result.WarpMessage == nil || result.WarpMessage.SourceChainID != r.sourceChainID {
+			continue
+		}
+		wt, err := actions.UnmarshalWarpTransfer(result.WarpMessage.Payload)
+		if err != nil || wt.DestinationChainID != r.destinationChainID {
+			continue
+		}
+		txID := txIDs[i]
+		if contains(r.relayed, txID) {
+			continue
+		}
+		if err := r.relay(ctx, txID, wt); err != nil {
+			return err
+		}
+		r.relayed = append(r.relayed, txID)
+		if err := r.checkpoint(height-1, r.relayed); err != nil {
+			return err
+		}
+	}
+	return r.checkpoint(height, nil)
+}
+
+// relay imports the asset exported by [txID] once enough of the source stake
+// weight has signed it.
+func (r *Relayer) relay(ctx context.Context, txID ids.ID, wt *actions.WarpTransfer) error {
+	msg, err := r.aggregate(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	// We never fill swaps, so we must wait for any swap to expire
+	// before importing
+	if wt.SwapIn > 0 {
+		if wait := time.Until(time.UnixMilli(wt.SwapExpiry)); wait > 0 {
+			r.log.Info("waiting for swap to expire", zap.Stringer("txID", txID), zap.Duration("wait", wait))
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+	}
+
+	for ctx.Err() == nil {
+		importTxID, result, err := r.submitImport(ctx, msg)
+		switch {
+		case err != nil:
+			r.log.Warn("unable to import", zap.Stringer("txID", txID), zap.Error(err))
+		case result.Success:
+			r.log.Info("relayed export",
+				zap.Stringer("txID", txID),
+				zap.Stringer("importTxID", importTxID),
+				zap.Uint64("value", wt.Value),
+				zap.Uint64("reward", wt.Reward),
+			)
+			return nil
+		case skipWarpError(result.WarpError):
+			// The import will never succeed if the message was already imported
+			// or the destination doesn't accept messages from the source, so we
+			// don't retry.
+			r.log.Warn("skipping export",
+				zap.Stringer("txID", txID),
+				zap.Stringer("importTxID", importTxID),
+				zap.String("warpError", string(result.WarpError)),
+			)
+			return nil
+		default:
+			// The destination may require more signature weight than we
+			// gathered (or the import may have failed for another reason), so
+			// we aggregate signatures again and retry.
+			r.log.Warn("import failed",
+				zap.Stringer("txID", txID),
+				zap.Stringer("importTxID", importTxID),
+				zap.String("error", string(result.Error)),
+				zap.String("warpError", string(result.WarpError)),
+			)
+			if err := sleep(ctx, retryInterval); err != nil {
+				return err
+			}
+			msg, err = r.aggregate(ctx, txID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := sleep(ctx, retryInterval); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// skipWarpError returns true if an import that failed with [warpError] can
+// never succeed.
+func skipWarpError(warpError []byte) bool {
+	switch string(warpError) {
+	case chain.ErrDuplicateWarpMessage.Error(), chain.ErrDisabledChainID.Error():
+		return true
+	default:
+		return false
+	}
+}
+
+// aggregate waits until [r.config.QuorumNum]/[r.config.QuorumDen] of the
+// source stake weight has signed the message emitted by [txID].
+func (r *Relayer) aggregate(ctx context.Context, txID ids.ID) (*warp.Message, error) {
+	for ctx.Err() == nil {
+		msg, weight, signatureWeight, err := r.scli.GenerateAggregateWarpSignature(ctx, txID)
+		switch {
+		case err != nil:
+			r.log.Warn("unable to aggregate signatures", zap.Stringer("txID", txID), zap.Error(err))
+		case warp.VerifyWeight(signatureWeight, weight, r.config.QuorumNum, r.config.QuorumDen) == nil:
+			return msg, nil
+		default:
+			r.log.Info("waiting for signature weight",
+				zap.Stringer("txID", txID),
+				zap.Uint64("weight", weight),
+				zap.Uint64("signature weight", signatureWeight),
+			)
+		}
+		if err := sleep(ctx, retryInterval); err != nil {
+			return nil, err
+		}
+	}
+	return nil, ctx.Err()
+}
+
+// submitImport imports [msg] on the destination and returns the result of the
+// import once it is accepted.
+func (r *Relayer) submitImport(ctx context.Context, msg *warp.Message) (ids.ID, *chain.Result, error) {
+	parser, err := r.dtcli.Parser(ctx)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	_, tx, _, err := r.dcli.GenerateTransaction(
+		ctx,
+		parser,
+		msg,
+		[]chain.Action{&actions.ImportAsset{}},
+		r.factory,
+	)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+
+	// Submit over the websocket to get the [chain.Result] (which includes the
+	// reason the warp message was not verified)
+	dcli, err := rpc.NewWebSocketClient(r.config.DestinationRPC, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	defer dcli.Close()
+	if err := dcli.RegisterTx(tx); err != nil {
+		return ids.Empty, nil, err
+	}
+	_, dErr, result, err := dcli.ListenTx(ctx)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	if dErr != nil {
+		return ids.Empty, nil, dErr
+	}
+	return tx.ID(), result, nil
+}
+
+// checkpoint persists that all exports up to [height] (and [relayed] from the
+// block after [height]) have been relayed.
+func (r *Relayer) checkpoint(height uint64, relayed []ids.ID) error {
+	b, err := json.Marshal(&Checkpoint{Height: height, Relayed: relayed})
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so that the checkpoint is never
+	// corrupted by a crash
+	tmp := r.config.CheckpointPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, r.config.CheckpointPath); err != nil {
+		return err
+	}
+	r.next = height + 1
+	r.relayed = relayed
+	return nil
+}
+
+// sleep waits for [d] or until [ctx] is cancelled (returning its error).
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func contains(txIDs []ids.ID, txID ids.ID) bool {
+	for _, id := range txIDs {
+		if id == txID {
+			return true
+		}
+	}
+	return false
+}
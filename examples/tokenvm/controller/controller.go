@@ -121,6 +121,18 @@ func (c *Controller) Initialize(
 	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
 
 	// Create builder and gossiper
+	gcfg := gossiper.DefaultProposerConfig()
+	gcfg.GossipMaxSize = c.config.GossipMaxSize
+	gcfg.GossipProposerDiff = c.config.GossipProposerDiff
+	gcfg.GossipProposerDepth = c.config.GossipProposerDepth
+	gcfg.NoGossipBuilderDiff = c.config.NoGossipBuilderDiff
+	gcfg.VerifyTimeout = c.config.VerifyTimeout
+	gcfg.GossipPeerRate = c.config.GossipPeerRate
+	gcfg.GossipPeerBurst = c.config.GossipPeerBurst
+	gcfg.GossipPeerFaultPercent = c.config.GossipPeerFaultPercent
+	gcfg.GossipPeerMaxFaults = c.config.GossipPeerMaxFaults
+	gcfg.GossipPeerIgnoreDuration = c.config.GossipPeerIgnoreDuration
+	gcfg.GossipMaxPeers = c.config.GossipMaxPeers
 	var (
 		build  builder.Builder
 		gossip gossiper.Gossiper
@@ -128,15 +140,9 @@ func (c *Controller) Initialize(
 	if c.config.TestMode {
 		c.inner.Logger().Info("running build and gossip in test mode")
 		build = builder.NewManual(inner)
-		gossip = gossiper.NewManual(inner)
+		gossip = gossiper.NewManual(inner, gcfg)
 	} else {
 		build = builder.NewTime(inner)
-		gcfg := gossiper.DefaultProposerConfig()
-		gcfg.GossipMaxSize = c.config.GossipMaxSize
-		gcfg.GossipProposerDiff = c.config.GossipProposerDiff
-		gcfg.GossipProposerDepth = c.config.GossipProposerDepth
-		gcfg.NoGossipBuilderDiff = c.config.NoGossipBuilderDiff
-		gcfg.VerifyTimeout = c.config.VerifyTimeout
 		gossip, err = gossiper.NewProposer(inner, gcfg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
@@ -178,8 +184,11 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				return err
 			}
 		}
-		if result.Success {
-			switch action := tx.Action.(type) {
+		if !result.Success {
+			continue
+		}
+		for j, act := range tx.Actions {
+			switch action := act.(type) {
 			case *actions.CreateAsset:
 				c.metrics.createAsset.Inc()
 			case *actions.MintAsset:
@@ -190,10 +199,10 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.transfer.Inc()
 			case *actions.CreateOrder:
 				c.metrics.createOrder.Inc()
-				c.orderBook.Add(tx.ID(), tx.Auth.Actor(), action)
+				c.orderBook.Add(tx.ActionID(j), tx.Auth.Actor(), action)
 			case *actions.FillOrder:
 				c.metrics.fillOrder.Inc()
-				orderResult, err := actions.UnmarshalOrderResult(result.Output)
+				orderResult, err := actions.UnmarshalOrderResult(result.Outputs[j])
 				if err != nil {
 					// This should never happen
 					return err
@@ -210,6 +219,8 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.importAsset.Inc()
 			case *actions.ExportAsset:
 				c.metrics.exportAsset.Inc()
+			case *actions.CheckRemoteBalance:
+				c.metrics.checkRemoteBalance.Inc()
 			}
 		}
 	}
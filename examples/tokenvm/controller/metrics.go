@@ -23,6 +23,8 @@ type metrics struct {
 
 	importAsset prometheus.Counter
 	exportAsset prometheus.Counter
+
+	checkRemoteBalance prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -72,6 +74,11 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "export_asset",
 			Help:      "number of export asset actions",
 		}),
+		checkRemoteBalance: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "check_remote_balance",
+			Help:      "number of check remote balance actions",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -88,6 +95,8 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 
 		r.Register(m.importAsset),
 		r.Register(m.exportAsset),
+
+		r.Register(m.checkRemoteBalance),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
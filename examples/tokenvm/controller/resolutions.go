@@ -28,6 +28,17 @@ func (c *Controller) Tracer() trace.Tracer {
 	return c.inner.Tracer()
 }
 
+// readState returns a [storage.ReadState] for the post-execution state of the
+// accepted block at [height] (or the latest state if [height] is nil).
+func (c *Controller) readState(height *uint64) storage.ReadState {
+	if height == nil {
+		return c.inner.ReadState
+	}
+	return func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+		return c.inner.ReadStateAt(ctx, *height, keys)
+	}
+}
+
 func (c *Controller) GetTransaction(
 	ctx context.Context,
 	txID ids.ID,
@@ -38,16 +49,18 @@ func (c *Controller) GetTransaction(
 func (c *Controller) GetAssetFromState(
 	ctx context.Context,
 	asset ids.ID,
+	height *uint64,
 ) (bool, []byte, uint8, []byte, uint64, codec.Address, bool, error) {
-	return storage.GetAssetFromState(ctx, c.inner.ReadState, asset)
+	return storage.GetAssetFromState(ctx, c.readState(height), asset)
 }
 
 func (c *Controller) GetBalanceFromState(
 	ctx context.Context,
 	addr codec.Address,
 	asset ids.ID,
+	height *uint64,
 ) (uint64, error) {
-	return storage.GetBalanceFromState(ctx, c.inner.ReadState, addr, asset)
+	return storage.GetBalanceFromState(ctx, c.readState(height), addr, asset)
 }
 
 func (c *Controller) Orders(pair string, limit int) []*orderbook.Order {
@@ -57,6 +70,7 @@ func (c *Controller) Orders(pair string, limit int) []*orderbook.Order {
 func (c *Controller) GetOrderFromState(
 	ctx context.Context,
 	orderID ids.ID,
+	height *uint64,
 ) (
 	bool, // exists
 	ids.ID, // in
@@ -67,13 +81,14 @@ func (c *Controller) GetOrderFromState(
 	codec.Address, // owner
 	error,
 ) {
-	return storage.GetOrderFromState(ctx, c.inner.ReadState, orderID)
+	return storage.GetOrderFromState(ctx, c.readState(height), orderID)
 }
 
 func (c *Controller) GetLoanFromState(
 	ctx context.Context,
 	asset ids.ID,
 	destination ids.ID,
+	height *uint64,
 ) (uint64, error) {
-	return storage.GetLoanFromState(ctx, c.inner.ReadState, asset, destination)
+	return storage.GetLoanFromState(ctx, c.readState(height), asset, destination)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/upgrade"
 	"github.com/ava-labs/hypersdk/vm"
 )
 
@@ -44,7 +45,8 @@ type Genesis struct {
 	MaxBlockUnits              chain.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
-	ValidityWindow int64 `json:"validityWindow"` // ms
+	ValidityWindow  int64 `json:"validityWindow"` // ms
+	MaxActionsPerTx uint8 `json:"maxActionsPerTx"`
 
 	// Tx Fee Parameters
 	BaseComputeUnits          uint64 `json:"baseUnits"`
@@ -58,8 +60,40 @@ type Genesis struct {
 	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
 
+	// Warp Parameters
+	WarpPolicy chain.WarpPolicy `json:"warpPolicy"`
+
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
+
+	// Upgrades (parsed from upgradeBytes)
+	upgrades upgrade.Schedule
+	forks    []*Genesis // params of each upgrade
+}
+
+// upgradableParams are the parameters (by their JSON key) that can be changed
+// by an upgrade. Parameters that are committed to by state (the state branch
+// factor), that bound replay protection (the validity window), or that are only
+// used when the chain is created (allocations) can't be changed.
+var upgradableParams = []string{
+	"minBlockGap",
+	"minEmptyBlockGap",
+	"minUnitPrice",
+	"unitPriceChangeDenominator",
+	"windowTargetUnits",
+	"maxBlockUnits",
+	"maxActionsPerTx",
+	"baseUnits",
+	"baseWarpUnits",
+	"warpUnitsPerSigner",
+	"outgoingWarpComputeUnits",
+	"storageKeyReadUnits",
+	"storageValueReadUnits",
+	"storageKeyAllocateUnits",
+	"storageValueAllocateUnits",
+	"storageKeyWriteUnits",
+	"storageValueWriteUnits",
+	"warpPolicy",
 }
 
 func Default() *Genesis {
@@ -78,7 +112,8 @@ func Default() *Genesis {
 		MaxBlockUnits:              chain.Dimensions{1_800_000, 2_000, 2_000, 2_000, 2_000},
 
 		// Tx Parameters
-		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
+		ValidityWindow:  60 * hconsts.MillisecondsPerSecond, // ms
+		MaxActionsPerTx: 16,
 
 		// Tx Fee Compute Parameters
 		BaseComputeUnits:          1,
@@ -95,19 +130,70 @@ func Default() *Genesis {
 		StorageValueAllocateUnits: 5,
 		StorageKeyWriteUnits:      10,
 		StorageValueWriteUnits:    3,
+
+		// Warp Parameters
+		//
+		// We allow inbound transfers from all sources as long as 80% of stake
+		// has signed a message.
+		//
+		// This is safe because the tokenvm scopes all assets by their source
+		// chain.
+		WarpPolicy: chain.WarpPolicy{
+			Default: &chain.WarpQuorum{Num: 4, Den: 5},
+		},
 	}
 }
 
-func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+func New(b []byte, upgradeBytes []byte) (*Genesis, error) {
 	g := Default()
 	if len(b) > 0 {
 		if err := json.Unmarshal(b, g); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
+	if g.MaxActionsPerTx == 0 {
+		return nil, ErrInvalidMaxActionsPerTx
+	}
+	if err := g.WarpPolicy.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: warpPolicy", err)
+	}
+	upgrades, err := upgrade.Parse(upgradeBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.SetUpgrades(upgrades); err != nil {
+		return nil, err
+	}
 	return g, nil
 }
 
+// SetUpgrades schedules [upgrades], which may only override the
+// [upgradableParams] of [g].
+func (g *Genesis) SetUpgrades(upgrades upgrade.Schedule) error {
+	if err := upgrades.Verify(); err != nil {
+		return err
+	}
+	forks, err := upgrade.Apply(upgrades, g, upgradableParams)
+	if err != nil {
+		return err
+	}
+	for i, fork := range forks {
+		if fork.MaxActionsPerTx == 0 {
+			return fmt.Errorf("%w: in %s", ErrInvalidMaxActionsPerTx, upgrades[i].Name)
+		}
+		if err := fork.WarpPolicy.Verify(); err != nil {
+			return fmt.Errorf("%w: warpPolicy in %s", err, upgrades[i].Name)
+		}
+	}
+	g.upgrades = upgrades
+	g.forks = forks
+	return nil
+}
+
+func (g *Genesis) Upgrades() upgrade.Schedule {
+	return g.upgrades
+}
+
 func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutable) error {
 	ctx, span := tracer.Start(ctx, "Genesis.Load")
 	defer span.End()
@@ -7,28 +7,31 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/upgrade"
 )
 
 var _ chain.Rules = (*Rules)(nil)
 
 type Rules struct {
-	g *Genesis
+	g        *Genesis // params of the active upgrade
+	upgrades upgrade.Schedule
 
 	networkID uint32
 	chainID   ids.ID
 }
 
-// TODO: use upgradeBytes
-func (g *Genesis) Rules(_ int64, networkID uint32, chainID ids.ID) *Rules {
-	return &Rules{g, networkID, chainID}
+// Rules returns the [Rules] of the most recent upgrade active at [t] (or of
+// genesis, if none are active).
+func (g *Genesis) Rules(t int64, networkID uint32, chainID ids.ID) *Rules {
+	params := g
+	if i := g.upgrades.Active(t); i >= 0 {
+		params = g.forks[i]
+	}
+	return &Rules{params, g.upgrades, networkID, chainID}
 }
 
-func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
-	// We allow inbound transfers from all sources as long as 80% of stake has
-	// signed a message.
-	//
-	// This is safe because the tokenvm scopes all assets by their source chain.
-	return true, 4, 5
+func (r *Rules) GetWarpConfig(sourceChainID ids.ID) (bool, uint64, uint64) {
+	return r.g.WarpPolicy.Config(sourceChainID)
 }
 
 func (r *Rules) NetworkID() uint32 {
@@ -51,6 +54,10 @@ func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
 
+func (r *Rules) GetMaxActionsPerTx() uint8 {
+	return r.g.MaxActionsPerTx
+}
+
 func (r *Rules) GetMaxBlockUnits() chain.Dimensions {
 	return r.g.MaxBlockUnits
 }
@@ -111,6 +118,10 @@ func (r *Rules) GetWindowTargetUnits() chain.Dimensions {
 	return r.g.WindowTargetUnits
 }
 
+func (r *Rules) GetUpgradeTimestamp(name string) (int64, bool) {
+	return r.upgrades.Timestamp(name)
+}
+
 func (*Rules) FetchCustom(string) (any, bool) {
 	return nil, false
 }
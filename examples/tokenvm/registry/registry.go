@@ -35,8 +35,11 @@ func init() {
 		consts.ActionRegistry.Register((&actions.ImportAsset{}).GetTypeID(), actions.UnmarshalImportAsset, true),
 		consts.ActionRegistry.Register((&actions.ExportAsset{}).GetTypeID(), actions.UnmarshalExportAsset, false),
 
+		consts.ActionRegistry.Register((&actions.CheckRemoteBalance{}).GetTypeID(), actions.UnmarshalCheckRemoteBalance, true),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register(auth.NewSponsorWrapper(nil, nil).GetTypeID(), auth.UnmarshalSponsorWrapper, false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -18,10 +18,10 @@ type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
 	GetTransaction(context.Context, ids.ID) (bool, int64, bool, chain.Dimensions, uint64, error)
-	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint8, []byte, uint64, codec.Address, bool, error)
-	GetBalanceFromState(context.Context, codec.Address, ids.ID) (uint64, error)
+	GetAssetFromState(context.Context, ids.ID, *uint64) (bool, []byte, uint8, []byte, uint64, codec.Address, bool, error)
+	GetBalanceFromState(context.Context, codec.Address, ids.ID, *uint64) (uint64, error)
 	Orders(pair string, limit int) []*orderbook.Order
-	GetOrderFromState(context.Context, ids.ID) (
+	GetOrderFromState(context.Context, ids.ID, *uint64) (
 		bool, // exists
 		ids.ID, // in
 		uint64, // inTick
@@ -31,5 +31,5 @@ type Controller interface {
 		codec.Address, // owner
 		error,
 	)
-	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
+	GetLoanFromState(context.Context, ids.ID, ids.ID, *uint64) (uint64, error)
 }
@@ -59,6 +59,9 @@ func (cli *JSONRPCClient) Genesis(ctx context.Context) (*genesis.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := resp.Genesis.SetUpgrades(resp.Upgrades); err != nil {
+		return nil, err
+	}
 	cli.g = resp.Genesis
 	return resp.Genesis, nil
 }
@@ -93,12 +96,38 @@ func (cli *JSONRPCClient) Asset(
 	if ok && useCache {
 		return true, r.Symbol, r.Decimals, r.Metadata, r.Supply, r.Owner, r.Warp, nil
 	}
+	resp, err := cli.asset(ctx, asset, nil)
+	if resp == nil || err != nil {
+		return false, nil, 0, nil, 0, "", false, err
+	}
+	cli.assetsL.Lock()
+	cli.assets[asset] = resp
+	cli.assetsL.Unlock()
+	return true, resp.Symbol, resp.Decimals, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, nil
+}
+
+// AssetAt returns [asset] as of the accepted block at [height] (never cached).
+func (cli *JSONRPCClient) AssetAt(
+	ctx context.Context,
+	asset ids.ID,
+	height uint64,
+) (bool, []byte, uint8, []byte, uint64, string, bool, error) {
+	resp, err := cli.asset(ctx, asset, &height)
+	if resp == nil || err != nil {
+		return false, nil, 0, nil, 0, "", false, err
+	}
+	return true, resp.Symbol, resp.Decimals, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, nil
+}
+
+// asset returns a nil reply if [asset] does not exist.
+func (cli *JSONRPCClient) asset(ctx context.Context, asset ids.ID, height *uint64) (*AssetReply, error) {
 	resp := new(AssetReply)
 	err := cli.requester.SendRequest(
 		ctx,
 		"asset",
 		&AssetArgs{
-			Asset: asset,
+			Asset:  asset,
+			Height: height,
 		},
 		resp,
 	)
@@ -106,17 +135,23 @@ func (cli *JSONRPCClient) Asset(
 	// We use string parsing here because the JSON-RPC library we use may not
 	// allows us to perform errors.Is.
 	case err != nil && strings.Contains(err.Error(), ErrAssetNotFound.Error()):
-		return false, nil, 0, nil, 0, "", false, nil
+		return nil, nil
 	case err != nil:
-		return false, nil, 0, nil, 0, "", false, err
+		return nil, err
 	}
-	cli.assetsL.Lock()
-	cli.assets[asset] = resp
-	cli.assetsL.Unlock()
-	return true, resp.Symbol, resp.Decimals, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, nil
+	return resp, nil
 }
 
 func (cli *JSONRPCClient) Balance(ctx context.Context, addr string, asset ids.ID) (uint64, error) {
+	return cli.balance(ctx, addr, asset, nil)
+}
+
+// BalanceAt returns the balance of [addr] as of the accepted block at [height].
+func (cli *JSONRPCClient) BalanceAt(ctx context.Context, addr string, asset ids.ID, height uint64) (uint64, error) {
+	return cli.balance(ctx, addr, asset, &height)
+}
+
+func (cli *JSONRPCClient) balance(ctx context.Context, addr string, asset ids.ID, height *uint64) (uint64, error) {
 	resp := new(BalanceReply)
 	err := cli.requester.SendRequest(
 		ctx,
@@ -124,6 +159,7 @@ func (cli *JSONRPCClient) Balance(ctx context.Context, addr string, asset ids.ID
 		&BalanceArgs{
 			Address: addr,
 			Asset:   asset,
+			Height:  height,
 		},
 		resp,
 	)
@@ -144,12 +180,22 @@ func (cli *JSONRPCClient) Orders(ctx context.Context, pair string) ([]*orderbook
 }
 
 func (cli *JSONRPCClient) GetOrder(ctx context.Context, orderID ids.ID) (*orderbook.Order, error) {
+	return cli.getOrder(ctx, orderID, nil)
+}
+
+// GetOrderAt returns [orderID] as of the accepted block at [height].
+func (cli *JSONRPCClient) GetOrderAt(ctx context.Context, orderID ids.ID, height uint64) (*orderbook.Order, error) {
+	return cli.getOrder(ctx, orderID, &height)
+}
+
+func (cli *JSONRPCClient) getOrder(ctx context.Context, orderID ids.ID, height *uint64) (*orderbook.Order, error) {
 	resp := new(GetOrderReply)
 	err := cli.requester.SendRequest(
 		ctx,
 		"getOrder",
 		&GetOrderArgs{
 			OrderID: orderID,
+			Height:  height,
 		},
 		resp,
 	)
@@ -160,6 +206,26 @@ func (cli *JSONRPCClient) Loan(
 	ctx context.Context,
 	asset ids.ID,
 	destination ids.ID,
+) (uint64, error) {
+	return cli.loan(ctx, asset, destination, nil)
+}
+
+// LoanAt returns the amount of [asset] loaned to [destination] as of the
+// accepted block at [height].
+func (cli *JSONRPCClient) LoanAt(
+	ctx context.Context,
+	asset ids.ID,
+	destination ids.ID,
+	height uint64,
+) (uint64, error) {
+	return cli.loan(ctx, asset, destination, &height)
+}
+
+func (cli *JSONRPCClient) loan(
+	ctx context.Context,
+	asset ids.ID,
+	destination ids.ID,
+	height *uint64,
 ) (uint64, error) {
 	resp := new(LoanReply)
 	err := cli.requester.SendRequest(
@@ -168,6 +234,7 @@ func (cli *JSONRPCClient) Loan(
 		&LoanArgs{
 			Asset:       asset,
 			Destination: destination,
+			Height:      height,
 		},
 		resp,
 	)
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/upgrade"
 )
 
 type JSONRPCServer struct {
@@ -24,11 +25,13 @@ func NewJSONRPCServer(c Controller) *JSONRPCServer {
 }
 
 type GenesisReply struct {
-	Genesis *genesis.Genesis `json:"genesis"`
+	Genesis  *genesis.Genesis `json:"genesis"`
+	Upgrades upgrade.Schedule `json:"upgrades"`
 }
 
 func (j *JSONRPCServer) Genesis(_ *http.Request, _ *struct{}, reply *GenesisReply) (err error) {
 	reply.Genesis = j.c.Genesis()
+	reply.Upgrades = reply.Genesis.Upgrades()
 	return nil
 }
 
@@ -63,6 +66,10 @@ func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) erro
 
 type AssetArgs struct {
 	Asset ids.ID `json:"asset"`
+
+	// Height is the accepted block to read state at (the latest state is
+	// read if not provided).
+	Height *uint64 `json:"height,omitempty"`
 }
 
 type AssetReply struct {
@@ -78,7 +85,7 @@ func (j *JSONRPCServer) Asset(req *http.Request, args *AssetArgs, reply *AssetRe
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Asset")
 	defer span.End()
 
-	exists, symbol, decimals, metadata, supply, owner, warp, err := j.c.GetAssetFromState(ctx, args.Asset)
+	exists, symbol, decimals, metadata, supply, owner, warp, err := j.c.GetAssetFromState(ctx, args.Asset, args.Height)
 	if err != nil {
 		return err
 	}
@@ -97,6 +104,10 @@ func (j *JSONRPCServer) Asset(req *http.Request, args *AssetArgs, reply *AssetRe
 type BalanceArgs struct {
 	Address string `json:"address"`
 	Asset   ids.ID `json:"asset"`
+
+	// Height is the accepted block to read state at (the latest state is
+	// read if not provided).
+	Height *uint64 `json:"height,omitempty"`
 }
 
 type BalanceReply struct {
@@ -111,7 +122,7 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	if err != nil {
 		return err
 	}
-	balance, err := j.c.GetBalanceFromState(ctx, addr, args.Asset)
+	balance, err := j.c.GetBalanceFromState(ctx, addr, args.Asset, args.Height)
 	if err != nil {
 		return err
 	}
@@ -137,6 +148,10 @@ func (j *JSONRPCServer) Orders(req *http.Request, args *OrdersArgs, reply *Order
 
 type GetOrderArgs struct {
 	OrderID ids.ID `json:"orderID"`
+
+	// Height is the accepted block to read state at (the latest state is
+	// read if not provided).
+	Height *uint64 `json:"height,omitempty"`
 }
 
 type GetOrderReply struct {
@@ -147,7 +162,7 @@ func (j *JSONRPCServer) GetOrder(req *http.Request, args *GetOrderArgs, reply *G
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.GetOrder")
 	defer span.End()
 
-	exists, in, inTick, out, outTick, remaining, owner, err := j.c.GetOrderFromState(ctx, args.OrderID)
+	exists, in, inTick, out, outTick, remaining, owner, err := j.c.GetOrderFromState(ctx, args.OrderID, args.Height)
 	if err != nil {
 		return err
 	}
@@ -169,6 +184,10 @@ func (j *JSONRPCServer) GetOrder(req *http.Request, args *GetOrderArgs, reply *G
 type LoanArgs struct {
 	Destination ids.ID `json:"destination"`
 	Asset       ids.ID `json:"asset"`
+
+	// Height is the accepted block to read state at (the latest state is
+	// read if not provided).
+	Height *uint64 `json:"height,omitempty"`
 }
 
 type LoanReply struct {
@@ -179,7 +198,7 @@ func (j *JSONRPCServer) Loan(req *http.Request, args *LoanArgs, reply *LoanReply
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Loan")
 	defer span.End()
 
-	amount, err := j.c.GetLoanFromState(ctx, args.Asset, args.Destination)
+	amount, err := j.c.GetLoanFromState(ctx, args.Asset, args.Destination, args.Height)
 	if err != nil {
 		return err
 	}
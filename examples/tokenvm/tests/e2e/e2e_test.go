@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
@@ -528,10 +529,10 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    aother,
 					Value: sendAmount,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -606,13 +607,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       ids.Empty,
 					Value:       sendAmount,
 					Return:      false,
 					Destination: destination,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -652,11 +653,11 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    auth.NewED25519Address(other.PublicKey()),
 					Asset: ids.Empty,
 					Value: 500_000_000,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -743,7 +744,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -808,13 +809,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          rsender,
 					Asset:       newAsset,
 					Value:       100,
 					Return:      false,
 					Destination: ids.GenerateTestID(),
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -849,14 +850,14 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          rsender,
 					Asset:       newAsset,
 					Value:       2000,
 					Return:      true,
 					Destination: source,
 					Reward:      100,
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -958,7 +959,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1018,13 +1019,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       newAsset,
 					Value:       2900,
 					Return:      true,
 					Destination: source,
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1120,7 +1121,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1179,7 +1180,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       ids.Empty, // becomes newAsset
 					Value:       2000,
@@ -1189,7 +1190,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 					SwapOut:     200,
 					SwapExpiry:  time.Now().UnixMilli() + 100_000,
 					Destination: destination,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1270,9 +1271,9 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{
+				[]chain.Action{&actions.ImportAsset{
 					Fill: true,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1531,10 +1532,10 @@ func generateBlocks(
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 1,
-			},
+			}},
 			factory,
 		)
 		if failOnError {
@@ -1599,10 +1600,10 @@ func acceptTransaction(cli *rpc.JSONRPCClient, tcli *trpc.JSONRPCClient) {
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: sendAmount,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ = ginkgo.Describe("[GossipPeers]", func() {
+	var (
+		net  = useNetwork()
+		inst instance
+	)
+
+	ginkgo.BeforeEach(func() {
+		inst = net.newChain(genesisBytes)
+	})
+
+	ginkgo.It("ignores peers that send invalid gossip", func() {
+		ctx := context.Background()
+		acli := rpc.NewAdminJSONRPCClient(inst.JSONRPCServer.URL)
+		peers, err := acli.GossipPeers(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(peers).Should(gomega.BeEmpty())
+
+		// Exceed the faults tolerated from a single peer
+		nodeID := ids.GenerateTestNodeID()
+		maxFaults := gossiper.DefaultProposerConfig().GossipPeerMaxFaults
+		for i := 0; i <= maxFaults+1; i++ {
+			gomega.Ω(inst.vm.Gossiper().HandleAppGossip(ctx, nodeID, []byte{0xff})).Should(gomega.BeNil())
+		}
+
+		peers, err = acli.GossipPeers(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(peers).Should(gomega.HaveLen(1))
+		peer := peers[0]
+		gomega.Ω(peer.NodeID).Should(gomega.Equal(nodeID))
+		gomega.Ω(peer.Invalid).Should(gomega.Equal(uint64(maxFaults + 1)))
+		gomega.Ω(peer.Ignored).Should(gomega.Equal(uint64(1)))
+		gomega.Ω(peer.IgnoredUntil).ShouldNot(gomega.BeZero())
+	})
+})
@@ -224,7 +224,7 @@ var _ = ginkgo.BeforeSuite(func() {
 			genesisBytes,
 			nil,
 			[]byte(
-				`{"parallelism":3, "testMode":true, "logLevel":"debug", "trackedPairs":["*"]}`,
+				`{"parallelism":3, "testMode":true, "logLevel":"debug", "trackedPairs":["*"], "transactionIndexing":true}`,
 			),
 			toEngine,
 			nil,
@@ -337,10 +337,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 100_000, // must be more than StateLockup
-				},
+				}},
 				factory,
 			)
 			transferTxRoot = transferTx
@@ -370,10 +370,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 					MaxFee:    1000,
 				},
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 110,
-				},
+				}},
 			)
 			// Must do manual construction to avoid `tx.Sign` error (would fail with
 			// 0 timestamp)
@@ -427,7 +427,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			results := blk.(*chain.StatelessBlock).Results()
 			gomega.Ω(results).Should(gomega.HaveLen(1))
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-			gomega.Ω(results[0].Output).Should(gomega.BeNil())
+			gomega.Ω(results[0].Error).Should(gomega.BeNil())
+			gomega.Ω(results[0].Outputs).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Outputs[0]).Should(gomega.BeNil())
 
 			// Unit explanation
 			//
@@ -436,19 +438,19 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// read: 2 keys reads, 1 had 0 chunks
 			// allocate: 1 key created
 			// write: 1 key modified, 1 key new
-			transferTxConsumed := chain.Dimensions{227, 7, 12, 25, 26}
+			transferTxConsumed := chain.Dimensions{228, 7, 12, 25, 26}
 			gomega.Ω(results[0].Consumed).Should(gomega.Equal(transferTxConsumed))
 
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(297)))
+			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(298)))
 		})
 
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].tcli.Balance(context.Background(), sender, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
-			gomega.Ω(balance).To(gomega.Equal(uint64(9899703)))
+			gomega.Ω(balance).To(gomega.Equal(uint64(9899702)))
 			balance2, err := instances[1].tcli.Balance(context.Background(), sender2, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
 			gomega.Ω(balance2).To(gomega.Equal(uint64(100000)))
@@ -463,10 +465,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 101,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -493,10 +495,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 200,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -508,10 +510,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 201,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -538,10 +540,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 203,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -640,7 +642,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -656,7 +658,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		blk, lresults, prices, err := cli.ListenBlock(context.TODO(), parser)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(len(blk.Txs)).Should(gomega.Equal(1))
-		tx := blk.Txs[0].Action.(*actions.Transfer)
+		tx := blk.Txs[0].Actions[0].(*actions.Transfer)
 		gomega.Ω(tx.Asset).To(gomega.Equal(ids.Empty))
 		gomega.Ω(tx.Value).To(gomega.Equal(uint64(1)))
 		gomega.Ω(lresults).Should(gomega.Equal(results))
@@ -689,7 +691,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -724,6 +726,54 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(cli.Close()).Should(gomega.BeNil())
 	})
 
+	ginkgo.It("streams events filtered by address", func() {
+		cli, err := rpc.NewWebSocketClient(instances[0].WebSocketServer.URL, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Only subscribe to transfers to [other]
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rother := auth.NewED25519Address(other.PublicKey())
+		gomega.Ω(cli.RegisterEvents(&rpc.EventFilter{Addresses: []codec.Address{rother}})).Should(gomega.BeNil())
+
+		// Wait for message to be sent
+		time.Sleep(2 * pubsub.MaxMessageWait)
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{
+				&actions.Transfer{To: rsender2, Value: 1},
+				&actions.Transfer{To: rother, Value: 2},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Events).Should(gomega.HaveLen(2))
+
+		height, events, err := cli.ListenEvents(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(height).Should(gomega.Equal(instances[0].vm.LastAcceptedBlock().Height()))
+		gomega.Ω(events).Should(gomega.HaveLen(1))
+		gomega.Ω(events[0].TxID).Should(gomega.Equal(tx.ID()))
+		gomega.Ω(events[0].Event.Address).Should(gomega.Equal(rother))
+		gomega.Ω(events[0].Event.Topics).Should(gomega.Equal([]ids.ID{ids.Empty}))
+		from, value, err := actions.UnmarshalTransferEvent(events[0].Event)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(from).Should(gomega.Equal(rsender))
+		gomega.Ω(value).Should(gomega.Equal(uint64(2)))
+
+		gomega.Ω(cli.Close()).Should(gomega.BeNil())
+	})
+
 	ginkgo.It("transfer an asset with a memo", func() {
 		other, err := ed25519.GeneratePrivateKey()
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -733,11 +783,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 10,
 				Memo:  []byte("hello"),
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -749,6 +799,133 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(result.Success).Should(gomega.BeTrue())
 	})
 
+	ginkgo.It("indexes transactions by ID and address", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rother := auth.NewED25519Address(other.PublicKey())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rother,
+				Value: 10,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+
+		// Blocks are indexed asynchronously by the acceptor
+		var indexed *rpc.GetTransactionReply
+		gomega.Ω(rpc.Wait(context.Background(), func(ctx context.Context) (bool, error) {
+			found, reply, err := instances[0].cli.GetTransaction(ctx, tx.ID())
+			indexed = reply
+			return found, err
+		})).Should(gomega.BeNil())
+		gomega.Ω(indexed.Success).Should(gomega.BeTrue())
+		gomega.Ω(indexed.Fee).Should(gomega.Equal(results[0].Fee))
+
+		// The recipient is declared as a participant
+		txIDs, next, err := instances[0].cli.GetAddressTransactions(context.Background(), rother, nil, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txIDs).Should(gomega.Equal([]ids.ID{tx.ID()}))
+		gomega.Ω(next).Should(gomega.BeEmpty())
+
+		// Page through the sender history
+		txIDs, next, err = instances[0].cli.GetAddressTransactions(context.Background(), rsender, nil, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txIDs).Should(gomega.HaveLen(1))
+		gomega.Ω(next).ShouldNot(gomega.BeEmpty())
+		all := txIDs
+		for len(next) > 0 {
+			txIDs, next, err = instances[0].cli.GetAddressTransactions(context.Background(), rsender, next, 1)
+			gomega.Ω(err).Should(gomega.BeNil())
+			all = append(all, txIDs...)
+		}
+		gomega.Ω(all[len(all)-1]).Should(gomega.Equal(tx.ID()))
+
+		// Missing transactions are not found
+		found, _, err := instances[0].cli.GetTransaction(context.Background(), ids.GenerateTestID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(found).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("simulates transactions before issuance", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rother := auth.NewED25519Address(other.PublicKey())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		transfer := []chain.Action{&actions.Transfer{
+			To:    rother,
+			Value: 10,
+		}}
+
+		// Simulate the transaction without signing it
+		digest, err := chain.NewTx(&chain.Base{
+			Timestamp: hutils.UnixRMilli(time.Now().UnixMilli(), parser.Rules(time.Now().UnixMilli()).GetValidityWindow()),
+			ChainID:   instances[0].chainID,
+			MaxFee:    1, // not yet known
+		}, nil, transfer).Digest()
+		gomega.Ω(err).Should(gomega.BeNil())
+		unsigned, err := instances[0].cli.SimulateUnsignedTx(context.Background(), digest, rsender, rsender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(unsigned.Success).Should(gomega.BeTrue())
+		gomega.Ω(unsigned.Events).Should(gomega.HaveLen(1))
+		gomega.Ω(unsigned.TouchedKeys).ShouldNot(gomega.BeEmpty())
+
+		// Simulate the signed transaction before issuing it
+		submit, tx, maxFee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			transfer,
+			factory,
+			&rpc.Simulate{},
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		simulated, err := instances[0].cli.SimulateTx(context.Background(), tx.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(simulated.Success).Should(gomega.BeTrue())
+		gomega.Ω(simulated.Fee).Should(gomega.BeNumerically("<=", maxFee))
+		gomega.Ω(unsigned.Fee).Should(gomega.BeNumerically("<", simulated.Fee))
+
+		// Transactions that would fail are not returned
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rother,
+				Value: balance + 1,
+			}},
+			factory,
+			&rpc.Simulate{},
+		)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(rpc.ErrTxWouldFail.Error())))
+
+		// Simulation does not modify state
+		nbalance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nbalance).Should(gomega.Equal(balance))
+
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Consumed).Should(gomega.Equal(simulated.Units))
+		gomega.Ω(results[0].Fee).Should(gomega.Equal(simulated.Fee))
+	})
+
 	ginkgo.It("transfer an asset with large memo", func() {
 		other, err := ed25519.GeneratePrivateKey()
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -759,11 +936,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 10,
 				Memo:  make([]byte, 1000),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
@@ -792,11 +969,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Asset: assetID,
 				Value: 10,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -806,7 +983,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("asset missing"))
 
 		exists, _, _, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID, false)
@@ -814,6 +991,50 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(exists).Should(gomega.BeFalse())
 	})
 
+	ginkgo.It("reverts earlier actions if a later action fails", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		otherAddr := auth.NewED25519Address(other.PublicKey())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{
+				&actions.Transfer{
+					To:    otherAddr,
+					Value: 10,
+				},
+				&actions.MintAsset{
+					To:    otherAddr,
+					Asset: ids.GenerateTestID(),
+					Value: 10,
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Error)).
+			Should(gomega.ContainSubstring("asset missing"))
+		gomega.Ω(result.Outputs).Should(gomega.BeEmpty())
+
+		// The transfer in the first action was not applied
+		balance, err := instances[0].tcli.Balance(
+			context.Background(),
+			codec.MustAddressBech32(tconsts.HRP, otherAddr),
+			ids.Empty,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+	})
+
 	ginkgo.It("create a new asset (no metadata)", func() {
 		tx := chain.NewTx(
 			&chain.Base{
@@ -822,11 +1043,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   []byte("s0"),
 				Decimals: 0,
 				Metadata: nil,
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
@@ -853,11 +1074,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   nil,
 				Decimals: 0,
 				Metadata: []byte("m"),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
@@ -884,11 +1105,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   []byte("s0"),
 				Decimals: 0,
 				Metadata: make([]byte, actions.MaxMetadataSize*2),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
@@ -914,11 +1135,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   asset1Symbol,
 				Decimals: asset1Decimals,
 				Metadata: asset1,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -947,15 +1168,15 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 	ginkgo.It("mint a new asset", func() {
 		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
-		submit, _, _, err := instances[0].cli.GenerateTransaction(
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    rsender2,
 				Asset: asset1ID,
 				Value: 15,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -981,6 +1202,27 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(supply).Should(gomega.Equal(uint64(15)))
 		gomega.Ω(owner).Should(gomega.Equal(sender))
 		gomega.Ω(warp).Should(gomega.BeFalse())
+
+		// The recipient is declared as a participant (blocks are indexed
+		// asynchronously by the acceptor)
+		gomega.Ω(rpc.Wait(context.Background(), func(ctx context.Context) (bool, error) {
+			found, _, err := instances[0].cli.GetTransaction(ctx, tx.ID())
+			return found, err
+		})).Should(gomega.BeNil())
+		var (
+			all  []ids.ID
+			next []byte
+		)
+		for {
+			txIDs, nnext, err := instances[0].cli.GetAddressTransactions(context.Background(), rsender2, next, 0)
+			gomega.Ω(err).Should(gomega.BeNil())
+			all = append(all, txIDs...)
+			if len(nnext) == 0 {
+				break
+			}
+			next = nnext
+		}
+		gomega.Ω(all[len(all)-1]).Should(gomega.Equal(tx.ID()))
 	})
 
 	ginkgo.It("mint asset from wrong owner", func() {
@@ -992,11 +1234,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Asset: asset1ID,
 				Value: 10,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1006,7 +1248,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("wrong owner"))
 
 		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
@@ -1027,10 +1269,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.BurnAsset{
+			[]chain.Action{&actions.BurnAsset{
 				Asset: asset1ID,
 				Value: 5,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1065,10 +1307,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.BurnAsset{
+			[]chain.Action{&actions.BurnAsset{
 				Asset: asset1ID,
 				Value: 10,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1078,7 +1320,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("invalid balance"))
 
 		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
@@ -1102,10 +1344,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Asset: asset1ID,
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// bad codec)
@@ -1131,11 +1373,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    rsender2,
 				Asset: asset1ID,
 				Value: consts.MaxUint64,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1145,7 +1387,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("overflow"))
 
 		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset1ID)
@@ -1176,10 +1418,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 10,
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// bad codec)
@@ -1205,11 +1447,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   asset2Symbol,
 				Decimals: asset2Decimals,
 				Metadata: asset2,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1224,11 +1466,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    rsender,
 				Asset: asset2ID,
 				Value: 10,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1250,11 +1492,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   asset3Symbol,
 				Decimals: asset3Decimals,
 				Metadata: asset3,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1269,11 +1511,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    rsender2,
 				Asset: asset3ID,
 				Value: 10,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1295,13 +1537,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset3ID,
 				InTick:  1,
 				Out:     asset2ID,
 				OutTick: 2,
 				Supply:  4,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1333,13 +1575,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  4,
 				Out:     asset3ID,
 				OutTick: 2,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1349,7 +1591,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("supply is misaligned"))
 	})
 
@@ -1360,13 +1602,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  4,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1398,13 +1640,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  5,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1414,7 +1656,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("invalid balance"))
 	})
 
@@ -1431,13 +1673,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 10, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1447,7 +1689,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("value is misaligned"))
 	})
 
@@ -1464,13 +1706,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 20, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1480,7 +1722,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("invalid balance"))
 	})
 
@@ -1497,13 +1739,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 4, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1513,7 +1755,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeTrue())
-		or, err := actions.UnmarshalOrderResult(result.Output)
+		or, err := actions.UnmarshalOrderResult(result.Outputs[0])
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(or.In).Should(gomega.Equal(uint64(4)))
 		gomega.Ω(or.Out).Should(gomega.Equal(uint64(1)))
@@ -1544,10 +1786,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CloseOrder{
+			[]chain.Action{&actions.CloseOrder{
 				Order: order.ID,
 				Out:   asset3ID,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1557,7 +1799,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Error)).
 			Should(gomega.ContainSubstring("unauthorized"))
 	})
 
@@ -1572,10 +1814,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CloseOrder{
+			[]chain.Action{&actions.CloseOrder{
 				Order: order.ID,
 				Out:   asset3ID,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1611,13 +1853,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  2,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  1,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1655,13 +1897,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 4,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1671,7 +1913,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeTrue())
-		or, err := actions.UnmarshalOrderResult(result.Output)
+		or, err := actions.UnmarshalOrderResult(result.Outputs[0])
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(or.In).Should(gomega.Equal(uint64(2)))
 		gomega.Ω(or.Out).Should(gomega.Equal(uint64(1)))
@@ -1703,7 +1945,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// empty warp)
@@ -1732,7 +1974,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// empty warp)
@@ -1763,7 +2005,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// invalid object)
@@ -1797,7 +2039,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// invalid object)
@@ -1840,7 +2082,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1868,7 +2110,132 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("warp verification failed"))
+		gomega.Ω(string(result.Error)).Should(gomega.ContainSubstring("warp verification failed"))
+		gomega.Ω(string(result.WarpError)).Should(gomega.Equal(chain.ErrWarpNotVerified.Error()))
+	})
+
+	ginkgo.It("rejects warp messages denied by an upgrade", func() {
+		// The upgrade is scheduled in the past, so blocks on both sides of it
+		// can be built with explicit timestamps
+		sourceChainID := ids.GenerateTestID()
+		upgradeTime := time.Now().Add(-time.Minute).Truncate(time.Second).UnixMilli()
+		upgradeBytes := []byte(fmt.Sprintf(
+			`{"upgrades":[{"name":"deny","timestamp":%d,"params":{"warpPolicy":{"default":{"num":4,"den":5},"deny":[%q]}}}]}`,
+			upgradeTime, sourceChainID,
+		))
+
+		// nodes[0] builds each block and nodes[1] verifies it
+		chainID := ids.GenerateTestID()
+		nodes := make([]*vm.VM, 2)
+		for i := range nodes {
+			sk, err := bls.NewSecretKey()
+			gomega.Ω(err).Should(gomega.BeNil())
+			dname, err := os.MkdirTemp("", "upgrade-chainData")
+			gomega.Ω(err).Should(gomega.BeNil())
+			defer os.RemoveAll(dname)
+			snowCtx := &snow.Context{
+				NetworkID:      networkID,
+				ChainID:        chainID,
+				NodeID:         ids.GenerateTestNodeID(),
+				Log:            logging.NoLog{},
+				ChainDataDir:   dname,
+				Metrics:        metrics.NewOptionalGatherer(),
+				PublicKey:      bls.PublicFromSecretKey(sk),
+				WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+				ValidatorState: &validators.TestState{},
+			}
+			v := controller.New()
+			gomega.Ω(v.Initialize(
+				context.Background(),
+				snowCtx,
+				memdb.New(),
+				genesisBytes,
+				upgradeBytes,
+				[]byte(`{"testMode":true}`),
+				make(chan common.Message, 1),
+				nil,
+				&appSender{instances: instances},
+			)).Should(gomega.BeNil())
+			defer func() {
+				gomega.Ω(v.Shutdown(context.Background())).Should(gomega.BeNil())
+			}()
+			v.ForceReady()
+			nodes[i] = v
+		}
+		allowed, _, _ := nodes[0].Rules(upgradeTime - 1).GetWarpConfig(sourceChainID)
+		gomega.Ω(allowed).Should(gomega.BeTrue())
+		allowed, _, _ = nodes[0].Rules(upgradeTime).GetWarpConfig(sourceChainID)
+		gomega.Ω(allowed).Should(gomega.BeFalse())
+
+		// process builds a block at [tmstmp] with an import of an unsigned warp
+		// message from [sourceChainID] on nodes[0], verifies and accepts it on
+		// every node, and returns the warp error every node reported.
+		process := func(tmstmp int64) string {
+			ctx := context.Background()
+			wt := &actions.WarpTransfer{
+				To:                 rsender,
+				Symbol:             []byte("s"),
+				Decimals:           2,
+				Asset:              ids.GenerateTestID(),
+				Value:              100,
+				TxID:               ids.GenerateTestID(),
+				DestinationChainID: chainID,
+			}
+			wtb, err := wt.Marshal()
+			gomega.Ω(err).Should(gomega.BeNil())
+			uwm, err := warp.NewUnsignedMessage(networkID, sourceChainID, wtb)
+			gomega.Ω(err).Should(gomega.BeNil())
+			wm, err := warp.NewMessage(uwm, &warp.BitSetSignature{})
+			gomega.Ω(err).Should(gomega.BeNil())
+			tx, err := chain.NewTx(
+				&chain.Base{
+					Timestamp: upgradeTime + 10*consts.MillisecondsPerSecond,
+					ChainID:   chainID,
+					MaxFee:    1_000_000,
+				},
+				wm,
+				[]chain.Action{&actions.ImportAsset{}},
+			).Sign(factory, tconsts.ActionRegistry, tconsts.AuthRegistry)
+			gomega.Ω(err).Should(gomega.BeNil())
+
+			// The import fails (so it emits no events) and the message is not
+			// marked as verified
+			parent := nodes[0].LastAcceptedBlock()
+			blk := chain.NewBlock(nodes[0], parent, tmstmp)
+			blk.Txs = []*chain.Transaction{tx}
+			db, err := nodes[0].State()
+			gomega.Ω(err).Should(gomega.BeNil())
+			blk.StateRoot, err = db.GetMerkleRoot(ctx)
+			gomega.Ω(err).Should(gomega.BeNil())
+			source, err := blk.Marshal()
+			gomega.Ω(err).Should(gomega.BeNil())
+
+			var warpErr string
+			for i, node := range nodes {
+				sblk, err := node.ParseBlock(ctx, source)
+				gomega.Ω(err).Should(gomega.BeNil())
+				gomega.Ω(sblk.(block.WithVerifyContext).VerifyWithContext(ctx, &block.Context{})).Should(gomega.BeNil())
+				gomega.Ω(sblk.Accept(ctx)).Should(gomega.BeNil())
+				results := sblk.(*chain.StatelessBlock).Results()
+				gomega.Ω(results).Should(gomega.HaveLen(1))
+				gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+				if i == 0 {
+					warpErr = string(results[0].WarpError)
+				} else {
+					// All nodes must report the same warp error
+					gomega.Ω(string(results[0].WarpError)).Should(gomega.Equal(warpErr))
+				}
+			}
+			return warpErr
+		}
+
+		ginkgo.By("attempt to verify messages before the upgrade", func() {
+			gomega.Ω(process(upgradeTime - consts.MillisecondsPerSecond)).Should(gomega.Equal(chain.ErrWarpNotVerified.Error()))
+		})
+
+		ginkgo.By("deny messages after the upgrade", func() {
+			gomega.Ω(process(upgradeTime)).Should(gomega.Equal(chain.ErrDisabledChainID.Error()))
+		})
 	})
 
 	ginkgo.It("export native asset", func() {
@@ -1883,14 +2250,14 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.ExportAsset{
+			[]chain.Action{&actions.ExportAsset{
 				To:          rsender,
 				Asset:       ids.Empty,
 				Value:       100,
 				Return:      false,
 				Reward:      10,
 				Destination: dest,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1929,14 +2296,14 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.ExportAsset{
+			[]chain.Action{&actions.ExportAsset{
 				To:          rsender,
 				Asset:       ids.Empty,
 				Value:       100,
 				Return:      true,
 				Reward:      10,
 				Destination: ids.GenerateTestID(),
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1946,7 +2313,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not warp asset"))
+		gomega.Ω(string(result.Error)).Should(gomega.ContainSubstring("not warp asset"))
 	})
 })
 
@@ -0,0 +1,88 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+var _ = ginkgo.Describe("[MempoolFeeOrdering]", func() {
+	var (
+		net    = useNetwork()
+		source instance
+	)
+
+	ginkgo.BeforeEach(func() {
+		source = net.newChain(genesisBytes)
+	})
+
+	newAddress := func() codec.Address {
+		priv, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		return auth.NewED25519Address(priv.PublicKey())
+	}
+
+	// transfer submits a transfer of [value] to [to] from [factory] that pays
+	// [feeMultiplier] times the required max fee.
+	transfer := func(ctx context.Context, to codec.Address, value uint64, feeMultiplier uint64) {
+		parser, err := source.tcli.Parser(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		transfer := []chain.Action{&actions.Transfer{
+			To:    to,
+			Value: value,
+		}}
+		_, _, maxFee, err := source.cli.GenerateTransaction(ctx, parser, nil, transfer, factory)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, err := source.cli.GenerateTransactionManual(parser, nil, transfer, factory, maxFee*feeMultiplier)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(ctx)).Should(gomega.BeNil())
+	}
+
+	ginkgo.It("does not replace transfers from the same sponsor to different recipients", func() {
+		ctx := context.Background()
+		first, second := newAddress(), newAddress()
+		transfer(ctx, first, 1, 1)
+		transfer(ctx, second, 1, 2)
+
+		// Both transfers pay fees from the same balance but do not conflict
+		results := expectBlk(source)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		for _, result := range results {
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+		}
+		for _, to := range []codec.Address{first, second} {
+			balance, err := source.tcli.Balance(ctx, codec.MustAddressBech32(tconsts.HRP, to), ids.Empty)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+		}
+	})
+
+	ginkgo.It("replaces a transfer to the same recipient that pays a higher fee", func() {
+		ctx := context.Background()
+		to := newAddress()
+		transfer(ctx, to, 1, 1)
+		transfer(ctx, to, 2, 2) // replaces the first transfer
+		transfer(ctx, to, 3, 1) // pays less than the second transfer
+
+		results := expectBlk(source)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		balance, err := source.tcli.Balance(ctx, codec.MustAddressBech32(tconsts.HRP, to), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(2)))
+	})
+})
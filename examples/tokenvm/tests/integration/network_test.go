@@ -0,0 +1,228 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/ava-labs/hypersdk/rpc"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
+	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+)
+
+// network tracks chains (each on its own subnet) that share a
+// [validators.State], so chains can verify warp messages from each other.
+type network struct {
+	subnets map[ids.ID]ids.ID
+	vdrs    map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput
+
+	// state returns the validators in [vdrs] at every height
+	state *validators.TestState
+
+	// chains are shut down after each spec
+	chains []instance
+}
+
+// useNetwork returns a [network] for the specs of the enclosing container
+// whose chains are shut down after each spec.
+func useNetwork() *network {
+	n := &network{
+		subnets: map[ids.ID]ids.ID{},
+		vdrs:    map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput{},
+	}
+	n.state = &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 0, nil
+		},
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			return n.subnets[chainID], nil
+		},
+		GetValidatorSetF: func(_ context.Context, _ uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return n.vdrs[subnetID], nil
+		},
+	}
+	ginkgo.AfterEach(func() {
+		for _, inst := range n.chains {
+			inst.JSONRPCServer.Close()
+			gomega.Ω(inst.vm.Shutdown(context.TODO())).Should(gomega.BeNil())
+		}
+		n.chains = nil
+	})
+	return n
+}
+
+// newChain creates a single-validator chain (from [genesisBytes]) on its own
+// subnet.
+func (n *network) newChain(genesisBytes []byte) instance {
+	insts, _ := n.newValidators(genesisBytes, 1)
+	return insts[0]
+}
+
+// newValidators creates a chain (from [genesisBytes]) with [count] validators
+// (each with the same weight) on its own subnet. App requests between the
+// validators are delivered by the returned [networkAppSender] of each
+// validator.
+func (n *network) newValidators(genesisBytes []byte, count int) ([]instance, []*networkAppSender) {
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	n.subnets[chainID] = subnetID
+	n.vdrs[subnetID] = map[ids.NodeID]*validators.GetValidatorOutput{}
+	sks := make([]*bls.SecretKey, count)
+	nodeIDs := make([]ids.NodeID, count)
+	for i := range sks {
+		sk, err := bls.NewSecretKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		sks[i] = sk
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		n.vdrs[subnetID][nodeIDs[i]] = &validators.GetValidatorOutput{
+			NodeID:    nodeIDs[i],
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    100,
+		}
+	}
+
+	insts := make([]instance, count)
+	apps := make([]*networkAppSender, count)
+	for i := range insts {
+		apps[i] = &networkAppSender{nodeID: nodeIDs[i]}
+		insts[i] = newValidator(n.state, subnetID, chainID, nodeIDs[i], sks[i], genesisBytes, apps[i])
+	}
+	for _, app := range apps {
+		app.instances = insts
+	}
+	n.chains = append(n.chains, insts...)
+	return insts, apps
+}
+
+func newValidator(
+	state validators.State,
+	subnetID ids.ID,
+	chainID ids.ID,
+	nodeID ids.NodeID,
+	sk *bls.SecretKey,
+	genesisBytes []byte,
+	app common.AppSender,
+) instance {
+	l, err := logFactory.Make(nodeID.String())
+	gomega.Ω(err).Should(gomega.BeNil())
+	dname, err := os.MkdirTemp("", nodeID.String()+"-chainData")
+	gomega.Ω(err).Should(gomega.BeNil())
+	snowCtx := &snow.Context{
+		NetworkID:      networkID,
+		SubnetID:       subnetID,
+		ChainID:        chainID,
+		NodeID:         nodeID,
+		Log:            l,
+		ChainDataDir:   dname,
+		Metrics:        metrics.NewOptionalGatherer(),
+		PublicKey:      bls.PublicFromSecretKey(sk),
+		WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+		ValidatorState: state,
+	}
+
+	toEngine := make(chan common.Message, 1)
+	v := controller.New()
+	gomega.Ω(v.Initialize(
+		context.TODO(),
+		snowCtx,
+		memdb.New(),
+		genesisBytes,
+		nil,
+		[]byte(
+			`{"parallelism":3, "testMode":true, "logLevel":"debug", "transactionIndexing":true, "adminAPIEnabled":true, "warpStateReadAPIEnabled":true, "mempoolFeeOrdering":true}`,
+		),
+		toEngine,
+		nil,
+		app,
+	)).Should(gomega.BeNil())
+
+	var hd map[string]http.Handler
+	hd, err = v.CreateHandlers(context.TODO())
+	gomega.Ω(err).Should(gomega.BeNil())
+
+	// The relayer derives all endpoints from a single URI (like a node), so we
+	// serve all handlers from a single server
+	mux := http.NewServeMux()
+	for endpoint, handler := range hd {
+		mux.Handle(endpoint, handler)
+	}
+	server := httptest.NewServer(mux)
+	v.ForceReady()
+	return instance{
+		chainID:            chainID,
+		nodeID:             nodeID,
+		vm:                 v,
+		toEngine:           toEngine,
+		JSONRPCServer:      server,
+		TokenJSONRPCServer: server,
+		WebSocketServer:    server,
+		cli:                rpc.NewJSONRPCClient(server.URL),
+		tcli:               trpc.NewJSONRPCClient(server.URL, networkID, chainID),
+	}
+}
+
+// networkAppSender delivers app requests (and their responses) from
+// [nodeID] to the other [instances] of its chain (requests to nodes in
+// [offline] fail). Gossip is sent like [appSender].
+type networkAppSender struct {
+	appSender
+
+	nodeID  ids.NodeID
+	offline set.Set[ids.NodeID]
+}
+
+func (app *networkAppSender) instance(nodeID ids.NodeID) (instance, bool) {
+	for _, inst := range app.instances {
+		if inst.nodeID == nodeID {
+			return inst, true
+		}
+	}
+	return instance{}, false
+}
+
+func (app *networkAppSender) SendAppRequest(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+	self, _ := app.instance(app.nodeID)
+	for nodeID := range nodeIDs {
+		nodeID := nodeID
+		inst, ok := app.instance(nodeID)
+		if !ok || app.offline.Contains(nodeID) {
+			go func() {
+				_ = self.vm.AppRequestFailed(context.Background(), nodeID, requestID, common.ErrTimeout)
+			}()
+			continue
+		}
+		go func() {
+			_ = inst.vm.AppRequest(context.Background(), app.nodeID, requestID, time.Now().Add(requestTimeout), request)
+		}()
+	}
+	return nil
+}
+
+func (app *networkAppSender) SendAppResponse(_ context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	inst, ok := app.instance(nodeID)
+	if !ok {
+		return nil
+	}
+	go func() {
+		_ = inst.vm.AppResponse(context.Background(), app.nodeID, requestID, response)
+	}()
+	return nil
+}
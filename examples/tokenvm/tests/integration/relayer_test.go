@@ -0,0 +1,230 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/hypersdk/chain"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/relayer"
+)
+
+// startRelayer runs a relayer configured by [c] until the returned function
+// is called.
+func startRelayer(c *config.Config) func() {
+	r, err := relayer.New(log, c)
+	gomega.Ω(err).Should(gomega.BeNil())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = r.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func readCheckpoint(path string) *relayer.Checkpoint {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var checkpoint relayer.Checkpoint
+	gomega.Ω(json.Unmarshal(b, &checkpoint)).Should(gomega.BeNil())
+	return &checkpoint
+}
+
+var _ = ginkgo.Describe("[Relayer]", func() {
+	var (
+		net = useNetwork()
+
+		source      instance
+		destination instance
+		c           *config.Config
+
+		// offline is a validator of [source] (with half of its stake weight)
+		// that never signs, if [sourceOffline] is set
+		offline       *validators.GetValidatorOutput
+		sourceOffline atomic.Bool
+	)
+	getValidatorSet := net.state.GetValidatorSetF
+	net.state.GetValidatorSetF = func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		vdrSet, err := getValidatorSet(ctx, height, subnetID)
+		if err != nil || subnetID != net.subnets[source.chainID] || !sourceOffline.Load() {
+			return vdrSet, err
+		}
+		set := maps.Clone(vdrSet)
+		set[offline.NodeID] = offline
+		return set, nil
+	}
+
+	// setup creates a destination chain with [destinationGenesis] for
+	// [source] and configures a relayer between them.
+	setup := func(destinationGenesis []byte) {
+		destination = net.newChain(destinationGenesis)
+		c = &config.Config{
+			SourceRPC:       source.JSONRPCServer.URL,
+			DestinationRPC:  destination.JSONRPCServer.URL,
+			PrivateKeyBytes: priv[:],
+			CheckpointPath:  filepath.Join(ginkgo.GinkgoT().TempDir(), "checkpoint"),
+			QuorumNum:       4,
+			QuorumDen:       5,
+		}
+	}
+
+	ginkgo.BeforeEach(func() {
+		sourceOffline.Store(false)
+		source = net.newChain(genesisBytes)
+		sk, err := bls.NewSecretKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		offline = &validators.GetValidatorOutput{
+			NodeID:    ids.GenerateTestNodeID(),
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    100,
+		}
+	})
+
+	// export exports the native asset of [source] to [destination] and
+	// returns the height of the block that included it.
+	export := func(ctx context.Context) uint64 {
+		parser, err := source.tcli.Parser(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := source.cli.GenerateTransaction(
+			ctx,
+			parser,
+			nil,
+			[]chain.Action{&actions.ExportAsset{
+				To:          rsender2,
+				Asset:       ids.Empty,
+				Value:       100,
+				Reward:      10,
+				Destination: destination.chainID,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(ctx)).Should(gomega.BeNil())
+		results := expectBlk(source)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].WarpMessage).ShouldNot(gomega.BeNil())
+		_, height, _, err := source.cli.Accepted(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		return height
+	}
+
+	ginkgo.It("relays exports to the destination", func() {
+		ctx := context.Background()
+		setup(genesisBytes)
+		height := export(ctx)
+
+		// Relay export (starting from the block that included it)
+		c.StartHeight = height
+		stop := startRelayer(c)
+		gomega.Eventually(func() int {
+			return destination.vm.Mempool().Len(ctx)
+		}, requestTimeout, 100*time.Millisecond).Should(gomega.Equal(1))
+		results := expectBlkWithContext(destination)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].WarpError).Should(gomega.BeEmpty())
+		gomega.Eventually(func() *relayer.Checkpoint {
+			return readCheckpoint(c.CheckpointPath)
+		}, requestTimeout, 100*time.Millisecond).Should(gomega.Equal(&relayer.Checkpoint{Height: height}))
+		stop()
+
+		assetID := actions.ImportedAssetID(ids.Empty, source.chainID)
+		balance, err := destination.tcli.Balance(ctx, sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+		balance, err = destination.tcli.Balance(ctx, sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+
+		// Ensure the export is not relayed again after restart
+		stop = startRelayer(c)
+		gomega.Consistently(func() int {
+			return destination.vm.Mempool().Len(ctx)
+		}, 3*time.Second, 100*time.Millisecond).Should(gomega.Equal(0))
+		stop()
+	})
+
+	ginkgo.It("skips exports the destination rejects", func() {
+		ctx := context.Background()
+
+		// Deny all messages from source on destination
+		g := *gen
+		g.WarpPolicy = chain.WarpPolicy{
+			Default: &chain.WarpQuorum{Num: 4, Den: 5},
+			Deny:    []ids.ID{source.chainID},
+		}
+		destinationGenesis, err := json.Marshal(&g)
+		gomega.Ω(err).Should(gomega.BeNil())
+		setup(destinationGenesis)
+		height := export(ctx)
+
+		// Import fails with the reason the warp message was rejected
+		c.StartHeight = height
+		stop := startRelayer(c)
+		defer stop()
+		gomega.Eventually(func() int {
+			return destination.vm.Mempool().Len(ctx)
+		}, requestTimeout, 100*time.Millisecond).Should(gomega.Equal(1))
+		results := expectBlkWithContext(destination)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].WarpError)).Should(gomega.Equal(chain.ErrDisabledChainID.Error()))
+
+		// Relayer moves on from the rejected export
+		gomega.Eventually(func() *relayer.Checkpoint {
+			return readCheckpoint(c.CheckpointPath)
+		}, requestTimeout, 100*time.Millisecond).Should(gomega.Equal(&relayer.Checkpoint{Height: height}))
+		balance, err := destination.tcli.Balance(ctx, sender2, actions.ImportedAssetID(ids.Empty, source.chainID))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+	})
+
+	ginkgo.It("retries imports the destination doesn't verify", func() {
+		ctx := context.Background()
+
+		// The relayer only waits for half of the source stake weight, but the
+		// destination requires 4/5 of it (so each import fails verification and
+		// the relayer keeps retrying it)
+		setup(genesisBytes)
+		c.QuorumNum, c.QuorumDen = 1, 2
+		sourceOffline.Store(true)
+		height := export(ctx)
+
+		c.StartHeight = height
+		stop := startRelayer(c)
+		defer stop()
+		for i := 0; i < 3; i++ {
+			gomega.Eventually(func() int {
+				return destination.vm.Mempool().Len(ctx)
+			}, requestTimeout, 100*time.Millisecond).Should(gomega.Equal(1))
+			results := expectBlkWithContext(destination)(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+			gomega.Ω(string(results[0].WarpError)).Should(gomega.Equal(chain.ErrWarpNotVerified.Error()))
+			gomega.Ω(readCheckpoint(c.CheckpointPath)).Should(gomega.BeNil())
+		}
+	})
+})
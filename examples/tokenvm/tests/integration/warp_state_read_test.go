@@ -0,0 +1,255 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+var _ = ginkgo.Describe("[WarpStateRead]", func() {
+	var (
+		net = useNetwork()
+
+		// sources are the validators of the chain that is read (reads are
+		// requested from sources[0])
+		sources []instance
+		apps    []*networkAppSender
+	)
+
+	ginkgo.BeforeEach(func() {
+		sources, apps = net.newValidators(genesisBytes, 3)
+	})
+
+	// transfer sends [value] to [rsender2] and accepts the block that
+	// includes it on every source validator.
+	transfer := func(ctx context.Context, value uint64) {
+		parser, err := sources[0].tcli.Parser(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := sources[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Value: value,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(ctx)).Should(gomega.BeNil())
+		results := expectBlk(sources[0])(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		blk := sources[0].vm.LastAcceptedBlock()
+		for _, source := range sources[1:] {
+			sblk, err := source.vm.ParseBlock(ctx, blk.Bytes())
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(sblk.Verify(ctx)).Should(gomega.BeNil())
+			gomega.Ω(sblk.Accept(ctx)).Should(gomega.BeNil())
+		}
+	}
+
+	// read returns a read of the balance of [rsender2] at the last accepted
+	// height (after two transfers of 100_000 and 1 to it).
+	read := func(ctx context.Context, quorumNum uint64, quorumDen uint64) (*warp.Message, uint64, uint64, error) {
+		transfer(ctx, 100_000) // must be more than StateLockup
+		transfer(ctx, 1)
+		_, height, _, err := sources[0].cli.Accepted(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		return sources[0].cli.GetWarpStateRead(
+			ctx,
+			height,
+			[][]byte{storage.BalanceKey(rsender2, ids.Empty)},
+			quorumNum,
+			quorumDen,
+		)
+	}
+
+	ginkgo.It("attests to accepted state", func() {
+		ctx := context.Background()
+		transfer(ctx, 100_000) // must be more than StateLockup
+		transfer(ctx, 1)
+
+		// Roots are deferred, so the read reflects the first transfer only
+		_, height, _, err := sources[0].cli.Accepted(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(height).Should(gomega.Equal(uint64(2)))
+		balanceKey := storage.BalanceKey(rsender2, ids.Empty)
+		missingKey := storage.BalanceKey(rsender2, ids.GenerateTestID())
+		msg, weight, signatureWeight, err := sources[0].cli.GetWarpStateRead(
+			ctx,
+			height,
+			[][]byte{balanceKey, missingKey},
+			1,
+			1,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Every validator signed the read
+		gomega.Ω(weight).Should(gomega.Equal(uint64(300)))
+		gomega.Ω(signatureWeight).Should(gomega.Equal(weight))
+		signers, err := msg.Signature.NumSigners()
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(signers).Should(gomega.Equal(len(sources)))
+
+		// Any chain that tracks the source validators can verify the read
+		gomega.Ω(msg.SourceChainID).Should(gomega.Equal(sources[0].chainID))
+		gomega.Ω(msg.Signature.Verify(ctx, &msg.UnsignedMessage, networkID, net.state, 0, 1, 1)).Should(gomega.BeNil())
+		read, err := chain.UnmarshalWarpStateRead(msg.Payload)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(read.Height).Should(gomega.Equal(height))
+		gomega.Ω(read.Timestamp).Should(gomega.Equal(sources[0].vm.LastAcceptedBlock().Tmstmp))
+		gomega.Ω(read.Keys).Should(gomega.Equal([][]byte{balanceKey, missingKey}))
+		gomega.Ω(read.Values).Should(gomega.HaveLen(2))
+		gomega.Ω(binary.BigEndian.Uint64(read.Values[0])).Should(gomega.Equal(uint64(100_000)))
+		gomega.Ω(read.Values[1]).Should(gomega.BeNil())
+
+		// The root matches the one used for state proofs
+		proof, err := sources[0].cli.GetStateProof(ctx, height, [][]byte{balanceKey})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(read.Root).Should(gomega.Equal(proof.StateRoot))
+	})
+
+	ginkgo.It("ignores validators that don't respond", func() {
+		ctx := context.Background()
+		apps[0].offline = set.Of(sources[2].nodeID)
+
+		// Failed requests are not waited on until the timeout
+		start := time.Now()
+		_, weight, signatureWeight, err := read(ctx, 2, 3)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(time.Since(start)).Should(gomega.BeNumerically("<", 5*time.Second))
+		gomega.Ω(weight).Should(gomega.Equal(uint64(300)))
+		gomega.Ω(signatureWeight).Should(gomega.Equal(uint64(200)))
+
+		_, _, _, err = sources[0].cli.GetWarpStateRead(
+			ctx,
+			2,
+			[][]byte{storage.BalanceKey(rsender2, ids.Empty)},
+			1,
+			1,
+		)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(warp.ErrInsufficientWeight.Error())))
+	})
+
+	ginkgo.It("rejects reads of unaccepted heights", func() {
+		ctx := context.Background()
+		_, _, _, err := sources[0].cli.GetWarpStateRead(
+			ctx,
+			10,
+			[][]byte{storage.BalanceKey(rsender2, ids.Empty)},
+			0,
+			0,
+		)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+	})
+
+	ginkgo.It("checks balances on another chain", func() {
+		ctx := context.Background()
+		msg, _, _, err := read(ctx, 1, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		destination := net.newChain(genesisBytes)
+		parser, err := destination.tcli.Parser(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// check includes [msg] in a transaction on [destination] that checks
+		// that [rsender2] held at least [min] of the native asset
+		check := func(min uint64) *chain.Result {
+			submit, _, _, err := destination.cli.GenerateTransaction(
+				ctx,
+				parser,
+				msg,
+				[]chain.Action{&actions.CheckRemoteBalance{
+					Owner: rsender2,
+					Asset: ids.Empty,
+					Min:   min,
+				}},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(ctx)).Should(gomega.BeNil())
+			results := expectBlkWithContext(destination)(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].WarpError).Should(gomega.BeEmpty())
+			return results[0]
+		}
+
+		ginkgo.By("reject balances below the minimum", func() {
+			result := check(100_001)
+			gomega.Ω(result.Success).Should(gomega.BeFalse())
+			gomega.Ω(result.Error).Should(gomega.Equal(actions.OutputInsufficientBalance))
+		})
+
+		ginkgo.By("accept balances at the minimum", func() {
+			gomega.Ω(check(100_000).Success).Should(gomega.BeTrue())
+		})
+
+		ginkgo.By("reject imports of reads", func() {
+			_, err := chain.NewTx(
+				&chain.Base{
+					Timestamp: time.Now().Add(time.Minute).Truncate(time.Second).UnixMilli(),
+					ChainID:   destination.chainID,
+					MaxFee:    consts.MaxUint64,
+				},
+				msg,
+				[]chain.Action{&actions.ImportAsset{}},
+			).Sign(factory, tconsts.ActionRegistry, tconsts.AuthRegistry)
+			gomega.Ω(err).Should(gomega.MatchError(actions.ErrWarpStateRead))
+		})
+	})
+
+	ginkgo.It("rejects stale reads", func() {
+		ctx := context.Background()
+		msg, _, _, err := read(ctx, 1, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		sread, err := chain.UnmarshalWarpStateRead(msg.Payload)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// The destination only accepts reads from its validity window
+		dgen := *gen
+		dgen.ValidityWindow = 2 * consts.MillisecondsPerSecond
+		dgenesisBytes, err := json.Marshal(&dgen)
+		gomega.Ω(err).Should(gomega.BeNil())
+		destination := net.newChain(dgenesisBytes)
+		time.Sleep(time.Until(time.UnixMilli(sread.Timestamp + dgen.ValidityWindow + consts.MillisecondsPerSecond)))
+
+		parser, err := destination.tcli.Parser(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := destination.cli.GenerateTransaction(
+			ctx,
+			parser,
+			msg,
+			[]chain.Action{&actions.CheckRemoteBalance{
+				Owner: rsender2,
+				Asset: ids.Empty,
+				Min:   100_000,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(ctx)).Should(gomega.BeNil())
+		results := expectBlkWithContext(destination)(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].WarpError).Should(gomega.BeEmpty())
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(results[0].Error).Should(gomega.Equal(actions.OutputStaleRead))
+	})
+})
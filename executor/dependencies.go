@@ -6,4 +6,5 @@ package executor
 type Metrics interface {
 	RecordBlocked()
 	RecordExecutable()
+	RecordAborted() // speculative execution was invalidated
 }
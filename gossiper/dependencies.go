@@ -35,4 +35,9 @@ type VM interface {
 	RecordTxsGossiped(int)
 	RecordSeenTxsReceived(int)
 	RecordTxsReceived(int)
+	RecordInvalidTxsReceived(int)
+	RecordExpiredTxsReceived(int)
+	RecordRateLimitedTxsReceived(int)
+	RecordIgnoredGossip()
+	RecordPeersIgnored()
 }
@@ -5,12 +5,14 @@ package gossiper
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
 	"go.uber.org/zap"
 )
 
@@ -20,12 +22,18 @@ type Manual struct {
 	vm         VM
 	appSender  common.AppSender
 	doneGossip chan struct{}
+
+	// peers is thread-safe
+	peers *peers
 }
 
-func NewManual(vm VM) *Manual {
+// NewManual returns a [Manual] gossiper. Only the per-peer limits of [cfg] are
+// used.
+func NewManual(vm VM, cfg *ProposerConfig) *Manual {
 	return &Manual{
 		vm:         vm,
 		doneGossip: make(chan struct{}),
+		peers:      newPeers(cfg),
 	}
 }
 
@@ -84,22 +92,45 @@ func (g *Manual) Force(ctx context.Context) error {
 }
 
 func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	now := time.Now().UnixMilli()
+	if !g.peers.Allow(nodeID, now) {
+		g.vm.RecordIgnoredGossip()
+		return nil
+	}
 	actionRegistry, authRegistry := g.vm.Registry()
-	_, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	_, txs, err := chain.UnmarshalTxs(msg, initialCapacity, now, actionRegistry, authRegistry)
 	if err != nil {
 		g.vm.Logger().Warn(
 			"AppGossip provided invalid txs",
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		recordGossip(g.vm, g.peers, nodeID, 1, 0, 1, 0, now)
+		return nil
+	}
+	if !g.peers.Take(nodeID, len(txs), now) {
+		g.vm.Logger().Debug(
+			"AppGossip exceeded rate limit",
+			zap.Stringer("peerID", nodeID),
+			zap.Int("txs", len(txs)),
+		)
+		g.vm.RecordRateLimitedTxsReceived(len(txs))
 		return nil
 	}
 	g.vm.RecordTxsReceived(len(txs))
 
 	start := time.Now()
+	var duplicate, invalid, expired int
 	for _, err := range g.vm.Submit(ctx, true, txs) {
-		if err == nil {
+		switch {
+		case err == nil:
 			continue
+		case errors.Is(err, chain.ErrDuplicateTx):
+			duplicate++
+		case errors.Is(err, chain.ErrTimestampTooLate):
+			expired++
+		case errors.Is(err, crypto.ErrInvalidSignature):
+			invalid++
 		}
 		g.vm.Logger().Warn(
 			"AppGossip failed to submit txs",
@@ -113,11 +144,16 @@ func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []b
 		zap.Stringer("nodeID", nodeID),
 		zap.Duration("t", time.Since(start)),
 	)
+	recordGossip(g.vm, g.peers, nodeID, len(txs), duplicate, invalid, expired, now)
 	return nil
 }
 
 func (*Manual) BlockVerified(int64) {}
 
+func (g *Manual) PeerScores() []*PeerScore {
+	return g.peers.Scores()
+}
+
 func (g *Manual) Done() {
 	<-g.doneGossip
 }
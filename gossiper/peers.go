@@ -0,0 +1,234 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// PeerScore is the accounting of all tx gossip received from a single peer.
+type PeerScore struct {
+	NodeID ids.NodeID `json:"nodeID"`
+
+	// Received is the number of txs received from the peer (excluding those
+	// dropped by rate limiting or while ignored).
+	Received uint64 `json:"received"`
+	// Duplicate is the number of received txs that we had already seen.
+	Duplicate uint64 `json:"duplicate"`
+	// Invalid is the number of received txs that could not be parsed or
+	// failed signature verification.
+	Invalid uint64 `json:"invalid"`
+	// Expired is the number of received txs that had already expired.
+	Expired uint64 `json:"expired"`
+	// RateLimited is the number of txs dropped because the peer exceeded its
+	// rate limit.
+	RateLimited uint64 `json:"rateLimited"`
+	// Ignored is the number of messages dropped while the peer was ignored.
+	Ignored uint64 `json:"ignored"`
+
+	// Faults is the number of messages received from the peer since its last
+	// fault-free period in which too many txs were invalid or expired.
+	Faults int `json:"faults"`
+	// IgnoredUntil is the time (in ms) until which all gossip from the peer is
+	// dropped (0 if the peer was never ignored).
+	IgnoredUntil int64 `json:"ignoredUntil"`
+}
+
+type peer struct {
+	score PeerScore
+
+	tokens     float64
+	lastRefill int64 // ms
+	lastFault  int64 // ms
+	lastSeen   int64 // ms
+}
+
+// peers rate limits and scores tx gossip from each peer.
+//
+// Each peer can gossip at most [rate] txs per second (with bursts up to
+// [burst] txs). A message is a fault if at least [faultPercent] of its txs are
+// invalid or expired (a few txs may expire in flight because of clock skew or
+// network delay). If a peer sends more than [maxFaults] faulty messages
+// (without a gap of [ignoreDuration] between faults), all of its gossip is
+// dropped for [ignoreDuration].
+//
+// At most [maxPeers] peers are tracked. When a new peer is seen, the least
+// recently seen peer that is not ignored is evicted (so a peer can't clear its
+// ignore deadline by getting evicted). If all peers are ignored, the peer
+// that is ignored for the shortest time is evicted instead.
+type peers struct {
+	rate           int
+	burst          int
+	faultPercent   int
+	maxFaults      int
+	ignoreDuration int64 // ms
+	maxPeers       int
+
+	l     sync.Mutex
+	peers map[ids.NodeID]*peer
+}
+
+func newPeers(cfg *ProposerConfig) *peers {
+	return &peers{
+		rate:           cfg.GossipPeerRate,
+		burst:          cfg.GossipPeerBurst,
+		faultPercent:   cfg.GossipPeerFaultPercent,
+		maxFaults:      cfg.GossipPeerMaxFaults,
+		ignoreDuration: cfg.GossipPeerIgnoreDuration,
+		maxPeers:       cfg.GossipMaxPeers,
+		peers:          map[ids.NodeID]*peer{},
+	}
+}
+
+func (p *peers) get(nodeID ids.NodeID, now int64) *peer {
+	pr, ok := p.peers[nodeID]
+	if !ok {
+		if len(p.peers) >= p.maxPeers {
+			p.evict(now)
+		}
+		pr = &peer{
+			score:      PeerScore{NodeID: nodeID},
+			tokens:     float64(p.burst),
+			lastRefill: now,
+		}
+		p.peers[nodeID] = pr
+	}
+	pr.lastSeen = now
+	return pr
+}
+
+// evict removes the least recently seen peer that is not ignored at [now] (or
+// the peer whose ignore deadline is the earliest, if all peers are ignored).
+//
+// Gossip is only received from connected peers, so the scan is rarely
+// performed once [maxPeers] is reached.
+func (p *peers) evict(now int64) {
+	var (
+		oldest   ids.NodeID
+		lastSeen int64
+		found    bool
+
+		soonest      ids.NodeID
+		ignoredUntil int64
+	)
+	for nodeID, pr := range p.peers {
+		if now < pr.score.IgnoredUntil {
+			if soonest == ids.EmptyNodeID || pr.score.IgnoredUntil < ignoredUntil {
+				soonest, ignoredUntil = nodeID, pr.score.IgnoredUntil
+			}
+			continue
+		}
+		if !found || pr.lastSeen < lastSeen {
+			oldest, lastSeen, found = nodeID, pr.lastSeen, true
+		}
+	}
+	if !found {
+		oldest = soonest
+	}
+	delete(p.peers, oldest)
+}
+
+// Allow returns false (and records that the message was dropped) if gossip
+// from [nodeID] is currently ignored.
+func (p *peers) Allow(nodeID ids.NodeID, now int64) bool {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	pr := p.get(nodeID, now)
+	if now < pr.score.IgnoredUntil {
+		pr.score.Ignored++
+		return false
+	}
+	return true
+}
+
+// Take returns true if [nodeID] can gossip [txs] txs now. If not, the txs are
+// recorded as rate limited.
+func (p *peers) Take(nodeID ids.NodeID, txs int, now int64) bool {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	pr := p.get(nodeID, now)
+	if elapsed := now - pr.lastRefill; elapsed > 0 {
+		pr.tokens += float64(elapsed) * float64(p.rate) / 1_000
+		if pr.tokens > float64(p.burst) {
+			pr.tokens = float64(p.burst)
+		}
+		pr.lastRefill = now
+	}
+	if pr.tokens < float64(txs) {
+		pr.score.RateLimited += uint64(txs)
+		return false
+	}
+	pr.tokens -= float64(txs)
+	pr.score.Received += uint64(txs)
+	return true
+}
+
+// Record adds the outcome of a message of [txs] txs received from [nodeID] to
+// its score. If enough of the txs were invalid or expired, the message is
+// counted as a fault and true is returned if [nodeID] is now ignored.
+func (p *peers) Record(nodeID ids.NodeID, txs int, duplicate int, invalid int, expired int, now int64) bool {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	pr := p.get(nodeID, now)
+	pr.score.Duplicate += uint64(duplicate)
+	pr.score.Invalid += uint64(invalid)
+	pr.score.Expired += uint64(expired)
+	bad := invalid + expired
+	if bad == 0 || bad*100 < p.faultPercent*txs {
+		return false
+	}
+
+	// Forgive faults if the peer has behaved for a while
+	if now-pr.lastFault >= p.ignoreDuration {
+		pr.score.Faults = 0
+	}
+	pr.lastFault = now
+	pr.score.Faults++
+	if pr.score.Faults <= p.maxFaults {
+		return false
+	}
+	pr.score.Faults = 0
+	pr.score.IgnoredUntil = now + p.ignoreDuration
+	return true
+}
+
+// Scores returns a copy of the scores of all peers we've received gossip from.
+func (p *peers) Scores() []*PeerScore {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	scores := make([]*PeerScore, 0, len(p.peers))
+	for _, pr := range p.peers {
+		score := pr.score
+		scores = append(scores, &score)
+	}
+	slices.SortFunc(scores, func(a, b *PeerScore) int {
+		return bytes.Compare(a.NodeID[:], b.NodeID[:])
+	})
+	return scores
+}
+
+// recordGossip records the outcome of a message of [txs] txs received from
+// [nodeID] in [p] and the metrics of [vm].
+func recordGossip(vm VM, p *peers, nodeID ids.NodeID, txs int, duplicate int, invalid int, expired int, now int64) {
+	vm.RecordInvalidTxsReceived(invalid)
+	vm.RecordExpiredTxsReceived(expired)
+	if !p.Record(nodeID, txs, duplicate, invalid, expired, now) {
+		return
+	}
+	vm.RecordPeersIgnored()
+	vm.Logger().Warn(
+		"ignoring gossip from peer",
+		zap.Stringer("peerID", nodeID),
+		zap.Int64("duration", p.ignoreDuration),
+	)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeers() *peers {
+	return newPeers(&ProposerConfig{
+		GossipPeerRate:           10,
+		GossipPeerBurst:          20,
+		GossipPeerFaultPercent:   50,
+		GossipPeerMaxFaults:      1,
+		GossipPeerIgnoreDuration: 1_000,
+		GossipMaxPeers:           2,
+	})
+}
+
+func TestPeersRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	p := newTestPeers()
+	nodeID := ids.GenerateTestNodeID()
+
+	// Burst is available immediately
+	require.True(p.Take(nodeID, 15, 0))
+	require.False(p.Take(nodeID, 10, 0))
+
+	// Tokens refill at [rate]
+	require.True(p.Take(nodeID, 10, 500))
+	require.False(p.Take(nodeID, 1, 500))
+
+	// Tokens never exceed [burst]
+	require.False(p.Take(nodeID, 21, 10_000))
+	require.True(p.Take(nodeID, 20, 10_000))
+
+	scores := p.Scores()
+	require.Len(scores, 1)
+	require.Equal(nodeID, scores[0].NodeID)
+	require.Equal(uint64(45), scores[0].Received)
+	require.Equal(uint64(32), scores[0].RateLimited)
+}
+
+func TestPeersIgnore(t *testing.T) {
+	require := require.New(t)
+
+	p := newTestPeers()
+	nodeID := ids.GenerateTestNodeID()
+	other := ids.GenerateTestNodeID()
+
+	// Duplicates are not faults
+	require.False(p.Record(nodeID, 5, 5, 0, 0, 0))
+
+	// Messages with few invalid or expired txs are not faults
+	require.False(p.Record(nodeID, 3, 0, 1, 0, 0))
+	require.False(p.Record(nodeID, 3, 0, 0, 1, 0))
+
+	// Faults are forgiven after [ignoreDuration]
+	require.False(p.Record(nodeID, 1, 0, 1, 0, 0))
+	require.False(p.Record(nodeID, 2, 0, 0, 1, 1_000))
+
+	// Exceeding [maxFaults] ignores the peer for [ignoreDuration]
+	require.True(p.Record(nodeID, 2, 0, 2, 0, 1_500))
+	require.False(p.Allow(nodeID, 1_500))
+	require.False(p.Allow(nodeID, 2_499))
+	require.True(p.Allow(nodeID, 2_500))
+	require.True(p.Allow(other, 1_500))
+
+	scores := p.Scores()
+	require.Len(scores, 2)
+	var score *PeerScore
+	for _, s := range scores {
+		if s.NodeID == nodeID {
+			score = s
+		}
+	}
+	require.Equal(&PeerScore{
+		NodeID:       nodeID,
+		Duplicate:    5,
+		Invalid:      4,
+		Expired:      2,
+		Ignored:      2,
+		IgnoredUntil: 2_500,
+	}, score)
+}
+
+func TestPeersEvict(t *testing.T) {
+	require := require.New(t)
+
+	p := newTestPeers()
+	a, b, c := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.True(p.Take(a, 20, 0))
+	require.True(p.Take(b, 20, 1))
+	require.True(p.Allow(a, 2))
+
+	// The least recently seen peer is evicted once [maxPeers] are tracked
+	require.True(p.Take(c, 20, 3))
+	scores := p.Scores()
+	require.Len(scores, 2)
+	for _, score := range scores {
+		require.NotEqual(b, score.NodeID)
+	}
+
+	// Evicted peers are tracked again from scratch
+	require.True(p.Take(b, 20, 4))
+
+	// Ignored peers are not evicted while they are ignored
+	require.False(p.Record(b, 1, 0, 1, 0, 5))
+	require.True(p.Record(b, 1, 0, 1, 0, 5))
+	require.True(p.Allow(c, 6))
+	require.True(p.Allow(a, 7))
+	require.False(p.Allow(b, 8))
+	scores = p.Scores()
+	require.Len(scores, 2)
+	for _, score := range scores {
+		require.NotEqual(c, score.NodeID)
+	}
+
+	// If all peers are ignored, the peer ignored for the shortest time is
+	// evicted
+	require.False(p.Record(a, 1, 0, 1, 0, 9))
+	require.True(p.Record(a, 1, 0, 1, 0, 9))
+	require.True(p.Allow(c, 10))
+	scores = p.Scores()
+	require.Len(scores, 2)
+	for _, score := range scores {
+		require.NotEqual(b, score.NodeID)
+	}
+}
@@ -41,6 +41,9 @@ type Proposer struct {
 
 	// cache is thread-safe
 	cache *cache.FIFO[ids.ID, any]
+
+	// peers is thread-safe
+	peers *peers
 }
 
 type ProposerConfig struct {
@@ -52,6 +55,14 @@ type ProposerConfig struct {
 	NoGossipBuilderDiff int
 	VerifyTimeout       int64 // ms
 	SeenCacheSize       int
+
+	// Per-peer limits on incoming gossip
+	GossipPeerRate           int   // txs/s
+	GossipPeerBurst          int   // txs
+	GossipPeerFaultPercent   int   // % of a message's txs that are invalid or expired
+	GossipPeerMaxFaults      int   // messages
+	GossipPeerIgnoreDuration int64 // ms
+	GossipMaxPeers           int   // peers
 }
 
 func DefaultProposerConfig() *ProposerConfig {
@@ -64,6 +75,13 @@ func DefaultProposerConfig() *ProposerConfig {
 		NoGossipBuilderDiff: 4,
 		VerifyTimeout:       proposer.MaxVerifyDelay.Milliseconds(),
 		SeenCacheSize:       2_500_000,
+
+		GossipPeerRate:           10_000,
+		GossipPeerBurst:          25_000, // must exceed the txs in a single message
+		GossipPeerFaultPercent:   25,
+		GossipPeerMaxFaults:      16,
+		GossipPeerIgnoreDuration: 60 * 1000,
+		GossipMaxPeers:           1_024,
 	}
 }
 
@@ -77,6 +95,8 @@ func NewProposer(vm VM, cfg *ProposerConfig) (*Proposer, error) {
 
 		q:         make(chan struct{}),
 		lastQueue: -1,
+
+		peers: newPeers(cfg),
 	}
 	g.timer = timer.NewTimer(g.handleTimerNotify)
 	cache, err := cache.NewFIFO[ids.ID, any](cfg.SeenCacheSize)
@@ -160,14 +180,29 @@ func (g *Proposer) Force(ctx context.Context) error {
 }
 
 func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	now := time.Now().UnixMilli()
+	if !g.peers.Allow(nodeID, now) {
+		g.vm.RecordIgnoredGossip()
+		return nil
+	}
 	actionRegistry, authRegistry := g.vm.Registry()
-	authCounts, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	authCounts, txs, err := chain.UnmarshalTxs(msg, initialCapacity, now, actionRegistry, authRegistry)
 	if err != nil {
 		g.vm.Logger().Warn(
 			"received invalid txs",
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		recordGossip(g.vm, g.peers, nodeID, 1, 0, 1, 0, now)
+		return nil
+	}
+	if !g.peers.Take(nodeID, len(txs), now) {
+		g.vm.Logger().Debug(
+			"dropping rate limited gossip",
+			zap.Stringer("peerID", nodeID),
+			zap.Int("txs", len(txs)),
+		)
+		g.vm.RecordRateLimitedTxsReceived(len(txs))
 		return nil
 	}
 	g.vm.RecordTxsReceived(len(txs))
@@ -219,6 +254,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		recordGossip(g.vm, g.peers, nodeID, len(txs), seen, len(txs), 0, now)
 		return nil
 	}
 
@@ -234,10 +270,14 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 
 	// Submit incoming gossip to mempool
 	start := time.Now()
+	var expired int
 	for _, err := range g.vm.Submit(ctx, false, txs) {
 		if err == nil || errors.Is(err, chain.ErrDuplicateTx) {
 			continue
 		}
+		if errors.Is(err, chain.ErrTimestampTooLate) {
+			expired++
+		}
 		g.vm.Logger().Debug(
 			"failed to submit gossiped txs",
 			zap.Stringer("nodeID", nodeID),
@@ -253,6 +293,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 		zap.Bool("validator", isValidator),
 		zap.Duration("t", time.Since(start)),
 	)
+	recordGossip(g.vm, g.peers, nodeID, len(txs), seen, 0, expired, now)
 
 	// only trace error to prevent VM's being shutdown
 	// from "AppGossip" returning an error
@@ -339,6 +380,10 @@ func (g *Proposer) BlockVerified(t int64) {
 	g.lastVerified = t
 }
 
+func (g *Proposer) PeerScores() []*PeerScore {
+	return g.peers.Scores()
+}
+
 func (g *Proposer) Done() {
 	g.timer.Stop()
 	<-g.doneGossip
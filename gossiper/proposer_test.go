@@ -0,0 +1,178 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+const (
+	testAction  uint8 = 0
+	testAuth    uint8 = 0
+	validExpiry int64 = 2_000
+	lateExpiry  int64 = 1_000 // rejected by [VM.Submit]
+)
+
+// newTestRegistries returns registries with a single [chain.Action] and a
+// single [chain.Auth] that is encoded as a byte (1 if its signature is valid).
+func newTestRegistries(t *testing.T, ctrl *gomock.Controller) (chain.ActionRegistry, chain.AuthRegistry) {
+	require := require.New(t)
+
+	action := chain.NewMockAction(ctrl)
+	action.EXPECT().GetTypeID().Return(testAction).AnyTimes()
+	action.EXPECT().OutputsWarpMessage().Return(false).AnyTimes()
+	action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(
+		testAction,
+		func(*codec.Packer, *warp.Message) (chain.Action, error) { return action, nil },
+		false,
+	))
+
+	newAuth := func(valid bool) chain.Auth {
+		auth := chain.NewMockAuth(ctrl)
+		auth.EXPECT().GetTypeID().Return(testAuth).AnyTimes()
+		auth.EXPECT().Actor().Return(codec.Address{testAuth}).AnyTimes()
+		auth.EXPECT().Sponsor().Return(codec.Address{testAuth}).AnyTimes()
+		auth.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+		if valid {
+			auth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		} else {
+			auth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(crypto.ErrInvalidSignature).AnyTimes()
+		}
+		return auth
+	}
+	valid, invalid := newAuth(true), newAuth(false)
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(
+		testAuth,
+		func(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+			if p.UnpackBool() {
+				return valid, nil
+			}
+			return invalid, nil
+		},
+		false,
+	))
+	return actionRegistry, authRegistry
+}
+
+// testGossip encodes gossip messages of unique txs.
+type testGossip struct {
+	chainID ids.ID
+	txs     uint64
+}
+
+// message returns a message of [valid] txs and [late] expired txs. If [signed]
+// is false, all signatures are invalid.
+func (g *testGossip) message(valid int, late int, signed bool) []byte {
+	p := codec.NewWriter(0, consts.NetworkSizeLimit)
+	p.PackInt(valid + late)
+	for i := 0; i < valid+late; i++ {
+		expiry := validExpiry
+		if i >= valid {
+			expiry = lateExpiry
+		}
+		g.txs++
+		(&chain.Base{Timestamp: expiry, ChainID: g.chainID, MaxFee: g.txs}).Marshal(p)
+		p.PackBytes(nil) // warp message
+		p.PackByte(1)    // number of actions
+		p.PackByte(testAction)
+		p.PackByte(testAuth)
+		p.PackBool(signed)
+	}
+	return p.Bytes()
+}
+
+func TestProposerHandleAppGossip(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	actionRegistry, authRegistry := newTestRegistries(t, ctrl)
+	vm := NewMockVM(ctrl)
+	vm.EXPECT().Registry().Return(actionRegistry, authRegistry).AnyTimes()
+	vm.EXPECT().Logger().Return(logging.NoLog{}).AnyTimes()
+	vm.EXPECT().GetAuthBatchVerifier(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false).AnyTimes()
+	vm.EXPECT().IsValidator(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	vm.EXPECT().Submit(gomock.Any(), false, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ bool, txs []*chain.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i, tx := range txs {
+				if tx.Base.Timestamp == lateExpiry {
+					errs[i] = chain.ErrTimestampTooLate
+				}
+			}
+			return errs
+		},
+	).AnyTimes()
+	vm.EXPECT().RecordTxsReceived(gomock.Any()).AnyTimes()
+	vm.EXPECT().RecordSeenTxsReceived(gomock.Any()).AnyTimes()
+	vm.EXPECT().RecordInvalidTxsReceived(gomock.Any()).AnyTimes()
+	vm.EXPECT().RecordExpiredTxsReceived(gomock.Any()).AnyTimes()
+	vm.EXPECT().RecordRateLimitedTxsReceived(11).Times(1)
+	vm.EXPECT().RecordPeersIgnored().Times(1)
+	vm.EXPECT().RecordIgnoredGossip().Times(1)
+
+	cfg := DefaultProposerConfig()
+	cfg.GossipPeerRate = 1 // no refill while the test runs
+	cfg.GossipPeerBurst = 10
+	cfg.GossipPeerFaultPercent = 50
+	cfg.GossipPeerMaxFaults = 1
+	g, err := NewProposer(vm, cfg)
+	require.NoError(err)
+	gossip := &testGossip{chainID: ids.GenerateTestID()}
+	nodeID := ids.GenerateTestNodeID()
+	other := ids.GenerateTestNodeID()
+
+	// A few expired txs are not a fault
+	require.NoError(g.HandleAppGossip(ctx, nodeID, gossip.message(3, 1, true)))
+
+	// Too many expired txs or invalid signatures are
+	require.NoError(g.HandleAppGossip(ctx, nodeID, gossip.message(1, 1, true)))
+	require.NoError(g.HandleAppGossip(ctx, nodeID, gossip.message(2, 0, false)))
+
+	// Gossip from the ignored peer is dropped
+	require.NoError(g.HandleAppGossip(ctx, nodeID, gossip.message(1, 0, true)))
+
+	// Other peers are limited independently
+	require.NoError(g.HandleAppGossip(ctx, other, gossip.message(11, 0, true)))
+	require.NoError(g.HandleAppGossip(ctx, other, []byte{0xff}))
+
+	scores := g.PeerScores()
+	require.Len(scores, 2)
+	for _, score := range scores {
+		switch score.NodeID {
+		case nodeID:
+			require.Positive(score.IgnoredUntil)
+			score.IgnoredUntil = 0
+			require.Equal(&PeerScore{
+				NodeID:   nodeID,
+				Received: 8,
+				Invalid:  2,
+				Expired:  2,
+				Ignored:  1,
+			}, score)
+		case other:
+			require.Equal(&PeerScore{
+				NodeID:      other,
+				Invalid:     1,
+				RateLimited: 11,
+				Faults:      1,
+			}, score)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// Header is an accepted block tracked by [Client].
+type Header struct {
+	ID ids.ID
+	*chain.StatefulBlock
+}
+
+// StateProver serves proofs of state at accepted heights (like
+// [rpc.JSONRPCClient]).
+type StateProver interface {
+	GetStateProof(ctx context.Context, height uint64, keys [][]byte) (*rpc.GetStateProofReply, error)
+}
+
+// BlockStream streams accepted blocks (like [rpc.WebSocketClient]).
+type BlockStream interface {
+	RegisterBlocks() error
+	ListenBlock(ctx context.Context, parser chain.Parser) (*chain.StatefulBlock, []*chain.Result, chain.Dimensions, error)
+}
+
+// Client follows the headers of accepted blocks (without executing any
+// transactions) starting from a trusted checkpoint and answers state reads
+// verified against these headers.
+//
+// Headers are not signed by validators, so [Client] can't tell whether a
+// header that extends the last one was actually accepted by the chain. The
+// checkpoint and the blocks passed to [Accept] (or streamed to [Follow]) must
+// come from a trusted source (like a node run by the user). Each header is
+// only accepted if it extends the last header (by height and parent ID) and
+// its [EventsRoot] matches the results it was delivered with, which guards
+// against a faulty (but not a malicious) source.
+//
+// State proofs are verified against the tracked headers, so they can be
+// served by any (untrusted) node. Because roots are deferred, state can only
+// be read as of a block once its child is tracked.
+type Client struct {
+	prover       StateProver
+	parser       chain.Parser
+	branchFactor merkledb.BranchFactor
+	window       int
+
+	l       sync.RWMutex
+	headers map[uint64]*Header
+	last    *Header
+}
+
+// New creates a [Client] that trusts [checkpoint] and keeps the last
+// [window] headers it accepts (older state can't be read). [window] must be
+// at least 2 (reading state requires the child of the block that produced it).
+//
+// State reads are verified against proofs served by [prover].
+// [branchFactor] must match the branch factor of the chain's state.
+func New(
+	prover StateProver,
+	parser chain.Parser,
+	branchFactor merkledb.BranchFactor,
+	checkpoint *chain.StatefulBlock,
+	window int,
+) (*Client, error) {
+	if window < 2 {
+		return nil, ErrInvalidWindow
+	}
+	id, err := checkpoint.ID()
+	if err != nil {
+		return nil, err
+	}
+	h := &Header{id, checkpoint}
+	return &Client{
+		prover:       prover,
+		parser:       parser,
+		branchFactor: branchFactor,
+		window:       window,
+		headers:      map[uint64]*Header{checkpoint.Hght: h},
+		last:         h,
+	}, nil
+}
+
+// Accept verifies that [blk] extends the last accepted header and that
+// [results] are those committed to by [blk] before tracking it.
+func (c *Client) Accept(blk *chain.StatefulBlock, results []*chain.Result) error {
+	id, err := blk.ID()
+	if err != nil {
+		return err
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	if blk.Hght != c.last.Hght+1 {
+		return fmt.Errorf("%w: expected %d but got %d", ErrInvalidHeight, c.last.Hght+1, blk.Hght)
+	}
+	if blk.Prnt != c.last.ID {
+		return fmt.Errorf("%w: expected %s but got %s", ErrInvalidParent, c.last.ID, blk.Prnt)
+	}
+	if blk.Tmstmp < c.last.Tmstmp {
+		return fmt.Errorf("%w: %d is before parent %d", ErrInvalidTimestamp, blk.Tmstmp, c.last.Tmstmp)
+	}
+	if len(results) != len(blk.Txs) {
+		return fmt.Errorf("%w: expected %d but got %d", ErrInvalidResults, len(blk.Txs), len(results))
+	}
+	eventsRoot, err := chain.EventsRoot(results)
+	if err != nil {
+		return err
+	}
+	if eventsRoot != blk.EventsRoot {
+		return fmt.Errorf("%w: events root mismatch", ErrInvalidResults)
+	}
+
+	h := &Header{id, blk}
+	c.headers[blk.Hght] = h
+	c.last = h
+	if blk.Hght >= uint64(c.window) {
+		delete(c.headers, blk.Hght-uint64(c.window))
+	}
+	return nil
+}
+
+// Follow accepts blocks streamed by [blocks] until [ctx] is done or an
+// invalid block is received. [blocks] must be trusted (see [Client]).
+//
+// Blocks at or below the last accepted header are skipped (if they match the
+// tracked header). If any blocks are missing from the stream, [ErrMissingBlocks]
+// is returned.
+func (c *Client) Follow(ctx context.Context, blocks BlockStream) error {
+	if err := blocks.RegisterBlocks(); err != nil {
+		return err
+	}
+	for {
+		blk, results, _, err := blocks.ListenBlock(ctx, c.parser)
+		if err != nil {
+			return err
+		}
+		last := c.Latest()
+		if blk.Hght <= last.Hght {
+			h, ok := c.Header(blk.Hght)
+			if !ok {
+				continue
+			}
+			id, err := blk.ID()
+			if err != nil {
+				return err
+			}
+			if id != h.ID {
+				return fmt.Errorf("%w: %s at height %d", ErrConflictingBlock, id, blk.Hght)
+			}
+			continue
+		}
+		if blk.Hght > last.Hght+1 {
+			return fmt.Errorf("%w: %d-%d", ErrMissingBlocks, last.Hght+1, blk.Hght-1)
+		}
+		if err := c.Accept(blk, results); err != nil {
+			return err
+		}
+	}
+}
+
+// Latest returns the last accepted header.
+func (c *Client) Latest() *Header {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	return c.last
+}
+
+// Header returns the accepted header at [height] (if it is tracked).
+func (c *Client) Header(height uint64) (*Header, bool) {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	h, ok := c.headers[height]
+	return h, ok
+}
+
+// GetState returns the verified values of [keys] in the most recent state
+// committed to by an accepted header and the height of the block that
+// produced it.
+func (c *Client) GetState(ctx context.Context, keys [][]byte) (uint64, []maybe.Maybe[[]byte], error) {
+	last := c.Latest()
+	if last.Hght == 0 {
+		return 0, nil, fmt.Errorf("%w: no state committed after genesis", ErrUnknownHeader)
+	}
+	values, err := c.getState(ctx, last, keys)
+	return last.Hght - 1, values, err
+}
+
+// GetStateAt returns the verified values of [keys] in the post-execution
+// state of the block at [height]. This requires the child of [height]
+// to be tracked.
+func (c *Client) GetStateAt(ctx context.Context, height uint64, keys [][]byte) ([]maybe.Maybe[[]byte], error) {
+	h, ok := c.Header(height + 1)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownHeader, height+1)
+	}
+	return c.getState(ctx, h, keys)
+}
+
+func (c *Client) getState(ctx context.Context, h *Header, keys [][]byte) ([]maybe.Maybe[[]byte], error) {
+	reply, err := c.prover.GetStateProof(ctx, h.Hght, keys)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.VerifyStateProof(ctx, h.StatefulBlock, c.branchFactor, keys, reply)
+}
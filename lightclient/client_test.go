@@ -0,0 +1,248 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+func child(parent *Header) *chain.StatefulBlock {
+	return &chain.StatefulBlock{
+		Prnt:      parent.ID,
+		Tmstmp:    parent.Tmstmp + 1,
+		Hght:      parent.Hght + 1,
+		StateRoot: ids.GenerateTestID(),
+	}
+}
+
+func TestAccept(t *testing.T) {
+	require := require.New(t)
+
+	c, err := New(nil, nil, merkledb.BranchFactor16, chain.NewGenesisBlock(ids.GenerateTestID()), 2)
+	require.NoError(err)
+	genesis := c.Latest()
+
+	// Extends the checkpoint
+	blk1 := child(genesis)
+	require.NoError(c.Accept(blk1, nil))
+	h1 := c.Latest()
+	require.Equal(uint64(1), h1.Hght)
+	id1, err := blk1.ID()
+	require.NoError(err)
+	require.Equal(id1, h1.ID)
+
+	// Must build on the last accepted header
+	require.ErrorIs(c.Accept(child(genesis), nil), ErrInvalidHeight)
+	wrongParent := child(h1)
+	wrongParent.Prnt = ids.GenerateTestID()
+	require.ErrorIs(c.Accept(wrongParent, nil), ErrInvalidParent)
+	wrongTime := child(h1)
+	wrongTime.Tmstmp = h1.Tmstmp - 1
+	require.ErrorIs(c.Accept(wrongTime, nil), ErrInvalidTimestamp)
+
+	// Results must match the block
+	wrongEvents := child(h1)
+	wrongEvents.EventsRoot = ids.GenerateTestID()
+	require.ErrorIs(c.Accept(wrongEvents, nil), ErrInvalidResults)
+	require.ErrorIs(c.Accept(child(h1), []*chain.Result{{Success: true}}), ErrInvalidResults)
+
+	// Old headers are pruned
+	require.NoError(c.Accept(child(h1), nil))
+	_, ok := c.Header(0)
+	require.False(ok)
+	_, ok = c.Header(1)
+	require.True(ok)
+	_, ok = c.Header(2)
+	require.True(ok)
+
+	_, err = New(nil, nil, merkledb.BranchFactor16, chain.NewGenesisBlock(ids.GenerateTestID()), 1)
+	require.ErrorIs(err, ErrInvalidWindow)
+}
+
+// testBlockStream streams [blocks] and then waits for its context to be done.
+type testBlockStream struct {
+	blocks []*chain.StatefulBlock
+}
+
+func (*testBlockStream) RegisterBlocks() error { return nil }
+
+func (s *testBlockStream) ListenBlock(ctx context.Context, _ chain.Parser) (*chain.StatefulBlock, []*chain.Result, chain.Dimensions, error) {
+	if len(s.blocks) == 0 {
+		<-ctx.Done()
+		return nil, nil, chain.Dimensions{}, ctx.Err()
+	}
+	blk := s.blocks[0]
+	s.blocks = s.blocks[1:]
+	return blk, nil, chain.Dimensions{}, nil
+}
+
+func TestFollow(t *testing.T) {
+	require := require.New(t)
+
+	c, err := New(nil, nil, merkledb.BranchFactor16, chain.NewGenesisBlock(ids.GenerateTestID()), 4)
+	require.NoError(err)
+	genesis := c.Latest()
+	blk1 := child(genesis)
+	id1, err := blk1.ID()
+	require.NoError(err)
+	blk2 := child(&Header{id1, blk1})
+
+	// Blocks that were already accepted are skipped
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := &testBlockStream{blocks: []*chain.StatefulBlock{genesis.StatefulBlock, blk1, blk1}}
+	require.ErrorIs(c.Follow(ctx, stream), context.Canceled)
+	require.Equal(id1, c.Latest().ID)
+
+	// A different block at an accepted height is rejected
+	conflicting := child(genesis)
+	stream = &testBlockStream{blocks: []*chain.StatefulBlock{conflicting}}
+	require.ErrorIs(c.Follow(context.Background(), stream), ErrConflictingBlock)
+
+	// Blocks can't be skipped
+	skipped := child(&Header{ids.GenerateTestID(), blk2})
+	stream = &testBlockStream{blocks: []*chain.StatefulBlock{skipped}}
+	require.ErrorIs(c.Follow(context.Background(), stream), ErrMissingBlocks)
+
+	// Blocks must build on the last accepted header
+	wrongParent := child(&Header{ids.GenerateTestID(), blk1})
+	stream = &testBlockStream{blocks: []*chain.StatefulBlock{wrongParent}}
+	require.ErrorIs(c.Follow(context.Background(), stream), ErrInvalidParent)
+	require.Equal(id1, c.Latest().ID)
+}
+
+// testProver serves proofs from [db] and lets tests tamper with them.
+type testProver struct {
+	db     merkledb.MerkleDB
+	height uint64
+	tamper func(*rpc.GetStateProofReply, []*merkledb.RangeProof) []*merkledb.RangeProof
+}
+
+func (p *testProver) GetStateProof(ctx context.Context, _ uint64, keys [][]byte) (*rpc.GetStateProofReply, error) {
+	root, err := p.db.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	proofs := make([]*merkledb.RangeProof, len(keys))
+	for i, k := range keys {
+		proofs[i], err = p.db.GetRangeProof(ctx, maybe.Some(k), maybe.Some(k), 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reply := &rpc.GetStateProofReply{Height: p.height, StateRoot: root}
+	if p.tamper != nil {
+		proofs = p.tamper(reply, proofs)
+	}
+	for _, proof := range proofs {
+		b, err := proto.Marshal(proof.ToProto())
+		if err != nil {
+			return nil, err
+		}
+		reply.Proofs = append(reply.Proofs, b)
+	}
+	return reply, nil
+}
+
+func newTestDB(t *testing.T, kvs map[string][]byte) merkledb.MerkleDB {
+	require := require.New(t)
+
+	ctx := context.Background()
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		RootGenConcurrency:          1,
+		HistoryLength:               100,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Tracer:                      trace.Noop,
+	})
+	require.NoError(err)
+	for k, v := range kvs {
+		require.NoError(db.Put([]byte(k), v))
+	}
+	return db
+}
+
+func TestGetStateAt(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	db := newTestDB(t, map[string][]byte{"a": {1}, "b": {2}})
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	prover := &testProver{db: db, height: 1}
+
+	// The child of genesis commits to the state of genesis
+	c, err := New(prover, nil, merkledb.BranchFactor16, chain.NewGenesisBlock(ids.GenerateTestID()), 2)
+	require.NoError(err)
+	_, err = c.GetStateAt(ctx, 0, [][]byte{[]byte("a")})
+	require.ErrorIs(err, ErrUnknownHeader)
+	blk1 := child(c.Latest())
+	blk1.StateRoot = root
+	require.NoError(c.Accept(blk1, nil))
+
+	keys := [][]byte{[]byte("a"), []byte("c")}
+	values, err := c.GetStateAt(ctx, 0, keys)
+	require.NoError(err)
+	require.Equal([]maybe.Maybe[[]byte]{maybe.Some([]byte{1}), maybe.Nothing[[]byte]()}, values)
+	height, values, err := c.GetState(ctx, keys)
+	require.NoError(err)
+	require.Equal(uint64(0), height)
+	require.Equal([]maybe.Maybe[[]byte]{maybe.Some([]byte{1}), maybe.Nothing[[]byte]()}, values)
+
+	// Proofs that don't match the tracked header are rejected
+	otherDB := newTestDB(t, map[string][]byte{"a": {3}, "b": {2}})
+	for name, tamper := range map[string]func(*rpc.GetStateProofReply, []*merkledb.RangeProof) []*merkledb.RangeProof{
+		"wrong height": func(reply *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			reply.Height = 2
+			return proofs
+		},
+		"wrong root": func(reply *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			reply.StateRoot = ids.GenerateTestID()
+			return proofs
+		},
+		"missing proof": func(_ *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			return proofs[:1]
+		},
+		"tampered value": func(_ *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			proofs[0].KeyValues[0].Value = []byte{3}
+			return proofs
+		},
+		"omitted value": func(_ *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			proofs[0].KeyValues = nil
+			return proofs
+		},
+		"proof of another key": func(_ *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			proofs[1] = proofs[0]
+			return proofs
+		},
+		"proof of another state": func(_ *rpc.GetStateProofReply, proofs []*merkledb.RangeProof) []*merkledb.RangeProof {
+			proof, err := otherDB.GetRangeProof(ctx, maybe.Some(keys[0]), maybe.Some(keys[0]), 1)
+			require.NoError(err)
+			proofs[0] = proof
+			return proofs
+		},
+	} {
+		t.Run(name, func(*testing.T) {
+			prover.tamper = tamper
+			_, err := c.GetStateAt(ctx, 0, keys)
+			require.ErrorIs(err, rpc.ErrInvalidProof)
+		})
+	}
+}
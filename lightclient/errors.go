@@ -0,0 +1,17 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import "errors"
+
+var (
+	ErrInvalidWindow    = errors.New("window must be at least 2")
+	ErrInvalidHeight    = errors.New("invalid height")
+	ErrInvalidParent    = errors.New("invalid parent")
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrInvalidResults   = errors.New("invalid results")
+	ErrConflictingBlock = errors.New("conflicting block")
+	ErrMissingBlocks    = errors.New("missing blocks")
+	ErrUnknownHeader    = errors.New("unknown header")
+)
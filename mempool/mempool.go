@@ -4,6 +4,7 @@
 package mempool
 
 import (
+	"bytes"
 	"context"
 	"sync"
 	"time"
@@ -14,8 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/eheap"
+	"github.com/ava-labs/hypersdk/heap"
 	"github.com/ava-labs/hypersdk/list"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/exp/slices"
 )
 
 const maxPrealloc = 4_096
@@ -27,6 +30,40 @@ type Item interface {
 	Size() int
 }
 
+// NoncedItem is an [Item] with a sequence number for its [Sponsor]. The
+// nonces of all [NoncedItem]s in a [Mempool] are tracked so that gaps can be
+// detected (see [Mempool.HasNonce]).
+type NoncedItem interface {
+	Item
+
+	Nonce() uint64
+}
+
+// SponsorUsage is the number of items in a [Mempool] owned by a single
+// [Sponsor].
+type SponsorUsage struct {
+	Sponsor codec.Address `json:"sponsor"`
+	Items   int           `json:"items"`
+	// Exempt sponsors are not limited by the maximum number of items a
+	// single sponsor can have in the [Mempool].
+	Exempt bool `json:"exempt"`
+}
+
+// Prioritizer orders the items in a [Mempool] by the fee they pay instead
+// of by arrival.
+type Prioritizer[T Item] interface {
+	// Priority is the effective fee per unit paid by [item]. Items with the
+	// highest priority are returned first and items with the lowest priority
+	// are evicted when the [Mempool] is full.
+	Priority(item T) uint64
+
+	// Keys are the state keys touched by [item] (excluding those touched by
+	// every item from its [Sponsor], like the keys used to pay fees). An item
+	// replaces any items from the same [Sponsor] that touch any of the same
+	// keys if it has a higher priority.
+	Keys(item T) set.Set[string]
+}
+
 type Mempool[T Item] struct {
 	tracer trace.Tracer
 
@@ -44,6 +81,14 @@ type Mempool[T Item] struct {
 	// [Sponsor]
 	owned map[codec.Address]int
 
+	// sponsored tracks the IDs of the items in the mempool owned by a single
+	// [Sponsor]
+	sponsored map[codec.Address]set.Set[ids.ID]
+
+	// nonces tracks the number of [NoncedItem]s in the mempool with each
+	// nonce for a single [Sponsor]
+	nonces map[codec.Address]map[uint64]int
+
 	// streamedItems have been removed from the mempool during streaming
 	// and should not be re-added by calls to [Add].
 	streamLock        sync.Mutex // should never be needed
@@ -53,6 +98,12 @@ type Mempool[T Item] struct {
 
 	// sponsors that are exempt from [maxSponsorSize]
 	exemptSponsors set.Set[codec.Address]
+
+	// Only populated if ordering by [Priority]
+	prioritizer Prioritizer[T]
+	maxHeap     *heap.Heap[*list.Element[T], uint64]
+	minHeap     *heap.Heap[*list.Element[T], uint64]
+	keys        map[ids.ID]set.Set[string]
 }
 
 // New creates a new [Mempool]. [maxSize] must be > 0 or else the
@@ -73,6 +124,8 @@ func New[T Item](
 		eh:    eheap.New[*list.Element[T]](math.Min(maxSize, maxPrealloc)),
 
 		owned:          map[codec.Address]int{},
+		sponsored:      map[codec.Address]set.Set[ids.ID]{},
+		nonces:         map[codec.Address]map[uint64]int{},
 		exemptSponsors: set.Set[codec.Address]{},
 	}
 	for _, sponsor := range exemptSponsors {
@@ -81,8 +134,57 @@ func New[T Item](
 	return m
 }
 
+// NewPrioritized creates a new [Mempool] that orders items by the
+// [Priority] assigned by [prioritizer]. When full, the lowest priority
+// item is evicted to make room for a higher priority item.
+func NewPrioritized[T Item](
+	tracer trace.Tracer,
+	maxSize int, // items
+	maxSponsorSize int,
+	exemptSponsors []codec.Address,
+	prioritizer Prioritizer[T],
+) *Mempool[T] {
+	m := New[T](tracer, maxSize, maxSponsorSize, exemptSponsors)
+	prealloc := math.Min(maxSize, maxPrealloc)
+	m.prioritizer = prioritizer
+	m.maxHeap = heap.New[*list.Element[T], uint64](prealloc, false)
+	m.minHeap = heap.New[*list.Element[T], uint64](prealloc, true)
+	m.keys = make(map[ids.ID]set.Set[string], prealloc)
+	return m
+}
+
+func (m *Mempool[T]) addToOwned(item T) {
+	sender := item.Sponsor()
+	m.owned[sender]++
+	sponsored, ok := m.sponsored[sender]
+	if !ok {
+		sponsored = set.Set[ids.ID]{}
+		m.sponsored[sender] = sponsored
+	}
+	sponsored.Add(item.ID())
+	nonced, ok := any(item).(NoncedItem)
+	if !ok {
+		return
+	}
+	nonces, ok := m.nonces[sender]
+	if !ok {
+		nonces = map[uint64]int{}
+		m.nonces[sender] = nonces
+	}
+	nonces[nonced.Nonce()]++
+}
+
 func (m *Mempool[T]) removeFromOwned(item T) {
 	sender := item.Sponsor()
+	if nonced, ok := any(item).(NoncedItem); ok {
+		m.removeNonce(sender, nonced.Nonce())
+	}
+	if sponsored, ok := m.sponsored[sender]; ok {
+		sponsored.Remove(item.ID())
+		if sponsored.Len() == 0 {
+			delete(m.sponsored, sender)
+		}
+	}
 	items, ok := m.owned[sender]
 	if !ok {
 		// May no longer be populated
@@ -95,6 +197,99 @@ func (m *Mempool[T]) removeFromOwned(item T) {
 	m.owned[sender] = items - 1
 }
 
+func (m *Mempool[T]) removeNonce(sender codec.Address, nonce uint64) {
+	nonces, ok := m.nonces[sender]
+	if !ok {
+		return
+	}
+	if nonces[nonce] <= 1 {
+		delete(nonces, nonce)
+	} else {
+		nonces[nonce]--
+	}
+	if len(nonces) == 0 {
+		delete(m.nonces, sender)
+	}
+}
+
+func (m *Mempool[T]) addPriority(elem *list.Element[T], priority uint64, keys set.Set[string]) {
+	item := elem.Value()
+	itemID := item.ID()
+	m.maxHeap.Push(&heap.Entry[*list.Element[T], uint64]{
+		ID:    itemID,
+		Val:   priority,
+		Item:  elem,
+		Index: m.maxHeap.Len(),
+	})
+	m.minHeap.Push(&heap.Entry[*list.Element[T], uint64]{
+		ID:    itemID,
+		Val:   priority,
+		Item:  elem,
+		Index: m.minHeap.Len(),
+	})
+	m.keys[itemID] = keys
+}
+
+func (m *Mempool[T]) removePriority(item T) {
+	if m.prioritizer == nil {
+		return
+	}
+	itemID := item.ID()
+	if entry, ok := m.maxHeap.Get(itemID); ok {
+		m.maxHeap.Remove(entry.Index)
+	}
+	if entry, ok := m.minHeap.Get(itemID); ok {
+		m.minHeap.Remove(entry.Index)
+	}
+	delete(m.keys, itemID)
+}
+
+// replaced returns the items from the [Sponsor] of [item] that touch any of
+// [keys] (and should be replaced by [item], which has [priority]). If any of
+// these items has a priority of at least [priority], replaced returns false.
+//
+// [NoncedItem]s only replace items with the same nonce.
+func (m *Mempool[T]) replaced(item T, priority uint64, keys set.Set[string]) ([]*list.Element[T], bool) {
+	nonced, hasNonce := any(item).(NoncedItem)
+	var elems []*list.Element[T]
+	for itemID := range m.sponsored[item.Sponsor()] {
+		if !overlaps(m.keys[itemID], keys) {
+			continue
+		}
+		entry, _ := m.maxHeap.Get(itemID)
+		if other, ok := any(entry.Item.Value()).(NoncedItem); hasNonce && ok && other.Nonce() != nonced.Nonce() {
+			continue
+		}
+		if entry.Val >= priority {
+			return nil, false
+		}
+		elems = append(elems, entry.Item)
+	}
+	return elems, true
+}
+
+func overlaps(a set.Set[string], b set.Set[string]) bool {
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+	for k := range a {
+		if b.Contains(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeElem removes [elem] (which must be in the mempool) from m.
+func (m *Mempool[T]) removeElem(elem *list.Element[T]) T {
+	v := m.queue.Remove(elem)
+	m.eh.Remove(v.ID())
+	m.removeFromOwned(v)
+	m.removePriority(v)
+	m.pendingSize -= v.Size()
+	return v
+}
+
 // Has returns if the eh of [m] contains [itemID]
 func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	_, span := m.tracer.Start(ctx, "Mempool.Has")
@@ -108,8 +303,12 @@ func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 
 // Add pushes all new items from [items] to m. Does not add a item if
 // the item sponsor is not exempt and their items in the mempool exceed m.maxSponsorSize.
-// If the size of m exceeds m.maxSize, Add pops the lowest value item
-// from m.eh.
+//
+// If m is ordered by [Priority], an item replaces any items from the same
+// sponsor that touch the same keys with a lower priority (and is dropped
+// if any of these items has an equal or higher priority). If m is full, the
+// lowest priority item is evicted if it has a lower priority than the new
+// item. Otherwise, items are not added to a full mempool.
 func (m *Mempool[T]) Add(ctx context.Context, items []T) {
 	_, span := m.tracer.Start(ctx, "Mempool.Add")
 	defer span.End()
@@ -134,15 +333,41 @@ func (m *Mempool[T]) add(items []T, front bool) {
 			continue
 		}
 
+		// Find any items this item replaces
+		var (
+			priority uint64
+			keys     set.Set[string]
+			replaced []*list.Element[T]
+		)
+		if m.prioritizer != nil {
+			priority = m.prioritizer.Priority(item)
+			keys = m.prioritizer.Keys(item)
+			var ok bool
+			replaced, ok = m.replaced(item, priority, keys)
+			if !ok {
+				continue // conflicts with an item that pays at least as much
+			}
+		}
+
 		// Ensure sender isn't abusing mempool
-		senderItems := m.owned[sender]
+		senderItems := m.owned[sender] - len(replaced)
 		if !m.exemptSponsors.Contains(sender) && senderItems == m.maxSponsorSize {
 			continue // do nothing, wait for items to expire
 		}
 
 		// Ensure mempool isn't full
-		if m.queue.Size() == m.maxSize {
-			continue // do nothing, wait for items to expire
+		if m.queue.Size()-len(replaced) == m.maxSize {
+			if m.prioritizer == nil {
+				continue // do nothing, wait for items to expire
+			}
+			cheapest := m.minHeap.First()
+			if cheapest.Val >= priority {
+				continue // do nothing, not worth more than anything in mempool
+			}
+			m.removeElem(cheapest.Item)
+		}
+		for _, elem := range replaced {
+			m.removeElem(elem)
 		}
 
 		// Add to mempool
@@ -153,9 +378,24 @@ func (m *Mempool[T]) add(items []T, front bool) {
 			elem = m.queue.PushFront(item)
 		}
 		m.eh.Add(elem)
-		m.owned[sender]++
+		m.addToOwned(item)
 		m.pendingSize += item.Size()
+		if m.prioritizer != nil {
+			m.addPriority(elem, priority, keys)
+		}
+	}
+}
+
+// first returns the highest valued item in m.
+func (m *Mempool[T]) first() *list.Element[T] {
+	if m.prioritizer == nil {
+		return m.queue.First()
 	}
+	entry := m.maxHeap.First()
+	if entry == nil {
+		return nil
+	}
+	return entry.Item
 }
 
 // PeekNext returns the highest valued item in m.eh.
@@ -167,7 +407,7 @@ func (m *Mempool[T]) PeekNext(ctx context.Context) (T, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	first := m.queue.First()
+	first := m.first()
 	if first == nil {
 		return *new(T), false
 	}
@@ -187,15 +427,11 @@ func (m *Mempool[T]) PopNext(ctx context.Context) (T, bool) { // O(log N)
 }
 
 func (m *Mempool[T]) popNext() (T, bool) {
-	first := m.queue.First()
+	first := m.first()
 	if first == nil {
 		return *new(T), false
 	}
-	v := m.queue.Remove(first)
-	m.eh.Remove(v.ID())
-	m.removeFromOwned(v)
-	m.pendingSize -= v.Size()
-	return v, true
+	return m.removeElem(first), true
 }
 
 // Remove removes [items] from m.
@@ -213,10 +449,130 @@ func (m *Mempool[T]) Remove(ctx context.Context, items []T) {
 		}
 		m.queue.Remove(elem)
 		m.removeFromOwned(item)
+		m.removePriority(item)
 		m.pendingSize -= item.Size()
 	}
 }
 
+// HasNonce returns true if there is a [NoncedItem] from [sponsor] with
+// [nonce] in m.
+func (m *Mempool[T]) HasNonce(ctx context.Context, sponsor codec.Address, nonce uint64) bool {
+	_, span := m.tracer.Start(ctx, "Mempool.HasNonce")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.nonces[sponsor][nonce] > 0
+}
+
+// Get returns the item with [itemID] in m (if it exists).
+func (m *Mempool[T]) Get(ctx context.Context, itemID ids.ID) (T, bool) {
+	_, span := m.tracer.Start(ctx, "Mempool.Get")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elem, ok := m.eh.Get(itemID)
+	if !ok {
+		return *new(T), false
+	}
+	return elem.Value(), true
+}
+
+// List returns up to [limit] items in m (in the order they were added),
+// starting with [cursor] (or the oldest item if [cursor] is empty). If
+// there are more items, List also returns the ID of the next item, which
+// should be provided as [cursor] to fetch the next page.
+//
+// If [cursor] is no longer in m, List returns false.
+func (m *Mempool[T]) List(ctx context.Context, cursor ids.ID, limit int) ([]T, ids.ID, bool) {
+	_, span := m.tracer.Start(ctx, "Mempool.List")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elem := m.queue.First()
+	if cursor != ids.Empty {
+		var ok bool
+		elem, ok = m.eh.Get(cursor)
+		if !ok {
+			return nil, ids.Empty, false
+		}
+	}
+	items := make([]T, 0, math.Min(limit, m.queue.Size()))
+	for ; elem != nil && len(items) < limit; elem = elem.Next() {
+		items = append(items, elem.Value())
+	}
+	if elem == nil {
+		return items, ids.Empty, true
+	}
+	return items, elem.ID(), true
+}
+
+// Sponsored returns up to [limit] items in m owned by [sponsor] (sorted by
+// expiry, then by ID).
+func (m *Mempool[T]) Sponsored(ctx context.Context, sponsor codec.Address, limit int) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Sponsored")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sponsored := m.sponsored[sponsor]
+	items := make([]T, 0, sponsored.Len())
+	for itemID := range sponsored {
+		elem, _ := m.eh.Get(itemID)
+		items = append(items, elem.Value())
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		switch {
+		case a.Expiry() < b.Expiry():
+			return -1
+		case a.Expiry() > b.Expiry():
+			return 1
+		}
+		aID, bID := a.ID(), b.ID()
+		return bytes.Compare(aID[:], bID[:])
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Sponsors returns the number of items owned by up to [limit] [Sponsor]s with
+// items in m (sorted by the number of items, descending) and the maximum
+// number of items a non-exempt [Sponsor] can have in m.
+func (m *Mempool[T]) Sponsors(ctx context.Context, limit int) ([]*SponsorUsage, int) {
+	_, span := m.tracer.Start(ctx, "Mempool.Sponsors")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	usage := make([]*SponsorUsage, 0, len(m.owned))
+	for sponsor, items := range m.owned {
+		usage = append(usage, &SponsorUsage{
+			Sponsor: sponsor,
+			Items:   items,
+			Exempt:  m.exemptSponsors.Contains(sponsor),
+		})
+	}
+	slices.SortFunc(usage, func(a, b *SponsorUsage) int {
+		if a.Items != b.Items {
+			return b.Items - a.Items
+		}
+		return bytes.Compare(a.Sponsor[:], b.Sponsor[:])
+	})
+	if len(usage) > limit {
+		usage = usage[:limit]
+	}
+	return usage, m.maxSponsorSize
+}
+
 // Len returns the number of items in m.
 func (m *Mempool[T]) Len(ctx context.Context) int {
 	_, span := m.tracer.Start(ctx, "Mempool.Len")
@@ -250,6 +606,7 @@ func (m *Mempool[T]) SetMinTimestamp(ctx context.Context, t int64) []T {
 		m.queue.Remove(remove)
 		v := remove.Value()
 		m.removeFromOwned(v)
+		m.removePriority(v)
 		m.pendingSize -= v.Size()
 		removed[i] = v
 	}
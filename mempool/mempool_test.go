@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/golang/mock/gomock"
@@ -191,3 +192,235 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	// Mempool has same length
 	require.Equal(5, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
+
+type testPrioritizer struct {
+	priorities map[ids.ID]uint64
+	keys       map[ids.ID]set.Set[string]
+}
+
+func newTestPrioritizer() *testPrioritizer {
+	return &testPrioritizer{
+		priorities: map[ids.ID]uint64{},
+		keys:       map[ids.ID]set.Set[string]{},
+	}
+}
+
+func (p *testPrioritizer) Priority(item *TestItem) uint64 {
+	return p.priorities[item.ID()]
+}
+
+func (p *testPrioritizer) Keys(item *TestItem) set.Set[string] {
+	return p.keys[item.ID()]
+}
+
+func (p *testPrioritizer) generate(sponsor codec.Address, priority uint64, keys ...string) *TestItem {
+	item := GenerateTestItem(sponsor, 100)
+	p.priorities[item.ID()] = priority
+	p.keys[item.ID()] = set.Of(keys...)
+	return item
+}
+
+func TestMempoolPriority(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	p := newTestPrioritizer()
+	txm := NewPrioritized[*TestItem](tracer, 10, 10, nil, p)
+
+	for _, priority := range []uint64{2, 5, 1, 4, 3} {
+		txm.Add(ctx, []*TestItem{p.generate(codec.CreateAddress(1, ids.GenerateTestID()), priority)})
+	}
+	require.Equal(5, txm.Len(ctx))
+	next, ok := txm.PeekNext(ctx)
+	require.True(ok)
+	require.Equal(uint64(5), p.Priority(next))
+	for _, priority := range []uint64{5, 4, 3, 2, 1} {
+		popped, ok := txm.PopNext(ctx)
+		require.True(ok)
+		require.Equal(priority, p.Priority(popped))
+	}
+	_, ok = txm.PopNext(ctx)
+	require.False(ok)
+	require.Zero(txm.Size(ctx))
+	require.Empty(txm.keys)
+	require.Empty(txm.sponsored)
+}
+
+func TestMempoolPriorityEvictCheapest(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	p := newTestPrioritizer()
+	txm := NewPrioritized[*TestItem](tracer, 3, 10, nil, p)
+
+	cheap := p.generate(testSponsor, 1, "a")
+	txm.Add(ctx, []*TestItem{cheap, p.generate(testSponsor, 3, "b"), p.generate(testSponsor, 4, "c")})
+	require.Equal(3, txm.Len(ctx))
+
+	// Not worth more than anything in the mempool
+	cheaper := p.generate(testSponsor, 1, "d")
+	txm.Add(ctx, []*TestItem{cheaper})
+	require.False(txm.Has(ctx, cheaper.ID()))
+
+	// Evicts the cheapest item
+	better := p.generate(testSponsor, 2, "e")
+	txm.Add(ctx, []*TestItem{better})
+	require.True(txm.Has(ctx, better.ID()))
+	require.False(txm.Has(ctx, cheap.ID()))
+	require.Equal(3, txm.Len(ctx))
+	require.Equal(3, txm.owned[testSponsor])
+}
+
+func TestMempoolPriorityReplace(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	p := newTestPrioritizer()
+	txm := NewPrioritized[*TestItem](tracer, 10, 2, nil, p)
+	otherSponsor := codec.CreateAddress(4, ids.GenerateTestID())
+
+	original := p.generate(testSponsor, 2, "a", "b")
+	other := p.generate(otherSponsor, 1, "a")
+	txm.Add(ctx, []*TestItem{original, other})
+	require.Equal(2, txm.Len(ctx))
+
+	// Does not pay more
+	same := p.generate(testSponsor, 2, "b")
+	txm.Add(ctx, []*TestItem{same})
+	require.False(txm.Has(ctx, same.ID()))
+
+	// Replaces an item from the same sponsor (even when at [maxSponsorSize])
+	unrelated := p.generate(testSponsor, 1, "c")
+	txm.Add(ctx, []*TestItem{unrelated})
+	require.Equal(2, txm.owned[testSponsor])
+	replacement := p.generate(testSponsor, 3, "b")
+	txm.Add(ctx, []*TestItem{replacement})
+	require.True(txm.Has(ctx, replacement.ID()))
+	require.False(txm.Has(ctx, original.ID()))
+	require.True(txm.Has(ctx, unrelated.ID()))
+	require.Equal(2, txm.owned[testSponsor])
+
+	// Items from other sponsors are not replaced
+	require.True(txm.Has(ctx, other.ID()))
+	require.Equal(3, txm.Len(ctx))
+
+	// Replaced items can be removed without error
+	txm.Remove(ctx, []*TestItem{original, replacement})
+	require.Equal(2, txm.Len(ctx))
+	require.Equal(1, txm.owned[testSponsor])
+	removed := txm.SetMinTimestamp(ctx, 200)
+	require.Len(removed, 2)
+	require.Zero(txm.Len(ctx))
+	require.Empty(txm.keys)
+	require.Empty(txm.sponsored)
+}
+
+type NoncedTestItem struct {
+	*TestItem
+	nonce uint64
+}
+
+func (mti *NoncedTestItem) Nonce() uint64 {
+	return mti.nonce
+}
+
+type noncedTestPrioritizer struct {
+	*testPrioritizer
+}
+
+func (p *noncedTestPrioritizer) Priority(item *NoncedTestItem) uint64 {
+	return p.testPrioritizer.Priority(item.TestItem)
+}
+
+func (p *noncedTestPrioritizer) Keys(item *NoncedTestItem) set.Set[string] {
+	return p.testPrioritizer.Keys(item.TestItem)
+}
+
+func TestMempoolNonces(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	p := &noncedTestPrioritizer{newTestPrioritizer()}
+	txm := NewPrioritized[*NoncedTestItem](tracer, 10, 10, nil, p)
+
+	// Items with different nonces do not replace each other (even if they
+	// touch the same keys)
+	first := &NoncedTestItem{p.generate(testSponsor, 1, "nonce"), 0}
+	second := &NoncedTestItem{p.generate(testSponsor, 2, "nonce"), 1}
+	txm.Add(ctx, []*NoncedTestItem{first, second})
+	require.Equal(2, txm.Len(ctx))
+	require.True(txm.HasNonce(ctx, testSponsor, 0))
+	require.True(txm.HasNonce(ctx, testSponsor, 1))
+	require.False(txm.HasNonce(ctx, testSponsor, 2))
+
+	// Items with the same nonce replace each other
+	replacement := &NoncedTestItem{p.generate(testSponsor, 3, "nonce"), 1}
+	txm.Add(ctx, []*NoncedTestItem{replacement})
+	require.Equal(2, txm.Len(ctx))
+	require.False(txm.Has(ctx, second.ID()))
+	require.True(txm.HasNonce(ctx, testSponsor, 1))
+
+	// Nonces are no longer tracked once items are removed
+	txm.Remove(ctx, []*NoncedTestItem{first})
+	require.False(txm.HasNonce(ctx, testSponsor, 0))
+	_, ok := txm.PopNext(ctx)
+	require.True(ok)
+	require.False(txm.HasNonce(ctx, testSponsor, 1))
+	require.Empty(txm.nonces)
+}
+
+func TestMempoolInspect(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	exemptSponsor := codec.CreateAddress(99, ids.GenerateTestID())
+	sponsor := codec.CreateAddress(4, ids.GenerateTestID())
+	txm := New[*TestItem](tracer, 20, 4, []codec.Address{exemptSponsor})
+
+	items := []*TestItem{}
+	for i := int64(0); i < 5; i++ {
+		items = append(items, GenerateTestItem(exemptSponsor, i))
+	}
+	items = append(items, GenerateTestItem(sponsor, 5))
+	txm.Add(ctx, items)
+
+	// Lookup by ID
+	item, ok := txm.Get(ctx, items[2].ID())
+	require.True(ok)
+	require.Equal(items[2], item)
+	_, ok = txm.Get(ctx, ids.GenerateTestID())
+	require.False(ok)
+
+	// Page through all items
+	page, next, ok := txm.List(ctx, ids.Empty, 4)
+	require.True(ok)
+	require.Equal(items[:4], page)
+	require.Equal(items[4].ID(), next)
+	page, next, ok = txm.List(ctx, next, 4)
+	require.True(ok)
+	require.Equal(items[4:], page)
+	require.Equal(ids.Empty, next)
+
+	// Cursor is no longer in the mempool
+	txm.Remove(ctx, items[4:5])
+	_, _, ok = txm.List(ctx, items[4].ID(), 4)
+	require.False(ok)
+
+	// Lookup by sponsor
+	require.Equal(items[:4], txm.Sponsored(ctx, exemptSponsor, 10))
+	require.Equal(items[:2], txm.Sponsored(ctx, exemptSponsor, 2))
+	require.Equal(items[5:], txm.Sponsored(ctx, sponsor, 10))
+	require.Empty(txm.Sponsored(ctx, testSponsor, 10))
+
+	// Sponsor usage
+	usage, maxSponsorSize := txm.Sponsors(ctx, 10)
+	require.Equal(4, maxSponsorSize)
+	require.Equal([]*SponsorUsage{
+		{Sponsor: exemptSponsor, Items: 4, Exempt: true},
+		{Sponsor: sponsor, Items: 1},
+	}, usage)
+	usage, _ = txm.Sponsors(ctx, 1)
+	require.Equal([]*SponsorUsage{
+		{Sponsor: exemptSponsor, Items: 4, Exempt: true},
+	}, usage)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type AdminJSONRPCClient struct {
+	requester *requester.EndpointRequester
+}
+
+func NewAdminJSONRPCClient(uri string) *AdminJSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += AdminJSONRPCEndpoint
+	req := requester.New(uri, Name)
+	return &AdminJSONRPCClient{requester: req}
+}
+
+// GossipPeers returns the scores of all peers the node has received tx gossip
+// from.
+func (cli *AdminJSONRPCClient) GossipPeers(ctx context.Context) ([]*gossiper.PeerScore, error) {
+	resp := new(GossipPeersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"gossipPeers",
+		nil,
+		resp,
+	)
+	return resp.Peers, err
+}
+
+// Replay re-executes the accepted blocks in [start, end] on the node and
+// returns the first divergence from what was accepted (or nil, if there is
+// none).
+func (cli *AdminJSONRPCClient) Replay(ctx context.Context, start uint64, end uint64) (*chain.Divergence, error) {
+	resp := new(ReplayReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"replay",
+		&ReplayArgs{
+			Start: start,
+			End:   end,
+		},
+		resp,
+	)
+	return resp.Divergence, err
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/gossiper"
+)
+
+// AdminJSONRPCServer serves information about the node that is only useful
+// to its operator (and should not be exposed publicly). It is only registered
+// if the admin API is enabled in the VM config.
+type AdminJSONRPCServer struct {
+	vm VM
+}
+
+func NewAdminJSONRPCServer(vm VM) *AdminJSONRPCServer {
+	return &AdminJSONRPCServer{vm}
+}
+
+type GossipPeersReply struct {
+	Peers []*gossiper.PeerScore `json:"peers"`
+}
+
+// GossipPeers returns the scores of all peers we've received tx gossip from.
+func (a *AdminJSONRPCServer) GossipPeers(req *http.Request, _ *struct{}, reply *GossipPeersReply) error {
+	_, span := a.vm.Tracer().Start(req.Context(), "AdminJSONRPCServer.GossipPeers")
+	defer span.End()
+
+	reply.Peers = a.vm.GossipPeerScores()
+	return nil
+}
+
+type ReplayArgs struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+type ReplayReply struct {
+	// Divergence is nil if the blocks executed as they were accepted.
+	Divergence *chain.Divergence `json:"divergence"`
+}
+
+// Replay re-executes the accepted blocks in [Start, End] on the running node
+// and returns the first divergence from what was accepted. Unless the node is
+// an archive, the parent state of each block must still be in the most recent
+// [GetStateHistoryLength] roots committed since the node started.
+func (a *AdminJSONRPCServer) Replay(req *http.Request, args *ReplayArgs, reply *ReplayReply) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminJSONRPCServer.Replay")
+	defer span.End()
+
+	div, err := a.vm.Replay(ctx, args.Start, args.End)
+	if err != nil {
+		return err
+	}
+	reply.Divergence = div
+	return nil
+}
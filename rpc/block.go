@@ -0,0 +1,119 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// BlockHeader is everything in a block except its transactions.
+type BlockHeader struct {
+	Parent      ids.ID     `json:"parent"`
+	Timestamp   int64      `json:"timestamp"`
+	Height      uint64     `json:"height"`
+	StateRoot   ids.ID     `json:"stateRoot"`
+	WarpResults set.Bits64 `json:"warpResults"`
+	EventsRoot  ids.ID     `json:"eventsRoot"`
+}
+
+// TypedObject is an [chain.Action] or [chain.Auth] encoded as JSON alongside
+// the type ID it is registered with.
+type TypedObject struct {
+	TypeID uint8           `json:"typeId"`
+	Value  json.RawMessage `json:"value"`
+}
+
+type BlockTx struct {
+	ID          ids.ID         `json:"id"`
+	Base        *chain.Base    `json:"base"`
+	WarpMessage *warp.Message  `json:"warpMessage"`
+	Actions     []*TypedObject `json:"actions"`
+	Auth        *TypedObject   `json:"auth"`
+	Actor       codec.Address  `json:"actor"`
+	Sponsor     codec.Address  `json:"sponsor"`
+}
+
+type BlockReply struct {
+	BlockID ids.ID       `json:"blockId"`
+	Header  *BlockHeader `json:"header"`
+	Txs     []*BlockTx   `json:"txs"`
+	// Results contains the [chain.Result] of each tx in [Txs] (nil if the
+	// block was accepted during state sync and never executed).
+	Results []*chain.Result `json:"results"`
+	// Bytes is the encoded block, which can be parsed with
+	// [chain.UnmarshalBlock].
+	Bytes []byte `json:"bytes"`
+}
+
+func newTypedObject(typeID uint8, v any) (*TypedObject, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedObject{TypeID: typeID, Value: b}, nil
+}
+
+func newBlockTx(tx *chain.Transaction) (*BlockTx, error) {
+	actions := make([]*TypedObject, len(tx.Actions))
+	for i, action := range tx.Actions {
+		obj, err := newTypedObject(action.GetTypeID(), action)
+		if err != nil {
+			return nil, err
+		}
+		actions[i] = obj
+	}
+	auth, err := newTypedObject(tx.Auth.GetTypeID(), tx.Auth)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockTx{
+		ID:          tx.ID(),
+		Base:        tx.Base,
+		WarpMessage: tx.WarpMessage,
+		Actions:     actions,
+		Auth:        auth,
+		Actor:       tx.Auth.Actor(),
+		Sponsor:     tx.Auth.Sponsor(),
+	}, nil
+}
+
+func newBlockTxs(txs []*chain.Transaction) ([]*BlockTx, error) {
+	btxs := make([]*BlockTx, len(txs))
+	for i, tx := range txs {
+		btx, err := newBlockTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		btxs[i] = btx
+	}
+	return btxs, nil
+}
+
+func newBlockReply(blk *chain.StatelessBlock, results []*chain.Result) (*BlockReply, error) {
+	txs, err := newBlockTxs(blk.Txs)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockReply{
+		BlockID: blk.ID(),
+		Header: &BlockHeader{
+			Parent:      blk.Prnt,
+			Timestamp:   blk.Tmstmp,
+			Height:      blk.Hght,
+			StateRoot:   blk.StateRoot,
+			WarpResults: blk.WarpResults,
+			EventsRoot:  blk.EventsRoot,
+		},
+		Txs:     txs,
+		Results: results,
+		Bytes:   blk.Bytes(),
+	}, nil
+}
@@ -10,5 +10,35 @@ const (
 	JSONRPCEndpoint   = "/coreapi"
 	WebSocketEndpoint = "/corews"
 
+	// AdminJSONRPCEndpoint is only registered if the admin API is enabled
+	AdminJSONRPCEndpoint = "/adminapi"
+
+	// SnapshotEndpoint is only registered if the snapshot API is enabled
+	SnapshotEndpoint = "/snapshot"
+
 	DefaultHandshakeTimeout = 10 * time.Second
+
+	// MaxAddressTransactions is the most txIDs returned by a single
+	// [JSONRPCServer.GetAddressTransactions] call.
+	MaxAddressTransactions = 1024
+
+	// MaxMempoolTransactions is the most txIDs returned by a single
+	// [JSONRPCServer.GetMempoolTransactions] call.
+	MaxMempoolTransactions = 1024
+
+	// MaxMempoolSponsorTransactions is the most transactions returned by a
+	// single [JSONRPCServer.GetMempoolSponsorTransactions] call.
+	MaxMempoolSponsorTransactions = 256
+
+	// MaxMempoolSponsors is the most sponsors returned by a single
+	// [JSONRPCServer.GetMempoolSponsors] call.
+	MaxMempoolSponsors = 1024
+
+	// MaxStateProofKeys is the most keys that can be proven by a single
+	// [JSONRPCServer.GetStateProof] call.
+	MaxStateProofKeys = 64
+
+	// MaxBlockRange is the most blocks returned by a single
+	// [JSONRPCServer.GetBlockRange] call.
+	MaxBlockRange = 32
 )
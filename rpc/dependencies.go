@@ -5,13 +5,19 @@ package rpc
 
 import (
 	"context"
+	"io"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/snapshot"
 )
 
 type VM interface {
@@ -32,7 +38,24 @@ type VM interface {
 	GetWarpSignatures(ids.ID) ([]*chain.WarpSignature, error)
 	CurrentValidators(
 		context.Context,
-	) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{})
+	) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}, error)
 	GatherSignatures(context.Context, ids.ID, []byte)
+	GetWarpAggregationQuorum() (uint64, uint64)
+	ReadWarpState(context.Context, uint64, [][]byte) (*warp.UnsignedMessage, []*chain.WarpSignature, error)
 	GetVerifyAuth() bool
+	GetIndexedTransaction(ids.ID) (bool, uint64, int64, *chain.Result, error)
+	GetAddressTransactions(codec.Address, []byte, int) ([]ids.ID, []byte, error)
+	GetTxTrace(ids.ID) (bool, uint64, *chain.TxTrace, error)
+	GetMempoolTx(context.Context, ids.ID) (*chain.Transaction, bool)
+	GetMempoolTxs(context.Context, ids.ID, int) ([]*chain.Transaction, ids.ID, bool)
+	GetMempoolSponsorTxs(context.Context, codec.Address, int) []*chain.Transaction
+	GetMempoolSponsors(context.Context, int) ([]*mempool.SponsorUsage, int)
+	GetNonce(context.Context, codec.Address) (uint64, error)
+	SimulateTx(context.Context, *chain.Transaction) (*chain.Result, []string, error)
+	GetStateProofs(context.Context, uint64, [][]byte) (ids.ID, []*merkledb.RangeProof, error)
+	GetAcceptedBlock(context.Context, uint64) (*chain.StatelessBlock, []*chain.Result, error)
+	GetAcceptedBlockByID(context.Context, ids.ID) (*chain.StatelessBlock, []*chain.Result, error)
+	ExportSnapshot(context.Context, uint64, io.Writer) (*snapshot.Manifest, error)
+	GossipPeerScores() []*gossiper.PeerScore
+	Replay(context.Context, uint64, uint64) (*chain.Divergence, error)
 }
@@ -9,4 +9,11 @@ var (
 	ErrClosed         = errors.New("closed")
 	ErrExpired        = errors.New("expired")
 	ErrMessageMissing = errors.New("message missing")
+	ErrTxNotFound     = errors.New("tx not found")
+	ErrCursorNotFound = errors.New("cursor not found")
+	ErrFilterTooLarge = errors.New("filter too large")
+	ErrTooManyKeys    = errors.New("too many keys")
+	ErrInvalidProof   = errors.New("invalid proof")
+	ErrSnapshotFailed = errors.New("snapshot failed")
+	ErrTxWouldFail    = errors.New("tx would fail")
 )
@@ -11,14 +11,15 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
-	autils "github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/math"
-	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
-	"golang.org/x/exp/maps"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -26,6 +27,12 @@ import (
 const (
 	unitPricesCacheRefresh = 10 * time.Second
 	waitSleep              = 500 * time.Millisecond
+
+	// maxFeeMarginPercent is how much more than the fee of its max units at
+	// the last known unit prices a transaction generated by
+	// [GenerateTransaction] is willing to pay (in case unit prices rise before
+	// it is executed).
+	maxFeeMarginPercent = 50
 )
 
 type JSONRPCClient struct {
@@ -156,25 +163,315 @@ func (cli *JSONRPCClient) GetWarpSignatures(
 	return resp.Message, m, resp.Signatures, nil
 }
 
+// GetTransaction returns the indexed result of [txID] (if the node has
+// transaction indexing enabled and [txID] is in the accepted block window).
+func (cli *JSONRPCClient) GetTransaction(ctx context.Context, txID ids.ID) (bool, *GetTransactionReply, error) {
+	resp := new(GetTransactionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getTransaction",
+		&GetTransactionArgs{TxID: txID},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTxNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// GetAddressTransactions returns up to [limit] txIDs that involve [addr]
+// starting at [cursor] and the cursor of the next page (nil if there are no
+// more).
+func (cli *JSONRPCClient) GetAddressTransactions(
+	ctx context.Context,
+	addr codec.Address,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	resp := new(GetAddressTransactionsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getAddressTransactions",
+		&GetAddressTransactionsArgs{
+			Address: addr,
+			Cursor:  cursor,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.TxIDs, resp.Next, err
+}
+
+// Nonce returns the nonce the next transaction sponsored by [addr] should use
+// (if the chain has nonces enabled).
+func (cli *JSONRPCClient) Nonce(ctx context.Context, addr codec.Address) (uint64, error) {
+	resp := new(NonceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nonce",
+		&NonceArgs{Address: addr},
+		resp,
+	)
+	return resp.Nonce, err
+}
+
+// TraceTx returns the execution trace of [txID] (if the node has transaction
+// tracing enabled and [txID] is in the accepted block window).
+func (cli *JSONRPCClient) TraceTx(ctx context.Context, txID ids.ID) (bool, *TraceTxReply, error) {
+	resp := new(TraceTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"traceTx",
+		&TraceTxArgs{TxID: txID},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTxNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// GetMempoolTransactions returns up to [limit] txIDs in the mempool (in the
+// order they were added), starting with [cursor] (or the oldest transaction
+// if [cursor] is empty), and the cursor of the next page (empty if there are
+// no more transactions).
+func (cli *JSONRPCClient) GetMempoolTransactions(
+	ctx context.Context,
+	cursor ids.ID,
+	limit int,
+) ([]ids.ID, ids.ID, error) {
+	resp := new(GetMempoolTransactionsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getMempoolTransactions",
+		&GetMempoolTransactionsArgs{
+			Cursor: cursor,
+			Limit:  limit,
+		},
+		resp,
+	)
+	return resp.TxIDs, resp.Next, err
+}
+
+// GetMempoolTransaction returns [txID] (if it is in the mempool).
+func (cli *JSONRPCClient) GetMempoolTransaction(ctx context.Context, txID ids.ID) (bool, *BlockTx, error) {
+	resp := new(GetMempoolTransactionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getMempoolTransaction",
+		&GetMempoolTransactionArgs{TxID: txID},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTxNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp.Tx, nil
+}
+
+// GetMempoolSponsorTransactions returns up to [limit] transactions in the
+// mempool sponsored by [sponsor] (sorted by expiry).
+func (cli *JSONRPCClient) GetMempoolSponsorTransactions(
+	ctx context.Context,
+	sponsor codec.Address,
+	limit int,
+) ([]*BlockTx, error) {
+	resp := new(GetMempoolSponsorTransactionsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getMempoolSponsorTransactions",
+		&GetMempoolSponsorTransactionsArgs{
+			Sponsor: sponsor,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.Txs, err
+}
+
+// GetMempoolSponsors returns the number of transactions up to [limit]
+// sponsors have in the mempool (starting with the sponsor with the most
+// transactions) and the most transactions a non-exempt sponsor can have.
+func (cli *JSONRPCClient) GetMempoolSponsors(ctx context.Context, limit int) ([]*mempool.SponsorUsage, int, error) {
+	resp := new(GetMempoolSponsorsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getMempoolSponsors",
+		&GetMempoolSponsorsArgs{Limit: limit},
+		resp,
+	)
+	return resp.Sponsors, resp.MaxSponsorSize, err
+}
+
+// SimulateTx executes the signed transaction [tx] on top of the last accepted
+// state without issuing it.
+func (cli *JSONRPCClient) SimulateTx(ctx context.Context, tx []byte) (*SimulateTxReply, error) {
+	resp := new(SimulateTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"simulateTx",
+		&SimulateTxArgs{Tx: tx},
+		resp,
+	)
+	return resp, err
+}
+
+// SimulateUnsignedTx executes the transaction [digest] (see
+// [chain.Transaction.Digest]) on behalf of [actor] and [sponsor] on top of
+// the last accepted state. The [Units] and [Fee] returned do not include
+// those required by [chain.Auth].
+func (cli *JSONRPCClient) SimulateUnsignedTx(
+	ctx context.Context,
+	digest []byte,
+	actor codec.Address,
+	sponsor codec.Address,
+) (*SimulateTxReply, error) {
+	resp := new(SimulateTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"simulateTx",
+		&SimulateTxArgs{
+			Tx:       digest,
+			Unsigned: true,
+			Actor:    actor,
+			Sponsor:  sponsor,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// GetStateProof returns proofs of [keys] against the [StateRoot] of the
+// accepted block at [height] (the post-execution state of [height]-1). The
+// proofs are not verified (see [VerifyStateProof] and [GetVerifiedState]).
+func (cli *JSONRPCClient) GetStateProof(ctx context.Context, height uint64, keys [][]byte) (*GetStateProofReply, error) {
+	resp := new(GetStateProofReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getStateProof",
+		&GetStateProofArgs{
+			Height: height,
+			Keys:   keys,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// GetBlockByHeight returns the accepted block at [height] (only the last
+// [AcceptedBlockWindow] blocks are retained).
+func (cli *JSONRPCClient) GetBlockByHeight(ctx context.Context, height uint64) (*BlockReply, error) {
+	resp := new(BlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getBlockByHeight",
+		&GetBlockByHeightArgs{Height: height},
+		resp,
+	)
+	return resp, err
+}
+
+// GetBlockByID returns the accepted block with [blkID] (only the last
+// [AcceptedBlockWindow] blocks are retained).
+func (cli *JSONRPCClient) GetBlockByID(ctx context.Context, blkID ids.ID) (*BlockReply, error) {
+	resp := new(BlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getBlockByID",
+		&GetBlockByIDArgs{BlockID: blkID},
+		resp,
+	)
+	return resp, err
+}
+
+// GetBlockRange returns up to [count] accepted blocks starting at [start].
+func (cli *JSONRPCClient) GetBlockRange(ctx context.Context, start uint64, count int) ([]*BlockReply, error) {
+	resp := new(GetBlockRangeReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getBlockRange",
+		&GetBlockRangeArgs{
+			Start: start,
+			Count: count,
+		},
+		resp,
+	)
+	return resp.Blocks, err
+}
+
+// GetVerifiedState fetches and verifies proofs of [keys] against the header of
+// [blk] and returns their values in the post-execution state of the parent
+// of [blk].
+func (cli *JSONRPCClient) GetVerifiedState(
+	ctx context.Context,
+	blk *chain.StatefulBlock,
+	branchFactor merkledb.BranchFactor,
+	keys [][]byte,
+) ([]maybe.Maybe[[]byte], error) {
+	reply, err := cli.GetStateProof(ctx, blk.Hght, keys)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyStateProof(ctx, blk, branchFactor, keys, reply)
+}
+
 type Modifier interface {
 	Base(*chain.Base)
 }
 
+// Simulate is a [Modifier] that instructs [GenerateTransaction] to execute the
+// transaction with [SimulateTx] before returning it and to return
+// [ErrTxWouldFail] if it would not succeed on the last accepted state.
+//
+// The [chain.Base.MaxFee] is not changed because the sponsor must be able to
+// pay the fee of the max units the transaction could use.
+type Simulate struct{}
+
+func (*Simulate) Base(*chain.Base) {}
+
+// Nonce is a [Modifier] that sets [chain.Base.Nonce] (see [JSONRPCClient.Nonce]).
+type Nonce uint64
+
+func (n Nonce) Base(b *chain.Base) {
+	b.Nonce = uint64(n)
+}
+
 func (cli *JSONRPCClient) GenerateTransaction(
 	ctx context.Context,
 	parser chain.Parser,
 	wm *warp.Message,
-	action chain.Action,
+	actions []chain.Action,
 	authFactory chain.AuthFactory,
 	modifiers ...Modifier,
 ) (func(context.Context) error, *chain.Transaction, uint64, error) {
-	// Get latest fee info
-	unitPrices, err := cli.UnitPrices(ctx, true)
+	// Get latest fee info (cached prices may be too low to pay the max fee)
+	unitPrices, err := cli.UnitPrices(ctx, false)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), action, authFactory, wm)
+	// Transactions with a nonce also pay for the nonce of their sponsor
+	nonces := false
+	for _, m := range modifiers {
+		if _, ok := m.(Nonce); ok {
+			nonces = true
+		}
+	}
+	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), actions, authFactory, wm, nonces)
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -182,17 +479,43 @@ func (cli *JSONRPCClient) GenerateTransaction(
 	if err != nil {
 		return nil, nil, 0, err
 	}
-	f, tx, err := cli.GenerateTransactionManual(parser, wm, action, authFactory, maxFee, modifiers...)
+	margin, err := math.Mul64(maxFee, maxFeeMarginPercent)
 	if err != nil {
 		return nil, nil, 0, err
 	}
+	maxFee, err = math.Add64(maxFee, margin/100)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	f, tx, err := cli.GenerateTransactionManual(parser, wm, actions, authFactory, maxFee, modifiers...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Ensure the transaction would succeed (if requested)
+	simulate := false
+	for _, m := range modifiers {
+		if _, ok := m.(*Simulate); ok {
+			simulate = true
+		}
+	}
+	if !simulate {
+		return f, tx, maxFee, nil
+	}
+	result, err := cli.SimulateTx(ctx, tx.Bytes())
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("%w: failed to simulate transaction", err)
+	}
+	if !result.Success {
+		return nil, nil, 0, fmt.Errorf("%w: %s", ErrTxWouldFail, result.Error)
+	}
 	return f, tx, maxFee, nil
 }
 
 func (cli *JSONRPCClient) GenerateTransactionManual(
 	parser chain.Parser,
 	wm *warp.Message,
-	action chain.Action,
+	actions []chain.Action,
 	authFactory chain.AuthFactory,
 	maxFee uint64,
 	modifiers ...Modifier,
@@ -220,7 +543,7 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 
 	// Build transaction
 	actionRegistry, authRegistry := parser.Registry()
-	tx := chain.NewTx(base, wm, action)
+	tx := chain.NewTx(base, wm, actions)
 	tx, err := tx.Sign(authFactory, actionRegistry, authRegistry)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: failed to sign transaction", err)
@@ -247,48 +570,11 @@ func Wait(ctx context.Context, check func(ctx context.Context) (bool, error)) er
 	return ctx.Err()
 }
 
-// getCanonicalValidatorSet returns the validator set of [subnetID] in a canonical ordering.
-// Also returns the total weight on [subnetID].
-func getCanonicalValidatorSet(
-	_ context.Context,
-	vdrSet map[ids.NodeID]*validators.GetValidatorOutput,
-) ([]*warp.Validator, uint64, error) {
-	var (
-		vdrs        = make(map[string]*warp.Validator, len(vdrSet))
-		totalWeight uint64
-		err         error
-	)
-	for _, vdr := range vdrSet {
-		totalWeight, err = math.Add64(totalWeight, vdr.Weight)
-		if err != nil {
-			return nil, 0, fmt.Errorf("%w: %v", warp.ErrWeightOverflow, err) //nolint:errorlint
-		}
-
-		if vdr.PublicKey == nil {
-			fmt.Println("skipping validator because of empty public key", vdr.NodeID)
-			continue
-		}
-
-		pkBytes := bls.PublicKeyToBytes(vdr.PublicKey)
-		uniqueVdr, ok := vdrs[string(pkBytes)]
-		if !ok {
-			uniqueVdr = &warp.Validator{
-				PublicKey:      vdr.PublicKey,
-				PublicKeyBytes: pkBytes,
-			}
-			vdrs[string(pkBytes)] = uniqueVdr
-		}
-
-		uniqueVdr.Weight += vdr.Weight // Impossible to overflow here
-		uniqueVdr.NodeIDs = append(uniqueVdr.NodeIDs, vdr.NodeID)
-	}
-
-	// Sort validators by public key
-	vdrList := maps.Values(vdrs)
-	autils.Sort(vdrList)
-	return vdrList, totalWeight, nil
-}
-
+// GenerateAggregateWarpSignature aggregates the signatures collected by the
+// node for the warp message emitted by [txID] (regardless of their weight).
+//
+// [GetAggregatedWarpMessage] should be preferred if the node is running a
+// signature aggregator.
 func (cli *JSONRPCClient) GenerateAggregateWarpSignature(
 	ctx context.Context,
 	txID ids.ID,
@@ -297,50 +583,73 @@ func (cli *JSONRPCClient) GenerateAggregateWarpSignature(
 	if err != nil {
 		return nil, 0, 0, fmt.Errorf("%w: failed to fetch warp signatures", err)
 	}
+	return AggregateWarpSignatures(unsignedMessage, validators, signatures)
+}
 
-	// Get canonical validator ordering to generate signature bit set
-	canonicalValidators, weight, err := getCanonicalValidatorSet(ctx, validators)
+// GetAggregatedWarpMessage returns the warp message emitted by [txID] signed
+// by at least [quorumNum]/[quorumDen] of the stake weight of the node's
+// validator set (the node's configured quorum is used if [quorumDen] is 0),
+// the total weight of the validator set, and the weight that signed the
+// message.
+func (cli *JSONRPCClient) GetAggregatedWarpMessage(
+	ctx context.Context,
+	txID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, uint64, uint64, error) {
+	resp := new(GetAggregatedWarpMessageReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getAggregatedWarpMessage",
+		&GetAggregatedWarpMessageArgs{
+			TxID:      txID,
+			QuorumNum: quorumNum,
+			QuorumDen: quorumDen,
+		},
+		resp,
+	)
 	if err != nil {
-		return nil, 0, 0, fmt.Errorf("%w: failed to get canonical validator set", err)
+		return nil, 0, 0, err
 	}
-
-	// Generate map of bls.PublicKey => Signature
-	signatureMap := map[ids.ID][]byte{}
-	for _, signature := range signatures {
-		// Convert to hash for easy comparison (could just as easily store the raw
-		// public key but that would involve a number of memory copies)
-		signatureMap[utils.ToID(signature.PublicKey)] = signature.Signature
+	message, err := warp.ParseMessage(resp.Message)
+	if err != nil {
+		return nil, 0, 0, err
 	}
+	return message, resp.Weight, resp.SignatureWeight, nil
+}
 
-	// Generate signature
-	signers := set.NewBits()
-	var signatureWeight uint64
-	orderedSignatures := []*bls.Signature{}
-	for i, vdr := range canonicalValidators {
-		sig, ok := signatureMap[utils.ToID(vdr.PublicKeyBytes)]
-		if !ok {
-			continue
-		}
-		blsSig, err := bls.SignatureFromBytes(sig)
-		if err != nil {
-			return nil, 0, 0, err
-		}
-		signers.Add(i)
-		signatureWeight += vdr.Weight
-		orderedSignatures = append(orderedSignatures, blsSig)
-	}
-	aggSignature, err := bls.AggregateSignatures(orderedSignatures)
+// GetWarpStateRead returns a warp message attesting to the values of [keys]
+// in the state committed to by the accepted block at [height], signed by at
+// least [quorumNum]/[quorumDen] of the stake weight of the node's validator set
+// (the node's configured quorum is used if [quorumDen] is 0), the total weight
+// of the validator set, and the weight that signed the message.
+//
+// The payload of the message can be parsed with [chain.UnmarshalWarpStateRead].
+func (cli *JSONRPCClient) GetWarpStateRead(
+	ctx context.Context,
+	height uint64,
+	keys [][]byte,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, uint64, uint64, error) {
+	resp := new(GetWarpStateReadReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getWarpStateRead",
+		&GetWarpStateReadArgs{
+			Height:    height,
+			Keys:      keys,
+			QuorumNum: quorumNum,
+			QuorumDen: quorumDen,
+		},
+		resp,
+	)
 	if err != nil {
-		return nil, 0, 0, fmt.Errorf("%w: failed to aggregate signatures", err)
-	}
-	aggSignatureBytes := bls.SignatureToBytes(aggSignature)
-	signature := &warp.BitSetSignature{
-		Signers: signers.Bytes(),
+		return nil, 0, 0, err
 	}
-	copy(signature.Signature[:], aggSignatureBytes)
-	message, err := warp.NewMessage(unsignedMessage, signature)
+	message, err := warp.ParseMessage(resp.Message)
 	if err != nil {
-		return nil, 0, 0, fmt.Errorf("%w: failed to generate warp message", err)
+		return nil, 0, 0, err
 	}
-	return message, weight, signatureWeight, nil
+	return message, resp.Weight, resp.SignatureWeight, nil
 }
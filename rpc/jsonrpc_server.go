@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -15,6 +16,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
 	"go.uber.org/zap"
 )
 
@@ -67,7 +69,7 @@ func (j *JSONRPCServer) SubmitTx(
 
 	actionRegistry, authRegistry := j.vm.Registry()
 	rtx := codec.NewReader(args.Tx, consts.NetworkSizeLimit) // will likely be much smaller than this
-	tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
+	tx, err := chain.UnmarshalTx(rtx, time.Now().UnixMilli(), actionRegistry, authRegistry)
 	if err != nil {
 		return fmt.Errorf("%w: unable to unmarshal on public service", err)
 	}
@@ -87,6 +89,202 @@ func (j *JSONRPCServer) SubmitTx(
 	return j.vm.Submit(ctx, false, []*chain.Transaction{tx})[0]
 }
 
+type SimulateTxArgs struct {
+	Tx []byte `json:"tx"`
+
+	// Unsigned indicates that [Tx] is a transaction digest (see
+	// [chain.Transaction.Digest]) that should be simulated on behalf of
+	// [Actor] and [Sponsor].
+	Unsigned bool          `json:"unsigned"`
+	Actor    codec.Address `json:"actor"`
+	Sponsor  codec.Address `json:"sponsor"`
+}
+
+type SimulateTxReply struct {
+	Success     bool             `json:"success"`
+	Error       []byte           `json:"error"`
+	Outputs     [][]byte         `json:"outputs"`
+	Events      []*chain.Event   `json:"events"`
+	Units       chain.Dimensions `json:"units"`
+	Fee         uint64           `json:"fee"`
+	TouchedKeys [][]byte         `json:"touchedKeys"`
+}
+
+func (j *JSONRPCServer) SimulateTx(
+	req *http.Request,
+	args *SimulateTxArgs,
+	reply *SimulateTxReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SimulateTx")
+	defer span.End()
+
+	actionRegistry, authRegistry := j.vm.Registry()
+	rtx := codec.NewReader(args.Tx, consts.NetworkSizeLimit)
+	var (
+		tx  *chain.Transaction
+		err error
+		now = time.Now().UnixMilli()
+	)
+	if args.Unsigned {
+		tx, err = chain.UnmarshalUnsignedTx(rtx, now, actionRegistry, authRegistry, args.Actor, args.Sponsor)
+	} else {
+		tx, err = chain.UnmarshalTx(rtx, now, actionRegistry, authRegistry)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: unable to unmarshal on public service", err)
+	}
+	if !rtx.Empty() {
+		return errors.New("tx has extra bytes")
+	}
+	result, touched, err := j.vm.SimulateTx(ctx, tx)
+	if err != nil {
+		return err
+	}
+	reply.Success = result.Success
+	reply.Error = result.Error
+	reply.Outputs = result.Outputs
+	reply.Events = result.Events
+	reply.Units = result.Consumed
+	reply.Fee = result.Fee
+	reply.TouchedKeys = make([][]byte, len(touched))
+	for i, k := range touched {
+		reply.TouchedKeys[i] = []byte(k)
+	}
+	return nil
+}
+
+type GetStateProofArgs struct {
+	// Height is the accepted block whose [StateRoot] the proofs are generated
+	// against. Because roots are deferred, this is the post-execution state of
+	// the block at [Height]-1.
+	Height uint64   `json:"height"`
+	Keys   [][]byte `json:"keys"`
+}
+
+type GetStateProofReply struct {
+	Height    uint64 `json:"height"`
+	StateRoot ids.ID `json:"stateRoot"`
+	// Proofs are encoded [merkledb.RangeProof]s (one per key) that prove the
+	// value (or absence) of each key.
+	Proofs [][]byte `json:"proofs"`
+}
+
+func (j *JSONRPCServer) GetStateProof(
+	req *http.Request,
+	args *GetStateProofArgs,
+	reply *GetStateProofReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetStateProof")
+	defer span.End()
+
+	if len(args.Keys) > MaxStateProofKeys {
+		return ErrTooManyKeys
+	}
+	root, proofs, err := j.vm.GetStateProofs(ctx, args.Height, args.Keys)
+	if err != nil {
+		return err
+	}
+	reply.Height = args.Height
+	reply.StateRoot = root
+	reply.Proofs = make([][]byte, len(proofs))
+	for i, proof := range proofs {
+		b, err := marshalStateProof(proof)
+		if err != nil {
+			return err
+		}
+		reply.Proofs[i] = b
+	}
+	return nil
+}
+
+type GetBlockByHeightArgs struct {
+	Height uint64 `json:"height"`
+}
+
+func (j *JSONRPCServer) GetBlockByHeight(
+	req *http.Request,
+	args *GetBlockByHeightArgs,
+	reply *BlockReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetBlockByHeight")
+	defer span.End()
+
+	blk, results, err := j.vm.GetAcceptedBlock(ctx, args.Height)
+	if err != nil {
+		return err
+	}
+	r, err := newBlockReply(blk, results)
+	if err != nil {
+		return err
+	}
+	*reply = *r
+	return nil
+}
+
+type GetBlockByIDArgs struct {
+	BlockID ids.ID `json:"blockId"`
+}
+
+func (j *JSONRPCServer) GetBlockByID(
+	req *http.Request,
+	args *GetBlockByIDArgs,
+	reply *BlockReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetBlockByID")
+	defer span.End()
+
+	blk, results, err := j.vm.GetAcceptedBlockByID(ctx, args.BlockID)
+	if err != nil {
+		return err
+	}
+	r, err := newBlockReply(blk, results)
+	if err != nil {
+		return err
+	}
+	*reply = *r
+	return nil
+}
+
+type GetBlockRangeArgs struct {
+	Start uint64 `json:"start"`
+	Count int    `json:"count"`
+}
+
+type GetBlockRangeReply struct {
+	// Blocks are the accepted blocks from [GetBlockRangeArgs.Start] (in
+	// order). Fewer than [GetBlockRangeArgs.Count] blocks are returned if
+	// the range extends past the last accepted block.
+	Blocks []*BlockReply `json:"blocks"`
+}
+
+func (j *JSONRPCServer) GetBlockRange(
+	req *http.Request,
+	args *GetBlockRangeArgs,
+	reply *GetBlockRangeReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetBlockRange")
+	defer span.End()
+
+	count := args.Count
+	if count <= 0 || count > MaxBlockRange {
+		count = MaxBlockRange
+	}
+	lastAccepted := j.vm.LastAcceptedBlock().Hght
+	reply.Blocks = make([]*BlockReply, 0, count)
+	for height := args.Start; height <= lastAccepted && len(reply.Blocks) < count; height++ {
+		blk, results, err := j.vm.GetAcceptedBlock(ctx, height)
+		if err != nil {
+			return err
+		}
+		r, err := newBlockReply(blk, results)
+		if err != nil {
+			return err
+		}
+		reply.Blocks = append(reply.Blocks, r)
+	}
+	return nil
+}
+
 type LastAcceptedReply struct {
 	Height    uint64 `json:"height"`
 	BlockID   ids.ID `json:"blockId"`
@@ -161,7 +359,10 @@ func (j *JSONRPCServer) GetWarpSignatures(
 	// Ensure we only return valid signatures
 	validSignatures := []*chain.WarpSignature{}
 	warpValidators := []*WarpValidator{}
-	validators, publicKeys := j.vm.CurrentValidators(req.Context())
+	validators, publicKeys, err := j.vm.CurrentValidators(req.Context())
+	if err != nil {
+		return err
+	}
 	for _, sig := range signatures {
 		if _, ok := publicKeys[string(sig.PublicKey)]; !ok {
 			continue
@@ -199,3 +400,389 @@ func (j *JSONRPCServer) GetWarpSignatures(
 	reply.Signatures = validSignatures
 	return nil
 }
+
+type GetAggregatedWarpMessageArgs struct {
+	TxID ids.ID `json:"txId"`
+
+	// QuorumNum and QuorumDen are the fraction of stake weight that must
+	// have signed the message (the configured quorum of the node is used if
+	// [QuorumDen] is 0).
+	QuorumNum uint64 `json:"quorumNum"`
+	QuorumDen uint64 `json:"quorumDen"`
+}
+
+type GetAggregatedWarpMessageReply struct {
+	// Message is an encoded [warp.Message] that can be included in a
+	// transaction on the destination chain.
+	Message         []byte `json:"message"`
+	Weight          uint64 `json:"weight"`
+	SignatureWeight uint64 `json:"signatureWeight"`
+}
+
+func (j *JSONRPCServer) GetAggregatedWarpMessage(
+	req *http.Request,
+	args *GetAggregatedWarpMessageArgs,
+	reply *GetAggregatedWarpMessageReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetAggregatedWarpMessage")
+	defer span.End()
+
+	unsignedMessage, err := j.vm.GetOutgoingWarpMessage(args.TxID)
+	if err != nil {
+		return err
+	}
+	if unsignedMessage == nil {
+		return ErrMessageMissing
+	}
+	signatures, err := j.vm.GetWarpSignatures(args.TxID)
+	if err != nil {
+		return err
+	}
+	quorumNum, quorumDen := args.QuorumNum, args.QuorumDen
+	if quorumDen == 0 {
+		quorumNum, quorumDen = j.vm.GetWarpAggregationQuorum()
+	}
+
+	// Ensure enough weight has signed the message before aggregating
+	validators, _, err := j.vm.CurrentValidators(ctx)
+	if err != nil {
+		return err
+	}
+	weight, signatureWeight, err := WarpSignatureWeight(validators, signatures)
+	if err != nil {
+		return err
+	}
+	err = warp.VerifyWeight(signatureWeight, weight, quorumNum, quorumDen)
+	if err == nil && signatureWeight == 0 {
+		// An empty validator set satisfies any quorum
+		err = warp.ErrInsufficientWeight
+	}
+	if err != nil {
+		// Gathering signatures is rate limited, so it is safe to trigger here
+		j.vm.GatherSignatures(context.TODO(), args.TxID, unsignedMessage.Bytes())
+		return err
+	}
+	message, weight, signatureWeight, err := AggregateWarpSignatures(unsignedMessage, validators, signatures)
+	if err != nil {
+		return err
+	}
+	reply.Message = message.Bytes()
+	reply.Weight = weight
+	reply.SignatureWeight = signatureWeight
+	return nil
+}
+
+type GetWarpStateReadArgs struct {
+	Height uint64   `json:"height"`
+	Keys   [][]byte `json:"keys"`
+
+	// QuorumNum and QuorumDen are the fraction of stake weight that must
+	// have signed the read (the configured quorum of the node is used if
+	// [QuorumDen] is 0).
+	QuorumNum uint64 `json:"quorumNum"`
+	QuorumDen uint64 `json:"quorumDen"`
+}
+
+type GetWarpStateReadReply struct {
+	// Message is an encoded [warp.Message] with a [chain.WarpStateRead]
+	// payload that can be included in a transaction on another chain.
+	Message         []byte `json:"message"`
+	Weight          uint64 `json:"weight"`
+	SignatureWeight uint64 `json:"signatureWeight"`
+}
+
+func (j *JSONRPCServer) GetWarpStateRead(
+	req *http.Request,
+	args *GetWarpStateReadArgs,
+	reply *GetWarpStateReadReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetWarpStateRead")
+	defer span.End()
+
+	if len(args.Keys) > chain.MaxWarpStateReadKeys {
+		return chain.ErrTooManyWarpStateReadKeys
+	}
+	unsignedMessage, signatures, err := j.vm.ReadWarpState(ctx, args.Height, args.Keys)
+	if err != nil {
+		return err
+	}
+	quorumNum, quorumDen := args.QuorumNum, args.QuorumDen
+	if quorumDen == 0 {
+		quorumNum, quorumDen = j.vm.GetWarpAggregationQuorum()
+	}
+
+	// Ensure enough weight has signed the read before aggregating
+	validators, _, err := j.vm.CurrentValidators(ctx)
+	if err != nil {
+		return err
+	}
+	weight, signatureWeight, err := WarpSignatureWeight(validators, signatures)
+	if err != nil {
+		return err
+	}
+	if err := warp.VerifyWeight(signatureWeight, weight, quorumNum, quorumDen); err != nil {
+		return err
+	}
+	message, weight, signatureWeight, err := AggregateWarpSignatures(unsignedMessage, validators, signatures)
+	if err != nil {
+		return err
+	}
+	reply.Message = message.Bytes()
+	reply.Weight = weight
+	reply.SignatureWeight = signatureWeight
+	return nil
+}
+
+type GetTransactionArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type GetTransactionReply struct {
+	Height    uint64           `json:"height"`
+	Timestamp int64            `json:"timestamp"`
+	Success   bool             `json:"success"`
+	Error     []byte           `json:"error"`
+	Outputs   [][]byte         `json:"outputs"`
+	Units     chain.Dimensions `json:"units"`
+	Fee       uint64           `json:"fee"`
+}
+
+func (j *JSONRPCServer) GetTransaction(
+	req *http.Request,
+	args *GetTransactionArgs,
+	reply *GetTransactionReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetTransaction")
+	defer span.End()
+
+	found, height, t, result, err := j.vm.GetIndexedTransaction(args.TxID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTxNotFound
+	}
+	reply.Height = height
+	reply.Timestamp = t
+	reply.Success = result.Success
+	reply.Error = result.Error
+	reply.Outputs = result.Outputs
+	reply.Units = result.Consumed
+	reply.Fee = result.Fee
+	return nil
+}
+
+type GetAddressTransactionsArgs struct {
+	Address codec.Address `json:"address"`
+	Cursor  []byte        `json:"cursor"`
+	Limit   int           `json:"limit"`
+}
+
+type GetAddressTransactionsReply struct {
+	TxIDs []ids.ID `json:"txIds"`
+	// Next should be provided as [GetAddressTransactionsArgs.Cursor] to fetch
+	// the next page (empty if there are no more transactions).
+	Next []byte `json:"next"`
+}
+
+func (j *JSONRPCServer) GetAddressTransactions(
+	req *http.Request,
+	args *GetAddressTransactionsArgs,
+	reply *GetAddressTransactionsReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetAddressTransactions")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > MaxAddressTransactions {
+		limit = MaxAddressTransactions
+	}
+	txIDs, next, err := j.vm.GetAddressTransactions(args.Address, args.Cursor, limit)
+	if err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs
+	reply.Next = next
+	return nil
+}
+
+type NonceArgs struct {
+	Address codec.Address `json:"address"`
+}
+
+type NonceReply struct {
+	Nonce uint64 `json:"nonce"`
+}
+
+func (j *JSONRPCServer) Nonce(req *http.Request, args *NonceArgs, reply *NonceReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.Nonce")
+	defer span.End()
+
+	nonce, err := j.vm.GetNonce(ctx, args.Address)
+	if err != nil {
+		return err
+	}
+	reply.Nonce = nonce
+	return nil
+}
+
+type TraceTxArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type TraceTxReply struct {
+	Height uint64 `json:"height"`
+
+	// Accesses contains every read and modification of a key (in order),
+	// including those of actions that were reverted.
+	Accesses []*KeyAccess `json:"accesses"`
+
+	Reads     []*KeyChunks `json:"reads"`
+	Allocates []*KeyChunks `json:"allocates"`
+	Writes    []*KeyChunks `json:"writes"`
+
+	Consumed   chain.Dimensions `json:"consumed"`
+	UnitPrices chain.Dimensions `json:"unitPrices"`
+	Fees       chain.Dimensions `json:"fees"`
+}
+
+func (j *JSONRPCServer) TraceTx(
+	req *http.Request,
+	args *TraceTxArgs,
+	reply *TraceTxReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.TraceTx")
+	defer span.End()
+
+	found, height, trace, err := j.vm.GetTxTrace(args.TxID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTxNotFound
+	}
+	*reply = *newTraceTxReply(height, trace)
+	return nil
+}
+
+type GetMempoolTransactionsArgs struct {
+	// Cursor is the ID of the first transaction to return (empty to start
+	// with the oldest transaction in the mempool).
+	Cursor ids.ID `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type GetMempoolTransactionsReply struct {
+	TxIDs []ids.ID `json:"txIds"`
+	// Next should be provided as [GetMempoolTransactionsArgs.Cursor] to fetch
+	// the next page (empty if there are no more transactions).
+	Next ids.ID `json:"next"`
+}
+
+func (j *JSONRPCServer) GetMempoolTransactions(
+	req *http.Request,
+	args *GetMempoolTransactionsArgs,
+	reply *GetMempoolTransactionsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetMempoolTransactions")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > MaxMempoolTransactions {
+		limit = MaxMempoolTransactions
+	}
+	txs, next, ok := j.vm.GetMempoolTxs(ctx, args.Cursor, limit)
+	if !ok {
+		return ErrCursorNotFound
+	}
+	reply.TxIDs = make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		reply.TxIDs[i] = tx.ID()
+	}
+	reply.Next = next
+	return nil
+}
+
+type GetMempoolTransactionArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type GetMempoolTransactionReply struct {
+	Tx *BlockTx `json:"tx"`
+}
+
+func (j *JSONRPCServer) GetMempoolTransaction(
+	req *http.Request,
+	args *GetMempoolTransactionArgs,
+	reply *GetMempoolTransactionReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetMempoolTransaction")
+	defer span.End()
+
+	tx, ok := j.vm.GetMempoolTx(ctx, args.TxID)
+	if !ok {
+		return ErrTxNotFound
+	}
+	btx, err := newBlockTx(tx)
+	if err != nil {
+		return err
+	}
+	reply.Tx = btx
+	return nil
+}
+
+type GetMempoolSponsorTransactionsArgs struct {
+	Sponsor codec.Address `json:"sponsor"`
+	Limit   int           `json:"limit"`
+}
+
+type GetMempoolSponsorTransactionsReply struct {
+	Txs []*BlockTx `json:"txs"`
+}
+
+func (j *JSONRPCServer) GetMempoolSponsorTransactions(
+	req *http.Request,
+	args *GetMempoolSponsorTransactionsArgs,
+	reply *GetMempoolSponsorTransactionsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetMempoolSponsorTransactions")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > MaxMempoolSponsorTransactions {
+		limit = MaxMempoolSponsorTransactions
+	}
+	txs, err := newBlockTxs(j.vm.GetMempoolSponsorTxs(ctx, args.Sponsor, limit))
+	if err != nil {
+		return err
+	}
+	reply.Txs = txs
+	return nil
+}
+
+type GetMempoolSponsorsArgs struct {
+	Limit int `json:"limit"`
+}
+
+type GetMempoolSponsorsReply struct {
+	// MaxSponsorSize is the most transactions a single non-exempt sponsor
+	// can have in the mempool.
+	MaxSponsorSize int                     `json:"maxSponsorSize"`
+	Sponsors       []*mempool.SponsorUsage `json:"sponsors"`
+}
+
+func (j *JSONRPCServer) GetMempoolSponsors(
+	req *http.Request,
+	args *GetMempoolSponsorsArgs,
+	reply *GetMempoolSponsorsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetMempoolSponsors")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > MaxMempoolSponsors {
+		limit = MaxMempoolSponsors
+	}
+	reply.Sponsors, reply.MaxSponsorSize = j.vm.GetMempoolSponsors(ctx, limit)
+	return nil
+}
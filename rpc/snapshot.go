@@ -0,0 +1,122 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/snapshot"
+)
+
+const snapshotHeightParam = "height"
+
+var _ http.Handler = (*SnapshotServer)(nil)
+
+// SnapshotServer streams snapshots (see [snapshot.Writer]) of the state at an
+// accepted height in response to GET requests. If no height is provided, the
+// last accepted height is used.
+type SnapshotServer struct {
+	vm VM
+}
+
+func NewSnapshotServer(vm VM) *SnapshotServer {
+	return &SnapshotServer{vm}
+}
+
+// countingWriter tracks whether anything has been written to the response
+// (after which we can no longer return an error status).
+type countingWriter struct {
+	w       io.Writer
+	written int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += n
+	return n, err
+}
+
+func (s *SnapshotServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, span := s.vm.Tracer().Start(req.Context(), "SnapshotServer.ServeHTTP")
+	defer span.End()
+
+	if req.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	height := s.vm.LastAcceptedBlock().Hght
+	if v := req.URL.Query().Get(snapshotHeightParam); len(v) > 0 {
+		h, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		height = h
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	cw := &countingWriter{w: w}
+	m, err := s.vm.ExportSnapshot(ctx, height, cw)
+	if err != nil {
+		s.vm.Logger().Warn("unable to export snapshot", zap.Uint64("height", height), zap.Error(err))
+		if cw.written == 0 {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	s.vm.Logger().Info("exported snapshot",
+		zap.Uint64("height", m.Height),
+		zap.Stringer("root", m.StateRoot),
+		zap.Int("bytes", cw.written),
+	)
+}
+
+// DownloadSnapshot writes a snapshot of the state at [height] (or the last
+// accepted height, if nil) served by the node at [uri] to [w].
+func DownloadSnapshot(ctx context.Context, uri string, height *uint64, w io.Writer) (*snapshot.Manifest, error) {
+	uri = strings.TrimSuffix(uri, "/") + SnapshotEndpoint
+	if height != nil {
+		uri += fmt.Sprintf("?%s=%d", snapshotHeightParam, *height)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotFailed, strings.TrimSpace(string(msg)))
+	}
+	// Read the manifest while copying so callers can inspect it
+	tee := io.TeeReader(resp.Body, w)
+	sr, err := snapshot.NewReader(tee)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+	for {
+		_, err := sr.Next()
+		if err == io.EOF { //nolint:errorlint
+			// Copy anything not consumed by [sr] (like the gzip footer)
+			if _, err := io.Copy(io.Discard, tee); err != nil {
+				return nil, err
+			}
+			return sr.Manifest(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
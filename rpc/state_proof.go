@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func marshalStateProof(proof *merkledb.RangeProof) ([]byte, error) {
+	return proto.Marshal(proof.ToProto())
+}
+
+func unmarshalStateProof(b []byte) (*merkledb.RangeProof, error) {
+	var pbProof pb.RangeProof
+	if err := proto.Unmarshal(b, &pbProof); err != nil {
+		return nil, err
+	}
+	var proof merkledb.RangeProof
+	if err := proof.UnmarshalProto(&pbProof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// VerifyStateProof verifies [reply] (returned by [JSONRPCClient.GetStateProof])
+// against the [StateRoot] in the header of [blk] and returns the value of each
+// of [keys] (Nothing if a key does not exist).
+//
+// Because roots are deferred, the values returned are those in the
+// post-execution state of the parent of [blk]. [branchFactor] must match
+// the branch factor of the chain's state (see [vm.Genesis]).
+func VerifyStateProof(
+	ctx context.Context,
+	blk *chain.StatefulBlock,
+	branchFactor merkledb.BranchFactor,
+	keys [][]byte,
+	reply *GetStateProofReply,
+) ([]maybe.Maybe[[]byte], error) {
+	if reply.Height != blk.Hght {
+		return nil, fmt.Errorf("%w: expected height %d but got %d", ErrInvalidProof, blk.Hght, reply.Height)
+	}
+	if reply.StateRoot != blk.StateRoot {
+		return nil, fmt.Errorf("%w: expected root %s but got %s", ErrInvalidProof, blk.StateRoot, reply.StateRoot)
+	}
+	if len(reply.Proofs) != len(keys) {
+		return nil, fmt.Errorf("%w: expected %d proofs but got %d", ErrInvalidProof, len(keys), len(reply.Proofs))
+	}
+	tokenSize, ok := merkledb.BranchFactorToTokenSize[branchFactor]
+	if !ok {
+		return nil, merkledb.ErrInvalidBranchFactor
+	}
+	values := make([]maybe.Maybe[[]byte], len(keys))
+	for i, k := range keys {
+		proof, err := unmarshalStateProof(reply.Proofs[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err) //nolint:errorlint
+		}
+		if err := proof.Verify(ctx, maybe.Some(k), maybe.Some(k), blk.StateRoot, tokenSize); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err) //nolint:errorlint
+		}
+		// [Verify] ensures any key-value pairs are in [k, k]
+		if len(proof.KeyValues) > 0 {
+			values[i] = maybe.Some(proof.KeyValues[0].Value)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// KeyAccess is a read or modification of a key during execution. Before and
+// After are nil if the key did not exist.
+type KeyAccess struct {
+	Type   string `json:"type"`
+	Key    []byte `json:"key"`
+	Before []byte `json:"before"`
+	After  []byte `json:"after"`
+}
+
+// KeyChunks is the number of chunks of a key that were charged for.
+type KeyChunks struct {
+	Key    []byte `json:"key"`
+	Chunks uint16 `json:"chunks"`
+}
+
+func accessType(t tstate.AccessType) string {
+	switch t {
+	case tstate.ReadAccess:
+		return "read"
+	case tstate.InsertAccess:
+		return "insert"
+	case tstate.RemoveAccess:
+		return "remove"
+	default:
+		return "unknown"
+	}
+}
+
+// keyChunks returns the entries of [m] in key order.
+func keyChunks(m map[string]uint16) []*KeyChunks {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	chunks := make([]*KeyChunks, len(keys))
+	for i, k := range keys {
+		chunks[i] = &KeyChunks{Key: []byte(k), Chunks: m[k]}
+	}
+	return chunks
+}
+
+func newTraceTxReply(height uint64, trace *chain.TxTrace) *TraceTxReply {
+	accesses := make([]*KeyAccess, len(trace.Accesses))
+	for i, access := range trace.Accesses {
+		accesses[i] = &KeyAccess{
+			Type:   accessType(access.Type),
+			Key:    access.Key,
+			Before: access.Before.Value(),
+			After:  access.After.Value(),
+		}
+	}
+	return &TraceTxReply{
+		Height:     height,
+		Accesses:   accesses,
+		Reads:      keyChunks(trace.Reads),
+		Allocates:  keyChunks(trace.Allocates),
+		Writes:     keyChunks(trace.Writes),
+		Consumed:   trace.Consumed,
+		UnitPrices: trace.UnitPrices,
+		Fees:       trace.Fees(),
+	}
+}
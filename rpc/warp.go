@@ -0,0 +1,147 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	autils "github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// getCanonicalValidatorSet returns the validator set of [subnetID] in a canonical ordering.
+// Also returns the total weight on [subnetID].
+func getCanonicalValidatorSet(
+	vdrSet map[ids.NodeID]*validators.GetValidatorOutput,
+) ([]*warp.Validator, uint64, error) {
+	var (
+		vdrs        = make(map[string]*warp.Validator, len(vdrSet))
+		totalWeight uint64
+		err         error
+	)
+	for _, vdr := range vdrSet {
+		totalWeight, err = math.Add64(totalWeight, vdr.Weight)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", warp.ErrWeightOverflow, err) //nolint:errorlint
+		}
+
+		// Validators without a registered public key can't sign
+		if vdr.PublicKey == nil {
+			continue
+		}
+
+		// Validators must be ordered by their uncompressed public keys (like
+		// [warp.GetCanonicalValidatorSet]) or the signer bit set will not match
+		// the set used during verification
+		pkBytes := bls.SerializePublicKey(vdr.PublicKey)
+		uniqueVdr, ok := vdrs[string(pkBytes)]
+		if !ok {
+			uniqueVdr = &warp.Validator{
+				PublicKey:      vdr.PublicKey,
+				PublicKeyBytes: pkBytes,
+			}
+			vdrs[string(pkBytes)] = uniqueVdr
+		}
+
+		uniqueVdr.Weight += vdr.Weight // Impossible to overflow here
+		uniqueVdr.NodeIDs = append(uniqueVdr.NodeIDs, vdr.NodeID)
+	}
+
+	// Sort validators by public key
+	vdrList := maps.Values(vdrs)
+	autils.Sort(vdrList)
+	return vdrList, totalWeight, nil
+}
+
+// signatureMap returns a map of public key hash => signature. Public keys are
+// converted to hashes for easy comparison (could just as easily store the raw
+// public key but that would involve a number of memory copies).
+func signatureMap(signatures []*chain.WarpSignature) map[ids.ID][]byte {
+	m := make(map[ids.ID][]byte, len(signatures))
+	for _, signature := range signatures {
+		m[utils.ToID(signature.PublicKey)] = signature.Signature
+	}
+	return m
+}
+
+// WarpSignatureWeight returns the total weight of [validators] and the weight
+// of [validators] that produced any of [signatures]. Signatures from public
+// keys that are not in [validators] are ignored.
+//
+// The validity of [signatures] is not checked.
+func WarpSignatureWeight(
+	validators map[ids.NodeID]*validators.GetValidatorOutput,
+	signatures []*chain.WarpSignature,
+) (uint64, uint64, error) {
+	canonicalValidators, weight, err := getCanonicalValidatorSet(validators)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: failed to get canonical validator set", err)
+	}
+	signed := signatureMap(signatures)
+	var signatureWeight uint64
+	for _, vdr := range canonicalValidators {
+		if _, ok := signed[utils.ToID(bls.PublicKeyToBytes(vdr.PublicKey))]; ok {
+			signatureWeight += vdr.Weight
+		}
+	}
+	return weight, signatureWeight, nil
+}
+
+// AggregateWarpSignatures aggregates all [signatures] of [unsignedMessage]
+// produced by [validators] into a [warp.Message]. It also returns the total
+// weight of [validators] and the weight of [validators] that signed the
+// message.
+func AggregateWarpSignatures(
+	unsignedMessage *warp.UnsignedMessage,
+	validators map[ids.NodeID]*validators.GetValidatorOutput,
+	signatures []*chain.WarpSignature,
+) (*warp.Message, uint64, uint64, error) {
+	// Get canonical validator ordering to generate signature bit set
+	canonicalValidators, weight, err := getCanonicalValidatorSet(validators)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: failed to get canonical validator set", err)
+	}
+
+	// Generate signature
+	signed := signatureMap(signatures)
+	signers := set.NewBits()
+	var signatureWeight uint64
+	orderedSignatures := []*bls.Signature{}
+	for i, vdr := range canonicalValidators {
+		sig, ok := signed[utils.ToID(bls.PublicKeyToBytes(vdr.PublicKey))]
+		if !ok {
+			continue
+		}
+		blsSig, err := bls.SignatureFromBytes(sig)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		signers.Add(i)
+		signatureWeight += vdr.Weight
+		orderedSignatures = append(orderedSignatures, blsSig)
+	}
+	aggSignature, err := bls.AggregateSignatures(orderedSignatures)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: failed to aggregate signatures", err)
+	}
+	aggSignatureBytes := bls.SignatureToBytes(aggSignature)
+	signature := &warp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(signature.Signature[:], aggSignatureBytes)
+	message, err := warp.NewMessage(unsignedMessage, signature)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: failed to generate warp message", err)
+	}
+	return message, weight, signatureWeight, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestAggregateWarpSignatures(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		networkID = uint32(1)
+		chainID   = ids.GenerateTestID()
+		subnetID  = ids.GenerateTestID()
+	)
+	msg, err := warp.NewUnsignedMessage(networkID, chainID, []byte("hello"))
+	require.NoError(err)
+
+	// Create validators with weights 10, 20, and 30 (and one without a public
+	// key)
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{}
+	var (
+		publicKeys []*bls.PublicKey
+		signers    []warp.Signer
+	)
+	for i := 1; i <= 3; i++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		nodeID := ids.GenerateTestNodeID()
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    uint64(i * 10),
+		}
+		publicKeys = append(publicKeys, bls.PublicFromSecretKey(sk))
+		signers = append(signers, warp.NewSigner(sk, networkID, chainID))
+	}
+	nodeID := ids.GenerateTestNodeID()
+	vdrs[nodeID] = &validators.GetValidatorOutput{NodeID: nodeID, Weight: 40}
+
+	// Sign with validators 2 and 3 (and a non-validator)
+	signatures := []*chain.WarpSignature{}
+	for i := 1; i < 3; i++ {
+		sig, err := signers[i].Sign(msg)
+		require.NoError(err)
+		signatures = append(signatures, &chain.WarpSignature{
+			PublicKey: bls.PublicKeyToBytes(publicKeys[i]),
+			Signature: sig,
+		})
+	}
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	sig, err := warp.NewSigner(sk, networkID, chainID).Sign(msg)
+	require.NoError(err)
+	signatures = append(signatures, &chain.WarpSignature{
+		PublicKey: bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)),
+		Signature: sig,
+	})
+
+	weight, signatureWeight, err := WarpSignatureWeight(vdrs, signatures)
+	require.NoError(err)
+	require.Equal(uint64(100), weight)
+	require.Equal(uint64(50), signatureWeight)
+
+	message, weight, signatureWeight, err := AggregateWarpSignatures(msg, vdrs, signatures)
+	require.NoError(err)
+	require.Equal(uint64(100), weight)
+	require.Equal(uint64(50), signatureWeight)
+
+	// Ensure the aggregate signature can be verified
+	state := &validators.TestState{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+	require.NoError(message.Signature.Verify(context.Background(), &message.UnsignedMessage, networkID, state, 0, 50, 100))
+	require.ErrorIs(
+		message.Signature.Verify(context.Background(), &message.UnsignedMessage, networkID, state, 0, 51, 100),
+		warp.ErrInsufficientWeight,
+	)
+}
+
+func TestAggregateWarpSignaturesCanonicalOrder(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		networkID = uint32(1)
+		chainID   = ids.GenerateTestID()
+		subnetID  = ids.GenerateTestID()
+	)
+	msg, err := warp.NewUnsignedMessage(networkID, chainID, []byte("hello"))
+	require.NoError(err)
+
+	// The compressed public key of [sks[0]] sorts before that of [sks[1]] but
+	// its uncompressed public key sorts after
+	sks := make([]*bls.SecretKey, 2)
+	for i := range sks {
+		b := make([]byte, bls.SecretKeyLen)
+		b[len(b)-1] = byte(i + 1)
+		sks[i], err = bls.SecretKeyFromBytes(b)
+		require.NoError(err)
+	}
+	pk0, pk1 := bls.PublicFromSecretKey(sks[0]), bls.PublicFromSecretKey(sks[1])
+	require.Negative(bytes.Compare(bls.PublicKeyToBytes(pk0), bls.PublicKeyToBytes(pk1)))
+	require.Positive(bytes.Compare(bls.SerializePublicKey(pk0), bls.SerializePublicKey(pk1)))
+
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{}
+	for _, sk := range sks {
+		nodeID := ids.GenerateTestNodeID()
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    1,
+		}
+	}
+	canonicalValidators, _, err := getCanonicalValidatorSet(vdrs)
+	require.NoError(err)
+	require.Len(canonicalValidators, 2)
+	require.Equal(bls.PublicKeyToBytes(pk1), bls.PublicKeyToBytes(canonicalValidators[0].PublicKey))
+	require.Equal(bls.PublicKeyToBytes(pk0), bls.PublicKeyToBytes(canonicalValidators[1].PublicKey))
+
+	// Only [sks[0]] signs, so the aggregate signature can only be verified if
+	// the signer bit set uses the uncompressed ordering
+	sig, err := warp.NewSigner(sks[0], networkID, chainID).Sign(msg)
+	require.NoError(err)
+	message, _, signatureWeight, err := AggregateWarpSignatures(msg, vdrs, []*chain.WarpSignature{{
+		PublicKey: bls.PublicKeyToBytes(pk0),
+		Signature: sig,
+	}})
+	require.NoError(err)
+	require.Equal(uint64(1), signatureWeight)
+	state := &validators.TestState{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+	require.NoError(message.Signature.Verify(context.Background(), &message.UnsignedMessage, networkID, state, 0, 1, 2))
+}
@@ -28,6 +28,7 @@ type WebSocketClient struct {
 
 	pendingBlocks chan []byte
 	pendingTxs    chan []byte
+	pendingEvents chan []byte
 
 	startedClose bool
 	closed       bool
@@ -62,6 +63,7 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 		writeStopped:  make(chan struct{}),
 		pendingBlocks: make(chan []byte, pending),
 		pendingTxs:    make(chan []byte, pending),
+		pendingEvents: make(chan []byte, pending),
 	}
 	go func() {
 		defer close(wc.readStopped)
@@ -89,6 +91,8 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 					wc.pendingBlocks <- tmsg
 				case TxMode:
 					wc.pendingTxs <- tmsg
+				case EventMode:
+					wc.pendingEvents <- tmsg
 				default:
 					utils.Outf("{{orange}}unexpected message mode:{{/}} %x\n", msg[0])
 					continue
@@ -176,6 +180,32 @@ func (c *WebSocketClient) ListenTx(ctx context.Context) (ids.ID, error, *chain.R
 	}
 }
 
+// RegisterEvents subscribes to all events that match [f] (replacing any
+// previous subscription).
+func (c *WebSocketClient) RegisterEvents(f *EventFilter) error {
+	if c.closed {
+		return ErrClosed
+	}
+	msg, err := PackEventFilter(f)
+	if err != nil {
+		return err
+	}
+	return c.mb.Send(append([]byte{EventMode}, msg...))
+}
+
+// ListenEvents listens for the events in each accepted block that match the
+// registered filter (blocks without any matching events are skipped).
+func (c *WebSocketClient) ListenEvents(ctx context.Context) (uint64, []*TxEvent, error) {
+	select {
+	case msg := <-c.pendingEvents:
+		return UnpackEventsMessage(msg)
+	case <-c.readStopped:
+		return 0, nil, c.err
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+}
+
 // Close closes [c]'s connection to the decision rpc server.
 func (c *WebSocketClient) Close() error {
 	var err error
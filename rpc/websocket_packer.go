@@ -15,6 +15,11 @@ import (
 const (
 	BlockMode byte = 0
 	TxMode    byte = 1
+	EventMode byte = 2
+
+	// MaxEventFilterItems is the maximum number of topics and addresses
+	// (each) that can be included in an [EventFilter].
+	MaxEventFilterItems = 64
 )
 
 func PackBlockMessage(b *chain.StatelessBlock) ([]byte, error) {
@@ -104,3 +109,126 @@ func UnpackTxMessage(msg []byte) (ids.ID, error, *chain.Result, error) {
 	}
 	return txID, nil, result, p.Err()
 }
+
+// EventFilter selects which events are sent to a subscriber. An event matches
+// if it has any of [Topics] and its address is any of [Addresses] (an empty
+// list matches everything).
+type EventFilter struct {
+	Topics    []ids.ID
+	Addresses []codec.Address
+}
+
+func (f *EventFilter) Matches(e *chain.Event) bool {
+	if len(f.Addresses) > 0 {
+		found := false
+		for _, addr := range f.Addresses {
+			if addr == e.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Topics) == 0 {
+		return true
+	}
+	for _, topic := range f.Topics {
+		for _, etopic := range e.Topics {
+			if topic == etopic {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func PackEventFilter(f *EventFilter) ([]byte, error) {
+	if len(f.Topics) > MaxEventFilterItems || len(f.Addresses) > MaxEventFilterItems {
+		return nil, ErrFilterTooLarge
+	}
+	size := consts.IntLen + len(f.Topics)*consts.IDLen + consts.IntLen + len(f.Addresses)*codec.AddressLen
+	p := codec.NewWriter(size, consts.NetworkSizeLimit)
+	p.PackInt(len(f.Topics))
+	for _, topic := range f.Topics {
+		p.PackID(topic)
+	}
+	p.PackInt(len(f.Addresses))
+	for _, addr := range f.Addresses {
+		p.PackAddress(addr)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnpackEventFilter(msg []byte) (*EventFilter, error) {
+	p := codec.NewReader(msg, consts.NetworkSizeLimit)
+	f := &EventFilter{}
+	numTopics := p.UnpackInt(false)
+	if numTopics > MaxEventFilterItems {
+		return nil, ErrFilterTooLarge
+	}
+	for i := 0; i < numTopics; i++ {
+		var topic ids.ID
+		p.UnpackID(false, &topic)
+		f.Topics = append(f.Topics, topic)
+	}
+	numAddresses := p.UnpackInt(false)
+	if numAddresses > MaxEventFilterItems {
+		return nil, ErrFilterTooLarge
+	}
+	for i := 0; i < numAddresses; i++ {
+		var addr codec.Address
+		p.UnpackAddress(&addr)
+		f.Addresses = append(f.Addresses, addr)
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return f, p.Err()
+}
+
+// TxEvent is an [chain.Event] emitted by an accepted transaction.
+type TxEvent struct {
+	TxID  ids.ID
+	Event *chain.Event
+}
+
+// Packs the events in an accepted block that match a subscriber's filter
+func PackEventsMessage(height uint64, events []*TxEvent) ([]byte, error) {
+	size := consts.Uint64Len + consts.IntLen
+	for _, e := range events {
+		size += consts.IDLen + e.Event.Size()
+	}
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackUint64(height)
+	p.PackInt(len(events))
+	for _, e := range events {
+		p.PackID(e.TxID)
+		e.Event.Marshal(p)
+	}
+	return p.Bytes(), p.Err()
+}
+
+// Unpacks the height of the block the events were emitted in and the events
+// from [msg].
+func UnpackEventsMessage(msg []byte) (uint64, []*TxEvent, error) {
+	p := codec.NewReader(msg, consts.MaxInt)
+	height := p.UnpackUint64(false)
+	count := p.UnpackInt(false)
+	events := []*TxEvent{} // don't preallocate all to avoid DoS
+	for i := 0; i < count; i++ {
+		e := &TxEvent{}
+		p.UnpackID(true, &e.TxID)
+		event, err := chain.UnmarshalEvent(p)
+		if err != nil {
+			return 0, nil, err
+		}
+		e.Event = event
+		events = append(events, e)
+	}
+	if !p.Empty() {
+		return 0, nil, chain.ErrInvalidObject
+	}
+	return height, events, p.Err()
+}
@@ -6,6 +6,7 @@ package rpc
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -27,6 +28,9 @@ type WebSocketServer struct {
 	txL         sync.Mutex
 	txListeners map[ids.ID]*pubsub.Connections
 	expiringTxs *emap.EMap[*chain.Transaction] // ensures all tx listeners are eventually responded to
+
+	eventL         sync.Mutex
+	eventListeners map[*pubsub.Connection]*EventFilter
 }
 
 func NewWebSocketServer(vm VM, maxPendingMessages int) (*WebSocketServer, *pubsub.Server) {
@@ -35,6 +39,7 @@ func NewWebSocketServer(vm VM, maxPendingMessages int) (*WebSocketServer, *pubsu
 		blockListeners: pubsub.NewConnections(),
 		txListeners:    map[ids.ID]*pubsub.Connections{},
 		expiringTxs:    emap.NewEMap[*chain.Transaction](),
+		eventListeners: map[*pubsub.Connection]*EventFilter{},
 	}
 	cfg := pubsub.NewDefaultServerConfig()
 	cfg.MaxPendingMessages = maxPendingMessages
@@ -57,6 +62,15 @@ func (w *WebSocketServer) AddTxListener(tx *chain.Transaction, c *pubsub.Connect
 	w.expiringTxs.Add([]*chain.Transaction{tx})
 }
 
+// AddEventListener sends all events that match [f] to [c]. If [c] was
+// already listening for events, its previous filter is replaced.
+func (w *WebSocketServer) AddEventListener(f *EventFilter, c *pubsub.Connection) {
+	w.eventL.Lock()
+	defer w.eventL.Unlock()
+
+	w.eventListeners[c] = f
+}
+
 // If never possible for a tx to enter mempool, call this
 func (w *WebSocketServer) RemoveTx(txID ids.ID, err error) error {
 	w.txL.Lock()
@@ -108,6 +122,10 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 		}
 	}
 
+	if err := w.publishEvents(b); err != nil {
+		return err
+	}
+
 	w.txL.Lock()
 	defer w.txL.Unlock()
 	results := b.Results()
@@ -129,6 +147,49 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 	return nil
 }
 
+func (w *WebSocketServer) publishEvents(b *chain.StatelessBlock) error {
+	w.eventL.Lock()
+	defer w.eventL.Unlock()
+
+	// Remove the listeners of closed connections on every block (like
+	// [blockListeners]) instead of only when an event would be published to
+	// them
+	active := w.s.Connections()
+	for c := range w.eventListeners {
+		if !active.Has(c) {
+			delete(w.eventListeners, c)
+		}
+	}
+	if len(w.eventListeners) == 0 {
+		return nil
+	}
+	results := b.Results()
+	for c, f := range w.eventListeners {
+		events := []*TxEvent{}
+		for i, tx := range b.Txs {
+			for _, event := range results[i].Events {
+				if !f.Matches(event) {
+					continue
+				}
+				events = append(events, &TxEvent{TxID: tx.ID(), Event: event})
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		bytes, err := PackEventsMessage(b.Height(), events)
+		if err != nil {
+			return err
+		}
+		conns := pubsub.NewConnections()
+		conns.Add(c)
+		if inactive := w.s.Publish(append([]byte{EventMode}, bytes...), conns); len(inactive) > 0 {
+			delete(w.eventListeners, c)
+		}
+	}
+	return nil
+}
+
 func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 	// Assumes controller is initialized before this is called
 	var (
@@ -155,11 +216,22 @@ func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 		case BlockMode:
 			w.blockListeners.Add(c)
 			log.Debug("added block listener")
+		case EventMode:
+			f, err := UnpackEventFilter(msgBytes[1:])
+			if err != nil {
+				log.Error("failed to unmarshal event filter",
+					zap.Int("len", len(msgBytes)),
+					zap.Error(err),
+				)
+				return
+			}
+			w.AddEventListener(f, c)
+			log.Debug("added event listener")
 		case TxMode:
 			msgBytes = msgBytes[1:]
 			// Unmarshal TX
 			p := codec.NewReader(msgBytes, consts.NetworkSizeLimit) // will likely be much smaller
-			tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+			tx, err := chain.UnmarshalTx(p, time.Now().UnixMilli(), actionRegistry, authRegistry)
 			if err != nil {
 				log.Error("failed to unmarshal tx",
 					zap.Int("len", len(msgBytes)),
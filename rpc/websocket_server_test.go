@@ -0,0 +1,62 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// testWebSocketVM is the subset of [VM] used by [WebSocketServer].
+type testWebSocketVM struct {
+	VM
+}
+
+func (*testWebSocketVM) Tracer() trace.Tracer { return trace.Noop }
+
+func (*testWebSocketVM) Logger() logging.Logger { return logging.NoLog{} }
+
+func (*testWebSocketVM) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return codec.NewTypeParser[chain.Action, *warp.Message](), codec.NewTypeParser[chain.Auth, *warp.Message]()
+}
+
+func TestWebSocketServerRemovesClosedEventListeners(t *testing.T) {
+	require := require.New(t)
+
+	w, s := NewWebSocketServer(&testWebSocketVM{}, 16)
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, s)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, 16, 1024)
+	require.NoError(err)
+	require.NoError(cli.RegisterEvents(&EventFilter{}))
+	require.Eventually(func() bool {
+		w.eventL.Lock()
+		defer w.eventL.Unlock()
+		return len(w.eventListeners) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The listener is removed once the connection is closed, even though no
+	// events are published to it
+	require.NoError(cli.Close())
+	require.Eventually(func() bool {
+		return s.Connections().Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(w.AcceptBlock(&chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{}}))
+	w.eventL.Lock()
+	defer w.eventL.Unlock()
+	require.Empty(w.eventListeners)
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import "errors"
+
+var (
+	ErrInvalidVersion  = errors.New("invalid version")
+	ErrInvalidRecord   = errors.New("invalid record")
+	ErrRecordTooLarge  = errors.New("record too large")
+	ErrUnexpectedCount = errors.New("unexpected count")
+	ErrClosed          = errors.New("closed")
+)
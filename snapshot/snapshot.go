@@ -0,0 +1,263 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package snapshot implements a portable file format for the state of a
+// hypervm at an accepted height (and the blocks needed to resume from it).
+//
+// A snapshot is a gzip-compressed stream of length-prefixed records: a JSON
+// [Manifest], any number of blocks and key-value pairs (in that order), and
+// a trailer with the number of each.
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	Version = 0
+
+	// MaxRecordSize is the largest record that can be read from a snapshot.
+	MaxRecordSize = 64 * units.MiB
+)
+
+type RecordType uint8
+
+const (
+	manifestRecord RecordType = iota
+	BlockRecord
+	KeyValueRecord
+	trailerRecord
+)
+
+// Manifest describes the contents of a snapshot.
+type Manifest struct {
+	Version   uint8  `json:"version"`
+	NetworkID uint32 `json:"networkId"`
+	ChainID   ids.ID `json:"chainId"`
+
+	// Height, BlockID, and Timestamp identify the accepted block whose
+	// post-execution state is in the snapshot.
+	Height    uint64 `json:"height"`
+	BlockID   ids.ID `json:"blockId"`
+	Timestamp int64  `json:"timestamp"`
+
+	// StateRoot is the root of the post-execution state of [BlockID] (the
+	// [StateRoot] of its child).
+	StateRoot    ids.ID                `json:"stateRoot"`
+	BranchFactor merkledb.BranchFactor `json:"branchFactor"`
+}
+
+// Record is a block or key-value pair read from a snapshot.
+type Record struct {
+	Type RecordType
+
+	// Height and Block are populated for a [BlockRecord]
+	Height uint64
+	Block  []byte
+
+	// Key and Value are populated for a [KeyValueRecord]
+	Key   []byte
+	Value []byte
+}
+
+// Writer writes a snapshot. [Writer.Close] must be called to write the
+// trailer (a snapshot without one can't be read).
+type Writer struct {
+	gz *gzip.Writer
+
+	blocks    uint64
+	keyValues uint64
+	closed    bool
+}
+
+func NewWriter(w io.Writer, m *Manifest) (*Writer, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	sw := &Writer{gz: gzip.NewWriter(w)}
+	if err := sw.writeRecord(manifestRecord, b); err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+func (w *Writer) writeRecord(t RecordType, payloads ...[]byte) error {
+	if w.closed {
+		return ErrClosed
+	}
+	var size int
+	for _, payload := range payloads {
+		size += len(payload)
+	}
+	header := make([]byte, consts.Uint8Len+consts.Uint32Len)
+	header[0] = byte(t)
+	binary.BigEndian.PutUint32(header[consts.Uint8Len:], uint32(size))
+	if _, err := w.gz.Write(header); err != nil {
+		return err
+	}
+	for _, payload := range payloads {
+		if _, err := w.gz.Write(payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBlock writes the encoded block at [height]. All blocks must be written
+// before any key-value pairs.
+func (w *Writer) WriteBlock(height uint64, blk []byte) error {
+	if w.keyValues > 0 {
+		return fmt.Errorf("%w: block after key-value pairs", ErrInvalidRecord)
+	}
+	w.blocks++
+	return w.writeRecord(BlockRecord, binary.BigEndian.AppendUint64(nil, height), blk)
+}
+
+func (w *Writer) WriteKeyValue(key []byte, value []byte) error {
+	w.keyValues++
+	return w.writeRecord(KeyValueRecord, binary.BigEndian.AppendUint32(nil, uint32(len(key))), key, value)
+}
+
+// Close writes the trailer and flushes the snapshot. It does not close the
+// underlying [io.Writer].
+func (w *Writer) Close() error {
+	trailer := binary.BigEndian.AppendUint64(nil, w.blocks)
+	trailer = binary.BigEndian.AppendUint64(trailer, w.keyValues)
+	if err := w.writeRecord(trailerRecord, trailer); err != nil {
+		return err
+	}
+	w.closed = true
+	return w.gz.Close()
+}
+
+// Reader reads a snapshot written by [Writer].
+type Reader struct {
+	gz       *gzip.Reader
+	r        *bufio.Reader
+	manifest *Manifest
+
+	blocks    uint64
+	keyValues uint64
+	done      bool
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sr := &Reader{gz: gz, r: bufio.NewReader(gz)}
+	t, b, err := sr.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	if t != manifestRecord {
+		return nil, fmt.Errorf("%w: expected manifest but got %d", ErrInvalidRecord, t)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, m.Version)
+	}
+	sr.manifest = &m
+	return sr, nil
+}
+
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+func (r *Reader) readRecord() (RecordType, []byte, error) {
+	header := make([]byte, consts.Uint8Len+consts.Uint32Len)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[consts.Uint8Len:])
+	if size > MaxRecordSize {
+		return 0, nil, fmt.Errorf("%w: %d", ErrRecordTooLarge, size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return 0, nil, err
+	}
+	return RecordType(header[0]), b, nil
+}
+
+// Next returns the next block or key-value pair in the snapshot. Once the
+// trailer is read (and matches the records read), [io.EOF] is returned.
+//
+// If the snapshot is truncated, [io.ErrUnexpectedEOF] is returned.
+func (r *Reader) Next() (*Record, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	t, b, err := r.readRecord()
+	if err == io.EOF { //nolint:errorlint
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case BlockRecord:
+		if r.keyValues > 0 || len(b) < consts.Uint64Len {
+			return nil, ErrInvalidRecord
+		}
+		r.blocks++
+		return &Record{
+			Type:   BlockRecord,
+			Height: binary.BigEndian.Uint64(b),
+			Block:  b[consts.Uint64Len:],
+		}, nil
+	case KeyValueRecord:
+		if len(b) < consts.Uint32Len {
+			return nil, ErrInvalidRecord
+		}
+		keyLen := uint64(binary.BigEndian.Uint32(b))
+		if uint64(len(b)-consts.Uint32Len) < keyLen {
+			return nil, ErrInvalidRecord
+		}
+		r.keyValues++
+		kv := b[consts.Uint32Len:]
+		return &Record{
+			Type:  KeyValueRecord,
+			Key:   kv[:keyLen],
+			Value: kv[keyLen:],
+		}, nil
+	case trailerRecord:
+		if len(b) != 2*consts.Uint64Len {
+			return nil, ErrInvalidRecord
+		}
+		blocks, keyValues := binary.BigEndian.Uint64(b), binary.BigEndian.Uint64(b[consts.Uint64Len:])
+		if blocks != r.blocks || keyValues != r.keyValues {
+			return nil, fmt.Errorf(
+				"%w: expected %d blocks and %d key-values but got %d and %d",
+				ErrUnexpectedCount, blocks, keyValues, r.blocks, r.keyValues,
+			)
+		}
+		r.done = true
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("%w: unknown type %d", ErrInvalidRecord, t)
+	}
+}
+
+// Close releases the resources of [r]. It does not close the underlying
+// [io.Reader].
+func (r *Reader) Close() error {
+	return r.gz.Close()
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	m := &Manifest{
+		Version:      Version,
+		ChainID:      ids.GenerateTestID(),
+		Height:       10,
+		BlockID:      ids.GenerateTestID(),
+		StateRoot:    ids.GenerateTestID(),
+		BranchFactor: merkledb.BranchFactor16,
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, m)
+	require.NoError(err)
+	require.NoError(w.WriteBlock(9, []byte{9}))
+	require.NoError(w.WriteBlock(10, []byte{10}))
+	require.NoError(w.WriteKeyValue([]byte{1}, []byte{2, 3}))
+	require.NoError(w.WriteKeyValue([]byte{4, 5}, nil))
+	require.ErrorIs(w.WriteBlock(11, []byte{11}), ErrInvalidRecord)
+	require.NoError(w.Close())
+	b := buf.Bytes()
+
+	r, err := NewReader(bytes.NewReader(b))
+	require.NoError(err)
+	require.Equal(m, r.Manifest())
+	var records []*Record
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		records = append(records, record)
+	}
+	require.NoError(r.Close())
+	require.Equal([]*Record{
+		{Type: BlockRecord, Height: 9, Block: []byte{9}},
+		{Type: BlockRecord, Height: 10, Block: []byte{10}},
+		{Type: KeyValueRecord, Key: []byte{1}, Value: []byte{2, 3}},
+		{Type: KeyValueRecord, Key: []byte{4, 5}, Value: []byte{}},
+	}, records)
+
+	// Truncated snapshots can't be read completely
+	var truncated bytes.Buffer
+	w, err = NewWriter(&truncated, m)
+	require.NoError(err)
+	require.NoError(w.WriteBlock(10, []byte{10}))
+	require.NoError(w.gz.Close()) // no trailer
+	r, err = NewReader(&truncated)
+	require.NoError(err)
+	_, err = r.Next()
+	require.NoError(err)
+	_, err = r.Next()
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
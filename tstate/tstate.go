@@ -50,6 +50,15 @@ func (ts *TState) PendingChanges() int {
 	return len(ts.changedKeys)
 }
 
+// ChangedKeys returns the new value of each key modified in [ts] (Nothing if
+// the key was deleted). The returned map must not be modified.
+func (ts *TState) ChangedKeys() map[string]maybe.Maybe[[]byte] {
+	ts.l.RLock()
+	defer ts.l.RUnlock()
+
+	return ts.changedKeys
+}
+
 // OpIndex returns the number of operations done on ts.
 func (ts *TState) OpIndex() int {
 	ts.l.RLock()
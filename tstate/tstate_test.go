@@ -613,3 +613,107 @@ func TestCreateView(t *testing.T) {
 		require.ErrorIs(err, database.ErrNotFound, "value not removed from db")
 	}
 }
+
+func TestTrackedViewValidate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+	scope := set.Of(key1str, key2str, key3str)
+	storage := map[string][]byte{key1str: testVal}
+
+	// Populate speculative views before anything is committed
+	reader := ts.NewTrackedView(scope, storage)
+	v, err := reader.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, v)
+	writer := ts.NewTrackedView(scope, storage)
+	require.NoError(writer.Insert(ctx, key2, testVal))
+	unrelated := ts.NewTrackedView(scope, storage)
+	require.NoError(unrelated.Remove(ctx, key3)) // doesn't exist
+	require.True(reader.Validate(ctx))
+	require.True(writer.Validate(ctx))
+
+	// Modify the key read by [reader]
+	first := ts.NewTrackedView(scope, storage)
+	require.NoError(first.Insert(ctx, key1, []byte("value2")))
+	require.True(first.Validate(ctx))
+	first.Commit()
+	require.False(reader.Validate(ctx))
+	require.True(writer.Validate(ctx))
+	require.True(unrelated.Validate(ctx))
+
+	// Reads are pinned to the first value seen
+	v, err = reader.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, v)
+
+	// Create the key allocated by [writer]
+	second := ts.NewTrackedView(scope, storage)
+	require.NoError(second.Insert(ctx, key2, []byte("value3")))
+	second.Commit()
+	require.False(writer.Validate(ctx))
+	require.True(unrelated.Validate(ctx))
+
+	// Untracked views are always valid
+	untracked := ts.NewView(scope, storage)
+	_, err = untracked.GetValue(ctx, key1)
+	require.NoError(err)
+	require.True(untracked.Validate(ctx))
+}
+
+func TestTrackedViewTouchedKeys(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+	scope := set.Of(key1str, key2str, key3str)
+	storage := map[string][]byte{key1str: testVal}
+
+	tsv := ts.NewTrackedView(scope, storage)
+	_, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.NoError(tsv.Insert(ctx, key2, testVal))
+	require.Equal(set.Of(key1str, key2str), tsv.TouchedKeys())
+
+	// Keys touched by rolled back operations are still included
+	idx := tsv.OpIndex()
+	require.NoError(tsv.Remove(ctx, key3))
+	tsv.Rollback(ctx, idx)
+	require.Equal(set.Of(key1str, key2str, key3str), tsv.TouchedKeys())
+
+	// Untracked views never record touched keys
+	untracked := ts.NewView(scope, storage)
+	_, err = untracked.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Empty(untracked.TouchedKeys())
+}
+
+func TestTracing(t *testing.T) {
+	require := require.New(t)
+	ts := New(10)
+	ctx := context.TODO()
+
+	// Untraced views don't record accesses
+	tsv := ts.NewView(set.Of(key1str, key2str), map[string][]byte{key1str: testVal})
+	_, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Nil(tsv.Accesses())
+
+	tsv = ts.NewView(set.Of(key1str, key2str), map[string][]byte{key1str: testVal})
+	tsv.EnableTracing()
+	v, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, v)
+	require.NoError(tsv.Insert(ctx, key2, []byte("new")))
+	restorePoint := tsv.OpIndex()
+	require.NoError(tsv.Remove(ctx, key1))
+	tsv.Rollback(ctx, restorePoint)
+	_, err = tsv.GetValue(ctx, []byte("missing"))
+	require.ErrorIs(err, ErrKeyNotSpecified)
+
+	// Accesses are recorded in order (including those rolled back)
+	require.Equal([]*Access{
+		{Type: ReadAccess, Key: key1, Before: maybe.Some(testVal), After: maybe.Some(testVal)},
+		{Type: InsertAccess, Key: key2, Before: maybe.Nothing[[]byte](), After: maybe.Some([]byte("new"))},
+		{Type: RemoveAccess, Key: key1, Before: maybe.Some(testVal), After: maybe.Nothing[[]byte]()},
+	}, tsv.Accesses())
+}
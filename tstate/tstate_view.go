@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/keys"
+	"golang.org/x/exp/slices"
 )
 
 const defaultOps = 4
@@ -23,6 +24,24 @@ const (
 	removeOp opType = 2
 )
 
+// AccessType is the kind of [Access].
+type AccessType uint8
+
+const (
+	ReadAccess   AccessType = 0
+	InsertAccess AccessType = 1
+	RemoveAccess AccessType = 2
+)
+
+// Access is a read or modification of a key recorded by a traced view (see
+// [TStateView.EnableTracing]).
+type Access struct {
+	Type   AccessType
+	Key    []byte
+	Before maybe.Maybe[[]byte]
+	After  maybe.Maybe[[]byte]
+}
+
 type op struct {
 	t             opType
 	k             string
@@ -47,6 +66,17 @@ type TStateView struct {
 	canAllocate bool
 	allocates   map[string]uint16
 	writes      map[string]uint16
+
+	// reads is populated with the first value read from the parent
+	// [TState] (or [scopeStorage]) for each key if the view is tracked.
+	// Subsequent reads of the same key return this value, so a tracked view
+	// is never affected by concurrent commits to the parent.
+	reads map[string]maybe.Maybe[[]byte]
+
+	// accesses is populated with every key access (in order) if the view is
+	// traced.
+	tracing  bool
+	accesses []*Access
 }
 
 func (ts *TState) NewView(scope set.Set[string], storage map[string][]byte) *TStateView {
@@ -65,6 +95,15 @@ func (ts *TState) NewView(scope set.Set[string], storage map[string][]byte) *TSt
 	}
 }
 
+// NewTrackedView returns a [TStateView] that records the values read from
+// [ts]. This allows the view to be speculatively populated while other views
+// are committed to [ts] and then checked with [Validate] before calling [Commit].
+func (ts *TState) NewTrackedView(scope set.Set[string], storage map[string][]byte) *TStateView {
+	tsv := ts.NewView(scope, storage)
+	tsv.reads = make(map[string]maybe.Maybe[[]byte], len(scope))
+	return tsv
+}
+
 // Rollback restores the TState to the ts.op[restorePoint] operation.
 func (ts *TStateView) Rollback(_ context.Context, restorePoint int) {
 	for i := len(ts.ops) - 1; i >= restorePoint; i-- {
@@ -147,6 +186,39 @@ func (ts *TStateView) EnableAllocation() {
 	ts.canAllocate = true
 }
 
+// EnableTracing causes every read and modification of a key to be recorded
+// (including those in operations that are rolled back).
+func (ts *TStateView) EnableTracing() {
+	ts.tracing = true
+}
+
+// Accesses returns the keys accessed by a traced view, in order.
+//
+// Accesses always returns nil for views that are not traced.
+func (ts *TStateView) Accesses() []*Access {
+	return ts.accesses
+}
+
+// trace records an access of [key] if the view is traced.
+func (ts *TStateView) trace(t AccessType, key string, before []byte, beforeExists bool, after []byte, afterExists bool) {
+	if !ts.tracing {
+		return
+	}
+	access := &Access{
+		Type:   t,
+		Key:    []byte(key),
+		Before: maybe.Nothing[[]byte](),
+		After:  maybe.Nothing[[]byte](),
+	}
+	if beforeExists {
+		access.Before = maybe.Some(slices.Clone(before))
+	}
+	if afterExists {
+		access.After = maybe.Some(slices.Clone(after))
+	}
+	ts.accesses = append(ts.accesses, access)
+}
+
 // KeyOperations returns the number of operations performed since the scope
 // was last set.
 //
@@ -171,6 +243,7 @@ func (ts *TStateView) GetValue(ctx context.Context, key []byte) ([]byte, error)
 	}
 	k := string(key)
 	v, exists := ts.getValue(ctx, k)
+	ts.trace(ReadAccess, k, v, exists, v, exists)
 	if !exists {
 		return nil, database.ErrNotFound
 	}
@@ -184,6 +257,29 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 		}
 		return v.Value(), true
 	}
+	return ts.getParentValue(ctx, key)
+}
+
+// getParentValue returns the value of [key] in the parent view (or
+// scope if the parent is unchanged).
+func (ts *TStateView) getParentValue(ctx context.Context, key string) ([]byte, bool) {
+	if ts.reads != nil {
+		if v, ok := ts.reads[key]; ok {
+			return v.Value(), v.HasValue()
+		}
+	}
+	v, exists := ts.readParent(ctx, key)
+	if ts.reads != nil {
+		if exists {
+			ts.reads[key] = maybe.Some(v)
+		} else {
+			ts.reads[key] = maybe.Nothing[[]byte]()
+		}
+	}
+	return v, exists
+}
+
+func (ts *TStateView) readParent(ctx context.Context, key string) ([]byte, bool) {
 	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
 		return v, exists
 	}
@@ -196,13 +292,35 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 // isUnchanged determines if a [key] is unchanged from the parent view (or
 // scope if the parent is unchanged).
 func (ts *TStateView) isUnchanged(ctx context.Context, key string, nval []byte, nexists bool) bool {
-	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
-		return !exists && !nexists || exists && nexists && bytes.Equal(v, nval)
+	v, exists := ts.getParentValue(ctx, key)
+	return !exists && !nexists || exists && nexists && bytes.Equal(v, nval)
+}
+
+// Validate returns whether all values read by a tracked view are still
+// the same in the parent [TState]. If they are, committing the view has the same
+// result as if the view was populated after all previously committed views.
+//
+// Validate always returns true for views that are not tracked.
+func (ts *TStateView) Validate(ctx context.Context) bool {
+	for k, v := range ts.reads {
+		current, exists := ts.readParent(ctx, k)
+		if exists != v.HasValue() || !bytes.Equal(current, v.Value()) {
+			return false
+		}
 	}
-	if v, ok := ts.scopeStorage[key]; ok {
-		return nexists && bytes.Equal(v, nval)
+	return true
+}
+
+// TouchedKeys returns all keys read or modified by a tracked view (including
+// those in operations that were rolled back).
+//
+// TouchedKeys always returns an empty set for views that are not tracked.
+func (ts *TStateView) TouchedKeys() set.Set[string] {
+	touched := set.NewSet[string](len(ts.reads))
+	for k := range ts.reads {
+		touched.Add(k)
 	}
-	return !nexists
+	return touched
 }
 
 // Insert allocates and writes (or just writes) a new key to [tstate]. If this
@@ -226,6 +344,7 @@ func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) erro
 	if exists {
 		if bytes.Equal(past, value) {
 			// No change, so this isn't an op.
+			ts.trace(InsertAccess, k, past, true, value, true)
 			return nil
 		}
 		op.t = insertOp
@@ -239,6 +358,7 @@ func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) erro
 		ts.allocates[k] = keyChunks
 		ts.writes[k] = valueChunks
 	}
+	ts.trace(InsertAccess, k, past, exists, value, true)
 	ts.ops = append(ts.ops, op)
 	ts.pendingChangedKeys[k] = maybe.Some(value)
 	if ts.isUnchanged(ctx, k, value, true) {
@@ -257,6 +377,7 @@ func (ts *TStateView) Remove(ctx context.Context, key []byte) error {
 	}
 	k := string(key)
 	past, exists := ts.getValue(ctx, k)
+	ts.trace(RemoveAccess, k, past, exists, nil, false)
 	if !exists {
 		// We do not update writes if the key does not exist.
 		return nil
@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgrade
+
+import "errors"
+
+var (
+	ErrMissingName         = errors.New("missing name")
+	ErrDuplicateName       = errors.New("duplicate name")
+	ErrInvalidTimestamp    = errors.New("invalid timestamp")
+	ErrUnorderedTimestamps = errors.New("upgrade timestamps must be increasing")
+	ErrNotUpgradable       = errors.New("param can not be changed by an upgrade")
+)
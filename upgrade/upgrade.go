@@ -0,0 +1,164 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package upgrade implements a schedule of network upgrades (forks) that
+// activate at block timestamps.
+//
+// A schedule is provided to a hypervm in its upgradeBytes:
+//
+//	{"upgrades":[{"name":"durango","timestamp":1700000000000,"params":{"minBlockGap":250}}]}
+//
+// Each upgrade may override the parameters of the previous one that the
+// hypervm allows to be upgraded (see [Apply]) and may be used to gate new
+// [chain.Action] and [chain.Auth] types with [ValidRange].
+package upgrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+type Upgrade struct {
+	Name string `json:"name"`
+
+	// Timestamp is the first block timestamp (in milliseconds) that the
+	// upgrade is active at.
+	Timestamp int64 `json:"timestamp"`
+
+	// Params are applied to the parameters of the previous upgrade (or
+	// genesis, if this is the first upgrade).
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Schedule is a list of upgrades ordered by activation.
+type Schedule []*Upgrade
+
+type config struct {
+	Upgrades Schedule `json:"upgrades"`
+}
+
+// Parse returns the [Schedule] in [b]. An empty schedule is returned if [b]
+// is empty.
+func Parse(b []byte) (Schedule, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var c config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upgrades %s: %w", string(b), err)
+	}
+	if err := c.Upgrades.Verify(); err != nil {
+		return nil, err
+	}
+	return c.Upgrades, nil
+}
+
+// Verify ensures each upgrade in [s] has a unique name and activates after
+// the previous one.
+func (s Schedule) Verify() error {
+	names := make(map[string]struct{}, len(s))
+	for i, u := range s {
+		if len(u.Name) == 0 {
+			return fmt.Errorf("%w: upgrade %d", ErrMissingName, i)
+		}
+		if _, ok := names[u.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateName, u.Name)
+		}
+		names[u.Name] = struct{}{}
+		if u.Timestamp < 0 {
+			return fmt.Errorf("%w: %s=%d", ErrInvalidTimestamp, u.Name, u.Timestamp)
+		}
+		if i > 0 && u.Timestamp <= s[i-1].Timestamp {
+			return fmt.Errorf("%w: %s=%d <= %s=%d", ErrUnorderedTimestamps, u.Name, u.Timestamp, s[i-1].Name, s[i-1].Timestamp)
+		}
+	}
+	return nil
+}
+
+// Active returns the index of the most recent upgrade active at [t] (or -1,
+// if none are active).
+func (s Schedule) Active(t int64) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if t >= s[i].Timestamp {
+			return i
+		}
+	}
+	return -1
+}
+
+// Timestamp returns the activation timestamp of the upgrade named [name] (if
+// it is scheduled).
+func (s Schedule) Timestamp(name string) (int64, bool) {
+	for _, u := range s {
+		if u.Name == name {
+			return u.Timestamp, true
+		}
+	}
+	return 0, false
+}
+
+// Apply returns the parameters of each upgrade in [s], which are created by
+// applying the [Upgrade.Params] of each upgrade (in order) to a copy of the
+// parameters before it (starting with [base]).
+//
+// Only the parameters named (by their JSON key) in [upgradable] may be set in
+// [Upgrade.Params]. Any other parameter (including one that doesn't exist)
+// returns [ErrNotUpgradable].
+//
+// Parameters are copied by their JSON encoding, so fields that are not
+// encoded are not copied.
+func Apply[T any](s Schedule, base *T, upgradable []string) ([]*T, error) {
+	forks := make([]*T, len(s))
+	prev, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	for i, u := range s {
+		fork := new(T)
+		if err := json.Unmarshal(prev, fork); err != nil {
+			return nil, err
+		}
+		if len(u.Params) > 0 {
+			var params map[string]json.RawMessage
+			if err := json.Unmarshal(u.Params, &params); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal params of %s: %w", u.Name, err)
+			}
+			keys := maps.Keys(params)
+			slices.Sort(keys)
+			for _, k := range keys {
+				if !slices.Contains(upgradable, k) {
+					return nil, fmt.Errorf("%w: %s in %s", ErrNotUpgradable, k, u.Name)
+				}
+			}
+			if err := json.Unmarshal(u.Params, fork); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal params of %s: %w", u.Name, err)
+			}
+		}
+		prev, err = json.Marshal(fork)
+		if err != nil {
+			return nil, err
+		}
+		forks[i] = fork
+	}
+	return forks, nil
+}
+
+// ValidRange returns the range of timestamps a [chain.Action] or [chain.Auth]
+// that is introduced by the upgrade named [name] is valid in. It can be
+// returned by [chain.Action.ValidRange] to only allow a type after the
+// upgrade activates.
+//
+// If [name] is not scheduled, the type is never valid.
+func ValidRange(r chain.Rules, name string) (int64, int64) {
+	t, ok := r.GetUpgradeTimestamp(name)
+	if !ok {
+		return math.MaxInt64, -1
+	}
+	return t, -1
+}
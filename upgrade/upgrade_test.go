@@ -0,0 +1,123 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgrade
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+type params struct {
+	A uint64   `json:"a"`
+	B []uint16 `json:"b"`
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		b    string
+		err  error
+	}{
+		{
+			name: "empty",
+			b:    "",
+		},
+		{
+			name: "valid",
+			b:    `{"upgrades":[{"name":"a","timestamp":10},{"name":"b","timestamp":20,"params":{"a":1}}]}`,
+		},
+		{
+			name: "missing name",
+			b:    `{"upgrades":[{"timestamp":10}]}`,
+			err:  ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			b:    `{"upgrades":[{"name":"a","timestamp":10},{"name":"a","timestamp":20}]}`,
+			err:  ErrDuplicateName,
+		},
+		{
+			name: "negative timestamp",
+			b:    `{"upgrades":[{"name":"a","timestamp":-1}]}`,
+			err:  ErrInvalidTimestamp,
+		},
+		{
+			name: "unordered timestamps",
+			b:    `{"upgrades":[{"name":"a","timestamp":20},{"name":"b","timestamp":20}]}`,
+			err:  ErrUnorderedTimestamps,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.b))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestActive(t *testing.T) {
+	require := require.New(t)
+
+	s, err := Parse([]byte(`{"upgrades":[{"name":"a","timestamp":10},{"name":"b","timestamp":20}]}`))
+	require.NoError(err)
+	require.Equal(-1, s.Active(9))
+	require.Equal(0, s.Active(10))
+	require.Equal(0, s.Active(19))
+	require.Equal(1, s.Active(20))
+	require.Equal(-1, Schedule(nil).Active(20))
+
+	ts, ok := s.Timestamp("b")
+	require.True(ok)
+	require.Equal(int64(20), ts)
+	_, ok = s.Timestamp("c")
+	require.False(ok)
+}
+
+func TestApply(t *testing.T) {
+	require := require.New(t)
+
+	s, err := Parse([]byte(`{"upgrades":[{"name":"a","timestamp":10,"params":{"a":2}},{"name":"b","timestamp":20,"params":{"b":[3]}}]}`))
+	require.NoError(err)
+	base := &params{A: 1, B: []uint16{1, 2}}
+	forks, err := Apply(s, base, []string{"a", "b"})
+	require.NoError(err)
+	require.Equal([]*params{
+		{A: 2, B: []uint16{1, 2}},
+		{A: 2, B: []uint16{3}},
+	}, forks)
+
+	// Ensure [base] was not modified
+	require.Equal(&params{A: 1, B: []uint16{1, 2}}, base)
+
+	// Only upgradable params can be set
+	_, err = Apply(s, base, []string{"a"})
+	require.ErrorIs(err, ErrNotUpgradable)
+	for _, p := range []string{`{"A":2}`, `{"c":1}`} {
+		s, err = Parse([]byte(`{"upgrades":[{"name":"a","timestamp":10,"params":` + p + `}]}`))
+		require.NoError(err)
+		_, err = Apply(s, base, []string{"a", "b"})
+		require.ErrorIs(err, ErrNotUpgradable)
+	}
+}
+
+func TestValidRange(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	r := chain.NewMockRules(ctrl)
+	r.EXPECT().GetUpgradeTimestamp("a").Return(int64(10), true)
+	r.EXPECT().GetUpgradeTimestamp("b").Return(int64(0), false)
+
+	start, end := ValidRange(r, "a")
+	require.Equal(int64(10), start)
+	require.Equal(int64(-1), end)
+	start, end = ValidRange(r, "b")
+	require.Equal(int64(math.MaxInt64), start)
+	require.Equal(int64(-1), end)
+}
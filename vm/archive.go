@@ -0,0 +1,102 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const stateHistoryPrefix = 0x9 // key + ^height -> value (archive only)
+
+// isArchive is set when an archive node is initialized from genesis (and
+// removed if archive mode is disabled). It ensures we never serve incomplete
+// state history.
+var isArchive = []byte("is_archive")
+
+// PrefixStateHistoryKey returns the key of the value [key] was set to by the
+// block at [height].
+//
+// Heights are inverted so that the first key at or after [height] is the most
+// recent change at or before [height].
+func PrefixStateHistoryKey(key []byte, height uint64) []byte {
+	k := make([]byte, 1+consts.Uint16Len+len(key)+consts.Uint64Len)
+	k[0] = stateHistoryPrefix
+	binary.BigEndian.PutUint16(k[1:], uint16(len(key)))
+	copy(k[1+consts.Uint16Len:], key)
+	binary.BigEndian.PutUint64(k[1+consts.Uint16Len+len(key):], ^height)
+	return k
+}
+
+// putStateHistory records that [key] was set to [value] (or deleted, if
+// Nothing) by the block at [height] and returns the number of bytes written.
+func putStateHistory(batch database.Batch, height uint64, key []byte, value maybe.Maybe[[]byte]) (int, error) {
+	if len(key) > int(consts.MaxUint16) {
+		return 0, ErrKeyTooLarge
+	}
+	k := PrefixStateHistoryKey(key, height)
+	v := make([]byte, consts.BoolLen+len(value.Value()))
+	if value.HasValue() {
+		v[0] = 0x1
+		copy(v[1:], value.Value())
+	}
+	if err := batch.Put(k, v); err != nil {
+		return 0, err
+	}
+	return len(k) + len(v), nil
+}
+
+// archiveGenesis records the value of every key in the genesis state (which
+// is not modified by any block) and marks [vmDB] as an archive.
+func (vm *VM) archiveGenesis() error {
+	batch := vm.vmDB.NewBatch()
+	iter := vm.stateDB.NewIterator()
+	defer iter.Release()
+
+	var size int
+	for iter.Next() {
+		n, err := putStateHistory(batch, 0, iter.Key(), maybe.Some(iter.Value()))
+		if err != nil {
+			return err
+		}
+		size += n
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := batch.Put(isArchive, []byte{0x1}); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	vm.metrics.stateHistoryBytes.Add(float64(size))
+	return nil
+}
+
+// readStateHistory returns the value of [key] in the post-execution state of
+// the block at [height] (or [database.ErrNotFound] if it did not exist).
+func (vm *VM) readStateHistory(height uint64, key []byte) ([]byte, error) {
+	start := PrefixStateHistoryKey(key, height)
+	iter := vm.vmDB.NewIteratorWithStartAndPrefix(start, start[:len(start)-consts.Uint64Len])
+	defer iter.Release()
+
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+		return nil, database.ErrNotFound
+	}
+	v := iter.Value()
+	if v[0] == 0x0 {
+		return nil, database.ErrNotFound
+	}
+	value := make([]byte, len(v)-consts.BoolLen)
+	copy(value, v[consts.BoolLen:])
+	return value, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateHistory(t *testing.T) {
+	require := require.New(t)
+	vm := VM{vmDB: memdb.New()}
+
+	batch := vm.vmDB.NewBatch()
+	for _, change := range []struct {
+		height uint64
+		key    string
+		value  maybe.Maybe[[]byte]
+	}{
+		{0, "a", maybe.Some([]byte{1})},
+		{2, "a", maybe.Some([]byte{2})},
+		{4, "a", maybe.Nothing[[]byte]()},
+		{3, "ab", maybe.Some([]byte{3})}, // shares a prefix with "a"
+	} {
+		_, err := putStateHistory(batch, change.height, []byte(change.key), change.value)
+		require.NoError(err)
+	}
+	require.NoError(batch.Write())
+
+	for _, tt := range []struct {
+		height uint64
+		key    string
+		value  []byte
+		err    error
+	}{
+		{0, "a", []byte{1}, nil},
+		{1, "a", []byte{1}, nil},
+		{2, "a", []byte{2}, nil},
+		{3, "a", []byte{2}, nil},
+		{4, "a", nil, database.ErrNotFound},
+		{2, "ab", nil, database.ErrNotFound},
+		{5, "ab", []byte{3}, nil},
+		{5, "b", nil, database.ErrNotFound},
+	} {
+		value, err := vm.readStateHistory(tt.height, []byte(tt.key))
+		require.ErrorIs(err, tt.err)
+		require.Equal(tt.value, value)
+	}
+}
@@ -32,8 +32,12 @@ type Config interface {
 	GetVerifyAuth() bool
 	GetRootGenerationCores() int
 	GetTransactionExecutionCores() int
+	GetOptimisticTransactionExecution() bool // speculatively execute txs with conflicting state keys
 	GetMempoolSponsorSize() int
 	GetMempoolExemptSponsors() []codec.Address
+	GetMempoolFeeOrdering() bool  // order mempool by fee instead of arrival
+	GetTransactionIndexing() bool // index accepted txs by ID and address
+	GetTransactionTracing() bool  // record key accesses and fees of accepted txs
 	GetStreamingBacklogSize() int
 	GetStateHistoryLength() int               // how many roots back of data to keep to serve state queries
 	GetIntermediateNodeCacheSize() int        // how many bytes to keep in intermediate cache
@@ -46,12 +50,21 @@ type Config interface {
 	GetStateSyncServerDelay() time.Duration
 	GetParsedBlockCacheSize() int
 	GetAcceptedBlockWindow() int
+	GetArchive() bool                 // retain all blocks, results, and state history (never prune or state sync)
+	GetAdminAPIEnabled() bool         // serve [rpc.AdminJSONRPCEndpoint]
+	GetSnapshotAPIEnabled() bool      // serve [rpc.SnapshotEndpoint]
+	GetWarpStateReadAPIEnabled() bool // serve getWarpStateRead (which requests signatures from all validators)
 	GetAcceptedBlockWindowCache() int
 	GetContinuousProfilerConfig() *profiler.Config
 	GetTargetBuildDuration() time.Duration
 	GetProcessingBuildSkip() int
 	GetTargetGossipDuration() time.Duration
 	GetBlockCompactionFrequency() int
+	GetWarpMaxOutstanding() int                 // most signature requests to have in-flight at once
+	GetWarpMaxRetries() int                     // most times to retry a signature request to a single validator
+	GetWarpMinGatherInterval() time.Duration    // least time between gathering signatures for a single message
+	GetWarpAggregationQuorum() (uint64, uint64) // stake weight (num/den) to gather signatures from for each outgoing message
+	GetWarpMaxPendingAge() time.Duration        // most time to gather signatures for a single message before giving up
 }
 
 type Genesis interface {
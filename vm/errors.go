@@ -15,4 +15,22 @@ var (
 	ErrStateSyncing        = errors.New("state still syncing")
 	ErrUnexpectedStateRoot = errors.New("unexpected state root")
 	ErrTooManyProcessing   = errors.New("too many processing")
+	ErrIndexingDisabled    = errors.New("transaction indexing disabled")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrStatePruned         = errors.New("state pruned")
+	ErrHeightNotAccepted   = errors.New("height not accepted")
+	ErrBlockPruned         = errors.New("block pruned")
+	ErrBlockNotAccepted    = errors.New("block not accepted")
+	ErrArchiveIncomplete   = errors.New("archive must be initialized from genesis")
+	ErrMissingStateChanges = errors.New("missing state changes")
+	ErrKeyTooLarge         = errors.New("key too large")
+	ErrDatabaseNotEmpty    = errors.New("database not empty")
+	ErrUnexpectedBlock     = errors.New("unexpected block")
+	ErrMissingBlock        = errors.New("missing block")
+	ErrUnexpectedChain     = errors.New("unexpected chain")
+	ErrInvalidReplayRange  = errors.New("invalid replay range")
+	ErrTracingDisabled     = errors.New("transaction tracing disabled")
+	ErrNoncesDisabled      = errors.New("nonces disabled")
+	ErrInvalidWarpPending  = errors.New("invalid pending warp message")
+	ErrStateReadDisabled   = errors.New("warp state read API disabled")
 )
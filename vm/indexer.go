@@ -0,0 +1,254 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	txIndexPrefix       = 0x5 // txID -> height + timestamp + result
+	addressTxPrefix     = 0x6 // address + height + index -> txID
+	indexedHeightPrefix = 0x7 // height -> keys written (for pruning)
+
+	addressTxCursorLen = consts.Uint64Len + consts.Uint32Len
+)
+
+var _ Indexer = (*dbIndexer)(nil)
+
+// Indexer records accepted transactions so they can be queried over RPC.
+//
+// [Indexer.Accepted] is called from the acceptor queue after
+// [Controller.Accepted], so it is safe for it to block on disk writes.
+type Indexer interface {
+	// Accepted indexes all transactions in [blk].
+	Accepted(ctx context.Context, blk *chain.StatelessBlock) error
+
+	// GetTransaction returns the height and timestamp of the block that
+	// included [txID] and its [chain.Result] (if [txID] is indexed).
+	GetTransaction(txID ids.ID) (bool, uint64, int64, *chain.Result, error)
+
+	// GetAddressTransactions returns up to [limit] transactions that involve
+	// [addr] (in the order they were accepted), starting at [cursor]. The
+	// returned cursor should be provided to fetch the next page and is nil
+	// if there are no more transactions.
+	GetAddressTransactions(addr codec.Address, cursor []byte, limit int) ([]ids.ID, []byte, error)
+}
+
+// IndexerController is an optional interface a [Controller] can implement to
+// replace the default [Indexer] (which stores transactions in [vmDB]) when
+// [Config.GetTransactionIndexing] is enabled.
+type IndexerController interface {
+	Indexer(vmDB database.Database) Indexer
+}
+
+// addresses returns all addresses involved in [tx], without duplicates.
+func addresses(tx *chain.Transaction) []codec.Address {
+	var (
+		seen  = map[codec.Address]struct{}{}
+		addrs = []codec.Address{}
+	)
+	add := func(addr codec.Address) {
+		if _, ok := seen[addr]; ok {
+			return
+		}
+		seen[addr] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	add(tx.Auth.Actor())
+	add(tx.Auth.Sponsor())
+	for _, action := range tx.Actions {
+		participants, ok := action.(chain.Participants)
+		if !ok {
+			continue
+		}
+		for _, addr := range participants.Participants() {
+			add(addr)
+		}
+	}
+	return addrs
+}
+
+// dbIndexer persists the transaction index in [db] and deletes any
+// transactions older than [window] blocks (the same as [UpdateLastAccepted]).
+type dbIndexer struct {
+	db     database.Database
+	window int
+}
+
+func newDBIndexer(db database.Database, window int) *dbIndexer {
+	return &dbIndexer{db, window}
+}
+
+func PrefixTxIndexKey(txID ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = txIndexPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+func PrefixAddressTxKey(addr codec.Address, height uint64, index uint32) []byte {
+	k := make([]byte, 1+codec.AddressLen+addressTxCursorLen)
+	k[0] = addressTxPrefix
+	copy(k[1:], addr[:])
+	binary.BigEndian.PutUint64(k[1+codec.AddressLen:], height)
+	binary.BigEndian.PutUint32(k[1+codec.AddressLen+consts.Uint64Len:], index)
+	return k
+}
+
+func PrefixIndexedHeightKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = indexedHeightPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+func (i *dbIndexer) Accepted(_ context.Context, blk *chain.StatelessBlock) error {
+	var (
+		batch   = i.db.NewBatch()
+		height  = blk.Height()
+		results = blk.Results()
+		written = [][]byte{}
+	)
+	for j, tx := range blk.Txs {
+		result := results[j]
+		p := codec.NewWriter(consts.Uint64Len*2+result.Size(), consts.NetworkSizeLimit)
+		p.PackUint64(height)
+		p.PackInt64(blk.Tmstmp)
+		if err := result.Marshal(p); err != nil {
+			return err
+		}
+		if err := p.Err(); err != nil {
+			return err
+		}
+		k := PrefixTxIndexKey(tx.ID())
+		if err := batch.Put(k, p.Bytes()); err != nil {
+			return err
+		}
+		written = append(written, k)
+
+		txID := tx.ID()
+		for _, addr := range addresses(tx) {
+			k := PrefixAddressTxKey(addr, height, uint32(j))
+			if err := batch.Put(k, txID[:]); err != nil {
+				return err
+			}
+			written = append(written, k)
+		}
+	}
+
+	// Record the keys written so they can be deleted when [height] expires
+	size := consts.IntLen
+	for _, k := range written {
+		size += codec.BytesLen(k)
+	}
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackInt(len(written))
+	for _, k := range written {
+		p.PackBytes(k)
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	if err := batch.Put(PrefixIndexedHeightKey(height), p.Bytes()); err != nil {
+		return err
+	}
+
+	// Delete transactions in the block that is no longer in the accepted
+	// block window
+	expiryHeight := height - uint64(i.window)
+	if expiryHeight > 0 && expiryHeight < height { // ensure we don't free genesis
+		if err := i.prune(batch, expiryHeight); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (i *dbIndexer) prune(batch database.Batch, height uint64) error {
+	hk := PrefixIndexedHeightKey(height)
+	v, err := i.db.Get(hk)
+	if errors.Is(err, database.ErrNotFound) {
+		// Indexing may have been enabled after [height] was accepted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	p := codec.NewReader(v, consts.MaxInt)
+	count := p.UnpackInt(false)
+	for j := 0; j < count; j++ {
+		var k []byte
+		p.UnpackBytes(-1, true, &k)
+		if err := p.Err(); err != nil {
+			return err
+		}
+		if err := batch.Delete(k); err != nil {
+			return err
+		}
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return batch.Delete(hk)
+}
+
+func (i *dbIndexer) GetTransaction(txID ids.ID) (bool, uint64, int64, *chain.Result, error) {
+	v, err := i.db.Get(PrefixTxIndexKey(txID))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, nil, nil
+	}
+	if err != nil {
+		return false, 0, 0, nil, err
+	}
+	p := codec.NewReader(v, consts.NetworkSizeLimit)
+	height := p.UnpackUint64(false)
+	t := p.UnpackInt64(false)
+	result, err := chain.UnmarshalResult(p)
+	if err != nil {
+		return false, 0, 0, nil, err
+	}
+	if !p.Empty() {
+		return false, 0, 0, nil, chain.ErrInvalidObject
+	}
+	if err := p.Err(); err != nil {
+		return false, 0, 0, nil, err
+	}
+	return true, height, t, result, nil
+}
+
+func (i *dbIndexer) GetAddressTransactions(
+	addr codec.Address,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	if len(cursor) != 0 && len(cursor) != addressTxCursorLen {
+		return nil, nil, ErrInvalidCursor
+	}
+	prefix := make([]byte, 1+codec.AddressLen)
+	prefix[0] = addressTxPrefix
+	copy(prefix[1:], addr[:])
+	iter := i.db.NewIteratorWithStartAndPrefix(append(bytes.Clone(prefix), cursor...), prefix)
+	defer iter.Release()
+
+	txIDs := []ids.ID{}
+	for iter.Next() {
+		k := iter.Key()
+		if len(txIDs) == limit {
+			return txIDs, bytes.Clone(k[len(prefix):]), nil
+		}
+		txIDs = append(txIDs, ids.ID(iter.Value()))
+	}
+	return txIDs, nil, iter.Error()
+}
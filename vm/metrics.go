@@ -13,6 +13,7 @@ import (
 type executorMetrics struct {
 	blocked    prometheus.Counter
 	executable prometheus.Counter
+	aborted    prometheus.Counter // only populated if optimistic execution is possible
 }
 
 func (em *executorMetrics) RecordBlocked() {
@@ -23,10 +24,22 @@ func (em *executorMetrics) RecordExecutable() {
 	em.executable.Inc()
 }
 
+func (em *executorMetrics) RecordAborted() {
+	if em.aborted == nil {
+		return
+	}
+	em.aborted.Inc()
+}
+
 type Metrics struct {
 	txsSubmitted             prometheus.Counter // includes gossip
 	txsReceived              prometheus.Counter
 	seenTxsReceived          prometheus.Counter
+	invalidTxsReceived       prometheus.Counter
+	expiredTxsReceived       prometheus.Counter
+	rateLimitedTxsReceived   prometheus.Counter
+	ignoredGossip            prometheus.Counter
+	peersIgnored             prometheus.Counter
 	txsGossiped              prometheus.Counter
 	txsVerified              prometheus.Counter
 	txsAccepted              prometheus.Counter
@@ -36,12 +49,17 @@ type Metrics struct {
 	emptyBlockBuilt          prometheus.Counter
 	clearedMempool           prometheus.Counter
 	deletedBlocks            prometheus.Counter
+	blockBytes               prometheus.Counter
+	resultBytes              prometheus.Counter
+	stateHistoryBytes        prometheus.Counter
+	traceBytes               prometheus.Counter
 	blocksFromDisk           prometheus.Counter
 	blocksHeightsFromDisk    prometheus.Counter
 	executorBuildBlocked     prometheus.Counter
 	executorBuildExecutable  prometheus.Counter
 	executorVerifyBlocked    prometheus.Counter
 	executorVerifyExecutable prometheus.Counter
+	executorVerifyAborted    prometheus.Counter
 	mempoolSize              prometheus.Gauge
 	bandwidthPrice           prometheus.Gauge
 	computePrice             prometheus.Gauge
@@ -153,6 +171,31 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "seen_txs_received",
 			Help:      "number of txs received over gossip that we've already seen",
 		}),
+		invalidTxsReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "invalid_txs_received",
+			Help:      "number of txs received over gossip that could not be parsed or verified",
+		}),
+		expiredTxsReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "expired_txs_received",
+			Help:      "number of txs received over gossip that had already expired",
+		}),
+		rateLimitedTxsReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "rate_limited_txs_received",
+			Help:      "number of txs received over gossip that were dropped by rate limiting",
+		}),
+		ignoredGossip: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "ignored_gossip",
+			Help:      "number of gossip messages dropped because the sender was ignored",
+		}),
+		peersIgnored: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "peers_ignored",
+			Help:      "number of times a peer was ignored for sending invalid or expired txs",
+		}),
 		txsGossiped: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_gossiped",
@@ -198,6 +241,26 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "deleted_blocks",
 			Help:      "number of blocks deleted",
 		}),
+		blockBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "block_bytes",
+			Help:      "bytes of accepted blocks written to disk",
+		}),
+		resultBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "result_bytes",
+			Help:      "bytes of accepted block results written to disk",
+		}),
+		stateHistoryBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "state_history_bytes",
+			Help:      "bytes of state history written to disk (archive only)",
+		}),
+		traceBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "trace_bytes",
+			Help:      "bytes of transaction traces written to disk",
+		}),
 		blocksFromDisk: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "blocks_from_disk",
@@ -228,6 +291,11 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "executor_verify_executable",
 			Help:      "executor tasks executable during verify",
 		}),
+		executorVerifyAborted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "executor_verify_aborted",
+			Help:      "speculatively executed tasks aborted during verify (abort rate is aborted/executable)",
+		}),
 		mempoolSize: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "chain",
 			Name:      "mempool_size",
@@ -268,13 +336,22 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		blockProcess:   blockProcess,
 	}
 	m.executorBuildRecorder = &executorMetrics{blocked: m.executorBuildBlocked, executable: m.executorBuildExecutable}
-	m.executorVerifyRecorder = &executorMetrics{blocked: m.executorVerifyBlocked, executable: m.executorVerifyExecutable}
+	m.executorVerifyRecorder = &executorMetrics{
+		blocked:    m.executorVerifyBlocked,
+		executable: m.executorVerifyExecutable,
+		aborted:    m.executorVerifyAborted,
+	}
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		r.Register(m.txsSubmitted),
 		r.Register(m.txsReceived),
 		r.Register(m.seenTxsReceived),
+		r.Register(m.invalidTxsReceived),
+		r.Register(m.expiredTxsReceived),
+		r.Register(m.rateLimitedTxsReceived),
+		r.Register(m.ignoredGossip),
+		r.Register(m.peersIgnored),
 		r.Register(m.txsGossiped),
 		r.Register(m.txsVerified),
 		r.Register(m.txsAccepted),
@@ -285,12 +362,17 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.emptyBlockBuilt),
 		r.Register(m.clearedMempool),
 		r.Register(m.deletedBlocks),
+		r.Register(m.blockBytes),
+		r.Register(m.resultBytes),
+		r.Register(m.stateHistoryBytes),
+		r.Register(m.traceBytes),
 		r.Register(m.blocksFromDisk),
 		r.Register(m.blocksHeightsFromDisk),
 		r.Register(m.executorBuildBlocked),
 		r.Register(m.executorBuildExecutable),
 		r.Register(m.executorVerifyBlocked),
 		r.Register(m.executorVerifyExecutable),
+		r.Register(m.executorVerifyAborted),
 		r.Register(m.bandwidthPrice),
 		r.Register(m.computePrice),
 		r.Register(m.storageReadPrice),
@@ -0,0 +1,71 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+type WarpStateReadHandler struct {
+	vm *VM
+}
+
+func NewWarpStateReadHandler(vm *VM) *WarpStateReadHandler {
+	return &WarpStateReadHandler{vm}
+}
+
+func (*WarpStateReadHandler) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*WarpStateReadHandler) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func (*WarpStateReadHandler) AppGossip(context.Context, ids.NodeID, []byte) error {
+	return nil
+}
+
+func (w *WarpStateReadHandler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	_ time.Time,
+	request []byte,
+) error {
+	return w.vm.warpStateReader.AppRequest(ctx, nodeID, requestID, request)
+}
+
+func (w *WarpStateReadHandler) AppRequestFailed(
+	_ context.Context,
+	_ ids.NodeID,
+	requestID uint32,
+) error {
+	return w.vm.warpStateReader.HandleRequestFailed(requestID)
+}
+
+func (w *WarpStateReadHandler) AppResponse(
+	_ context.Context,
+	_ ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	return w.vm.warpStateReader.HandleResponse(requestID, response)
+}
+
+func (*WarpStateReadHandler) CrossChainAppRequest(context.Context, ids.ID, uint32, time.Time, []byte) error {
+	return nil
+}
+
+func (*WarpStateReadHandler) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
+	return nil
+}
+
+func (*WarpStateReadHandler) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
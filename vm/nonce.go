@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// fillsNonceGap returns true if each nonce of the sponsor of [tx] between its
+// next nonce in [im] and the nonce of [tx] is used by a transaction in the
+// mempool (or in [pending]).
+func (vm *VM) fillsNonceGap(
+	ctx context.Context,
+	im state.Immutable,
+	tx *chain.Transaction,
+	pending map[codec.Address]map[uint64]bool,
+) (bool, error) {
+	sponsor := tx.Sponsor()
+	next, _, err := chain.GetNonce(ctx, vm.c.StateManager(), im, sponsor)
+	if err != nil {
+		return false, err
+	}
+	for ; next < tx.Nonce(); next++ {
+		if !vm.mempool.HasNonce(ctx, sponsor, next) && !pending[sponsor][next] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetNonce returns the nonce the next transaction sponsored by [addr] should
+// use, which accounts for transactions in processing blocks and the mempool.
+func (vm *VM) GetNonce(ctx context.Context, addr codec.Address) (uint64, error) {
+	if !vm.isReady() {
+		return 0, ErrNotReady
+	}
+	blk, err := vm.GetStatelessBlock(ctx, vm.preferred)
+	if err != nil {
+		return 0, err
+	}
+	view, err := blk.View(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	next, enabled, err := chain.GetNonce(ctx, vm.c.StateManager(), view, addr)
+	if err != nil {
+		return 0, err
+	}
+	if !enabled {
+		return 0, ErrNoncesDisabled
+	}
+	for vm.mempool.HasNonce(ctx, addr, next) {
+		next++
+	}
+	return next, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/mempool"
+)
+
+var _ mempool.Prioritizer[*chain.Transaction] = (*feePrioritizer)(nil)
+
+// feePrioritizer orders transactions in the mempool by [chain.Transaction.Priority]
+// using the most recent unit prices seen by the VM.
+type feePrioritizer struct {
+	vm *VM
+
+	l          sync.RWMutex
+	unitPrices chain.Dimensions
+}
+
+func newFeePrioritizer(vm *VM) *feePrioritizer {
+	return &feePrioritizer{vm: vm}
+}
+
+func (p *feePrioritizer) SetUnitPrices(unitPrices chain.Dimensions) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	p.unitPrices = unitPrices
+}
+
+func (p *feePrioritizer) Priority(tx *chain.Transaction) uint64 {
+	p.l.RLock()
+	unitPrices := p.unitPrices
+	p.l.RUnlock()
+
+	r := p.vm.c.Rules(time.Now().UnixMilli())
+	priority, err := tx.Priority(p.vm.c.StateManager(), r, unitPrices)
+	if err != nil {
+		// Should never happen because [StateKeys] are checked before
+		// adding to the mempool
+		return 0
+	}
+	return priority
+}
+
+func (p *feePrioritizer) Keys(tx *chain.Transaction) set.Set[string] {
+	keys, err := tx.ReplacementKeys(p.vm.c.StateManager())
+	if err != nil {
+		return nil
+	}
+	return keys
+}
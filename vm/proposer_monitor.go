@@ -133,9 +133,9 @@ func (p *ProposerMonitor) Proposers(
 
 func (p *ProposerMonitor) Validators(
 	ctx context.Context,
-) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
+) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}, error) {
 	if err := p.refresh(ctx); err != nil {
-		return nil, nil
+		return nil, nil, err
 	}
-	return p.validators, p.validatorPublicKeys
+	return p.validators, p.validatorPublicKeys, nil
 }
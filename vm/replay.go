@@ -0,0 +1,337 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Replay re-executes the accepted blocks in [start, end] and returns the
+// first [chain.Divergence] from the results and state changes that were accepted
+// (or nil, if there is none).
+//
+// Each block is executed on the accepted post-execution state of its parent,
+// which is read as needed (instead of being rebuilt) from the state history
+// of an archive node or, on other nodes, from the merkledb history of the
+// last [GetStateHistoryLength] roots committed since the VM started
+// ([ErrStatePruned] is returned for older blocks). Because the state of each
+// block is only compared to what was accepted, matching state changes imply
+// matching roots.
+func (vm *VM) Replay(ctx context.Context, start uint64, end uint64) (*chain.Divergence, error) {
+	lastAccepted := vm.LastAcceptedBlock().Hght
+	if start == 0 || start > end || end > lastAccepted {
+		return nil, fmt.Errorf("%w: [%d, %d] (last accepted=%d)", ErrInvalidReplayRange, start, end, lastAccepted)
+	}
+	var history replayHistory
+	if vm.config.GetArchive() {
+		changes, err := vm.stateHistoryChanges(start, end)
+		if err != nil {
+			return nil, err
+		}
+		history = &archiveReplayHistory{vm, changes}
+	} else {
+		history = &rootReplayHistory{vm}
+	}
+
+	for height := start; height <= end; height++ {
+		accepted, results, err := vm.GetAcceptedBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := chain.ParseBlock(ctx, accepted.Bytes(), choices.Accepted, vm)
+		if err != nil {
+			return nil, err
+		}
+		parent, err := history.State(ctx, height-1, blk.StateRoot)
+		if err != nil {
+			return nil, err
+		}
+		expectedChanges, err := history.Changes(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		replayResults, replayChanges, err := blk.Replay(ctx, parent)
+		if err != nil {
+			return &chain.Divergence{
+				Height:  height,
+				BlockID: blk.ID(),
+				TxIndex: -1,
+				Reason:  "execution failed",
+				Actual:  err.Error(),
+			}, nil
+		}
+		div, err := diffBlock(ctx, blk, vm.StateManager(), parent, results, replayResults, expectedChanges, replayChanges)
+		if err != nil || div != nil {
+			return div, err
+		}
+	}
+	return nil, nil
+}
+
+// replayHistory serves the accepted state that [Replay] compares against.
+type replayHistory interface {
+	// State returns the post-execution state of the block at [height] (and
+	// ensures its root is [root], if the root is known).
+	State(ctx context.Context, height uint64, root ids.ID) (state.Immutable, error)
+
+	// Changes returns the keys changed by the block at [height].
+	Changes(ctx context.Context, height uint64) (map[string]maybe.Maybe[[]byte], error)
+}
+
+// archiveReplayHistory reads the state history recorded by an archive node.
+type archiveReplayHistory struct {
+	vm      *VM
+	changes map[uint64]map[string]maybe.Maybe[[]byte]
+}
+
+func (h *archiveReplayHistory) State(_ context.Context, height uint64, _ ids.ID) (state.Immutable, error) {
+	return &historyState{h.vm, height}, nil
+}
+
+func (h *archiveReplayHistory) Changes(_ context.Context, height uint64) (map[string]maybe.Maybe[[]byte], error) {
+	return h.changes[height], nil
+}
+
+// historyState is the post-execution state of the block at [height] (read
+// from the state history).
+type historyState struct {
+	vm     *VM
+	height uint64
+}
+
+func (s *historyState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	return s.vm.readStateHistory(s.height, key)
+}
+
+// stateHistoryChanges returns the changes made by each block in [start, end]
+// with a single pass over the state history (which is ordered by key and
+// then by descending height).
+func (vm *VM) stateHistoryChanges(start uint64, end uint64) (map[uint64]map[string]maybe.Maybe[[]byte], error) {
+	changes := map[uint64]map[string]maybe.Maybe[[]byte]{}
+	iter := vm.vmDB.NewIteratorWithPrefix([]byte{stateHistoryPrefix})
+	defer iter.Release()
+	for iter.Next() {
+		k := iter.Key()
+		keyLen := int(binary.BigEndian.Uint16(k[1:]))
+		height := ^binary.BigEndian.Uint64(k[1+consts.Uint16Len+keyLen:])
+		if height < start || height > end {
+			continue
+		}
+		key := k[1+consts.Uint16Len : 1+consts.Uint16Len+keyLen]
+		v := iter.Value()
+		value := maybe.Nothing[[]byte]()
+		if v[0] == 0x1 {
+			value = maybe.Some(slices.Clone(v[consts.BoolLen:]))
+		}
+		if _, ok := changes[height]; !ok {
+			changes[height] = map[string]maybe.Maybe[[]byte]{}
+		}
+		changes[height][string(key)] = value
+	}
+	return changes, iter.Error()
+}
+
+// rootReplayHistory reads the merkledb history of recently accepted roots.
+type rootReplayHistory struct {
+	vm *VM
+}
+
+func (h *rootReplayHistory) State(_ context.Context, height uint64, root ids.ID) (state.Immutable, error) {
+	parentRoot, err := h.vm.stateRootAt(height)
+	if err != nil {
+		return nil, err
+	}
+	if parentRoot != root {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedStateRoot, root, parentRoot)
+	}
+	return &rootState{h.vm, root}, nil
+}
+
+func (h *rootReplayHistory) Changes(ctx context.Context, height uint64) (map[string]maybe.Maybe[[]byte], error) {
+	parentRoot, err := h.vm.stateRootAt(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	root, err := h.vm.stateRootAt(height)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]maybe.Maybe[[]byte]{}
+	start := maybe.Nothing[[]byte]()
+	for {
+		proof, err := h.vm.stateDB.GetChangeProof(ctx, parentRoot, root, start, maybe.Nothing[[]byte](), snapshotPageSize)
+		if errors.Is(err, merkledb.ErrInsufficientHistory) {
+			return nil, ErrStatePruned
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range proof.KeyChanges {
+			changes[string(change.Key)] = change.Value
+		}
+		if len(proof.KeyChanges) < snapshotPageSize {
+			return changes, nil
+		}
+		next := slices.Clone(proof.KeyChanges[len(proof.KeyChanges)-1].Key)
+		start = maybe.Some(append(next, 0x0))
+	}
+}
+
+// rootState is the state at [root] (read from the merkledb history).
+type rootState struct {
+	vm   *VM
+	root ids.ID
+}
+
+func (s *rootState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	return s.vm.readStateAtRoot(ctx, s.root, key)
+}
+
+// diffBlock returns the first [chain.Divergence] between the accepted [results] and
+// [changes] of [blk] and those of its re-execution on [parent] (or nil, if
+// there is none).
+func diffBlock(
+	ctx context.Context,
+	blk *chain.StatelessBlock,
+	sm chain.StateManager,
+	parent state.Immutable,
+	results []*chain.Result,
+	replayResults []*chain.Result,
+	changes map[string]maybe.Maybe[[]byte],
+	replayChanges map[string]maybe.Maybe[[]byte],
+) (*chain.Divergence, error) {
+	div := &chain.Divergence{Height: blk.Hght, BlockID: blk.ID(), TxIndex: -1}
+
+	// Compare results (which are nil for blocks accepted during state sync)
+	if len(results) > 0 {
+		for i, result := range results {
+			reason, expected, actual := diffResult(result, replayResults[i])
+			if len(reason) == 0 {
+				continue
+			}
+			div.TxIndex, div.TxID = i, blk.Txs[i].ID()
+			div.Reason, div.Expected, div.Actual = reason, expected, actual
+			return div, nil
+		}
+	}
+
+	// Compare state changes (in key order, so the divergence is
+	// deterministic)
+	keys := maps.Keys(changes)
+	for k := range replayChanges {
+		if _, ok := changes[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		expected, eok := changes[k]
+		actual, aok := replayChanges[k]
+		if eok && aok && expected.IsNothing() == actual.IsNothing() && bytes.Equal(expected.Value(), actual.Value()) {
+			continue
+		}
+
+		// A key may be written with its previous value (which is not a change
+		// in merkledb)
+		past, err := parent.GetValue(ctx, []byte(k))
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			if !eok {
+				expected = maybe.Nothing[[]byte]()
+			}
+			if !aok {
+				actual = maybe.Nothing[[]byte]()
+			}
+		case err != nil:
+			return nil, err
+		default:
+			if !eok {
+				expected = maybe.Some(past)
+			}
+			if !aok {
+				actual = maybe.Some(past)
+			}
+		}
+		if expected.IsNothing() == actual.IsNothing() && bytes.Equal(expected.Value(), actual.Value()) {
+			continue
+		}
+		for i, tx := range blk.Txs {
+			stateKeys, err := tx.StateKeys(sm)
+			if err != nil {
+				return nil, err
+			}
+			if stateKeys.Contains(k) {
+				div.TxIndex, div.TxID = i, tx.ID()
+				break
+			}
+		}
+		div.Key = []byte(k)
+		div.Reason = "state change"
+		div.Expected, div.Actual = formatChange(changes[k], eok), formatChange(replayChanges[k], aok)
+		return div, nil
+	}
+	return nil, nil
+}
+
+// diffResult returns the first field of [actual] that differs from
+// [expected] (or an empty string, if they are equal).
+func diffResult(expected *chain.Result, actual *chain.Result) (string, string, string) {
+	switch {
+	case expected.Success != actual.Success:
+		return "success", strconv.FormatBool(expected.Success), strconv.FormatBool(actual.Success)
+	case !bytes.Equal(expected.Error, actual.Error):
+		return "error", string(expected.Error), string(actual.Error)
+	case expected.Consumed != actual.Consumed:
+		return "consumed", fmt.Sprint(expected.Consumed), fmt.Sprint(actual.Consumed)
+	case expected.Fee != actual.Fee:
+		return "fee", strconv.FormatUint(expected.Fee, 10), strconv.FormatUint(actual.Fee, 10)
+	case !bytes.Equal(expected.WarpError, actual.WarpError):
+		return "warp error", string(expected.WarpError), string(actual.WarpError)
+	}
+
+	// Outputs, events, and warp messages are compared by their encoding
+	eb, aerr := marshalResult(expected)
+	ab, berr := marshalResult(actual)
+	if aerr != nil || berr != nil || !bytes.Equal(eb, ab) {
+		return "result", fmt.Sprintf("%x", eb), fmt.Sprintf("%x", ab)
+	}
+	return "", "", ""
+}
+
+func marshalResult(r *chain.Result) ([]byte, error) {
+	p := codec.NewWriter(r.Size(), consts.MaxInt)
+	if err := r.Marshal(p); err != nil {
+		return nil, err
+	}
+	return p.Bytes(), p.Err()
+}
+
+func formatChange(v maybe.Maybe[[]byte], ok bool) string {
+	switch {
+	case !ok:
+		return "unchanged"
+	case v.IsNothing():
+		return "deleted"
+	default:
+		return fmt.Sprintf("%x", v.Value())
+	}
+}
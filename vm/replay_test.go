@@ -0,0 +1,246 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+func replayTestKey(prefix byte, b ...byte) string {
+	return string(keys.EncodeChunks(append([]byte{prefix}, b...), 1))
+}
+
+// replayTestAction only declares the key [K] (it is never executed).
+type replayTestAction struct {
+	K byte
+}
+
+func (*replayTestAction) GetTypeID() uint8                      { return 0 }
+func (*replayTestAction) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*replayTestAction) MaxComputeUnits(chain.Rules) uint64    { return 1 }
+func (*replayTestAction) StateKeysMaxChunks() []uint16          { return []uint16{1} }
+func (*replayTestAction) OutputsWarpMessage() bool              { return false }
+func (*replayTestAction) Size() int                             { return consts.ByteLen }
+func (a *replayTestAction) Marshal(p *codec.Packer)             { p.PackByte(a.K) }
+func (a *replayTestAction) StateKeys(codec.Address, ids.ID) []string {
+	return []string{replayTestKey(0x0, a.K)}
+}
+
+func (*replayTestAction) Execute(
+	context.Context,
+	chain.Rules,
+	state.Mutable,
+	int64,
+	codec.Address,
+	ids.ID,
+	bool,
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
+	return true, 1, nil, nil, nil, nil
+}
+
+// replayTestAuth is an unsigned [chain.Auth] for [Signer].
+type replayTestAuth struct {
+	Signer ids.ID
+}
+
+func (*replayTestAuth) GetTypeID() uint8                      { return 0 }
+func (*replayTestAuth) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*replayTestAuth) ComputeUnits(chain.Rules) uint64       { return 1 }
+func (*replayTestAuth) Verify(context.Context, []byte) error  { return nil }
+func (a *replayTestAuth) Actor() codec.Address                { return codec.CreateAddress(0, a.Signer) }
+func (a *replayTestAuth) Sponsor() codec.Address              { return a.Actor() }
+func (*replayTestAuth) Size() int                             { return consts.IDLen }
+func (a *replayTestAuth) Marshal(p *codec.Packer)             { p.PackID(a.Signer) }
+func (a *replayTestAuth) Sign([]byte) (chain.Auth, error)     { return a, nil }
+func (*replayTestAuth) MaxUnits() (uint64, uint64)            { return consts.IDLen, 1 }
+
+// replayTestStateManager stores the balance of each sponsor under its
+// address (fees are never paid).
+type replayTestStateManager struct {
+	chain.StateManager
+}
+
+func (*replayTestStateManager) SponsorStateKeys(addr codec.Address) []string {
+	return []string{replayTestKey(0x1, addr[:]...)}
+}
+
+type replayTestState map[string][]byte
+
+func (s replayTestState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func TestDiffBlock(t *testing.T) {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(0, func(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+		return &replayTestAction{K: p.UnpackByte()}, p.Err()
+	}, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(0, func(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+		var a replayTestAuth
+		p.UnpackID(true, &a.Signer)
+		return &a, p.Err()
+	}, false))
+
+	// Each transaction uses a different key
+	txs := make([]*chain.Transaction, 3)
+	for i := range txs {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: consts.MillisecondsPerSecond, ChainID: ids.GenerateTestID(), MaxFee: 1},
+			nil,
+			[]chain.Action{&replayTestAction{K: byte(i)}},
+		).Sign(&replayTestAuth{Signer: ids.GenerateTestID()}, actionRegistry, authRegistry)
+		require.NoError(err)
+		txs[i] = tx
+	}
+	blk := &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: 10, Txs: txs}}
+	sm := &replayTestStateManager{}
+	parent := replayTestState{
+		replayTestKey(0x0, 0): {1},
+		replayTestKey(0x0, 1): {2},
+	}
+	newResults := func() []*chain.Result {
+		results := make([]*chain.Result, len(txs))
+		for i := range results {
+			results[i] = &chain.Result{Success: true, Outputs: [][]byte{{byte(i)}}, Fee: 1}
+		}
+		// The first transaction included a warp message that was rejected
+		results[0].WarpError = []byte(chain.ErrWarpNotVerified.Error())
+		return results
+	}
+	newChanges := func() map[string]maybe.Maybe[[]byte] {
+		return map[string]maybe.Maybe[[]byte]{
+			replayTestKey(0x0, 0): maybe.Nothing[[]byte](),
+			replayTestKey(0x0, 2): maybe.Some([]byte{3}),
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		replayResults func([]*chain.Result)
+		replayChanges func(map[string]maybe.Maybe[[]byte])
+		txIndex       int
+		key           string
+		reason        string
+	}{
+		{
+			name:    "same execution",
+			txIndex: -1,
+		},
+		{
+			name: "write of previous value",
+			replayChanges: func(changes map[string]maybe.Maybe[[]byte]) {
+				changes[replayTestKey(0x0, 1)] = maybe.Some([]byte{2})
+			},
+			txIndex: -1,
+		},
+		{
+			name: "different fee",
+			replayResults: func(results []*chain.Result) {
+				results[1].Fee++
+			},
+			txIndex: 1,
+			reason:  "fee",
+		},
+		{
+			name: "different warp error",
+			replayResults: func(results []*chain.Result) {
+				results[0].WarpError = []byte(chain.ErrDisabledChainID.Error())
+			},
+			txIndex: 0,
+			reason:  "warp error",
+		},
+		{
+			name: "different output",
+			replayResults: func(results []*chain.Result) {
+				results[2].Outputs = nil
+			},
+			txIndex: 2,
+			reason:  "result",
+		},
+		{
+			name: "different value",
+			replayChanges: func(changes map[string]maybe.Maybe[[]byte]) {
+				changes[replayTestKey(0x0, 2)] = maybe.Some([]byte{4})
+			},
+			txIndex: 2,
+			key:     replayTestKey(0x0, 2),
+			reason:  "state change",
+		},
+		{
+			name: "missing change",
+			replayChanges: func(changes map[string]maybe.Maybe[[]byte]) {
+				delete(changes, replayTestKey(0x0, 0))
+			},
+			txIndex: 0,
+			key:     replayTestKey(0x0, 0),
+			reason:  "state change",
+		},
+		{
+			name: "unexpected change",
+			replayChanges: func(changes map[string]maybe.Maybe[[]byte]) {
+				changes[replayTestKey(0x0, 1)] = maybe.Nothing[[]byte]()
+			},
+			txIndex: 1,
+			key:     replayTestKey(0x0, 1),
+			reason:  "state change",
+		},
+		{
+			name: "change to an undeclared key",
+			replayChanges: func(changes map[string]maybe.Maybe[[]byte]) {
+				changes[replayTestKey(0x2)] = maybe.Some([]byte{1})
+			},
+			txIndex: -1,
+			key:     replayTestKey(0x2),
+			reason:  "state change",
+		},
+	} {
+		t.Run(tt.name, func(*testing.T) {
+			replayResults, replayChanges := newResults(), newChanges()
+			if tt.replayResults != nil {
+				tt.replayResults(replayResults)
+			}
+			if tt.replayChanges != nil {
+				tt.replayChanges(replayChanges)
+			}
+			div, err := diffBlock(context.Background(), blk, sm, parent, newResults(), replayResults, newChanges(), replayChanges)
+			require.NoError(err)
+			if len(tt.reason) == 0 {
+				require.Nil(div)
+				return
+			}
+			require.NotNil(div)
+			require.Equal(uint64(10), div.Height)
+			require.Equal(tt.reason, div.Reason)
+			require.Equal(tt.txIndex, div.TxIndex)
+			if tt.txIndex >= 0 {
+				require.Equal(txs[tt.txIndex].ID(), div.TxID)
+			}
+			if len(tt.key) > 0 {
+				require.Equal([]byte(tt.key), div.Key)
+			} else {
+				require.Nil(div.Key)
+			}
+		})
+	}
+}
@@ -14,14 +14,17 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/workers"
 )
 
@@ -71,6 +74,9 @@ func (vm *VM) Rules(t int64) chain.Rules {
 }
 
 func (vm *VM) LastAcceptedBlock() *chain.StatelessBlock {
+	vm.lastAcceptedL.RLock()
+	defer vm.lastAcceptedL.RUnlock()
+
 	return vm.lastAccepted
 }
 
@@ -94,6 +100,12 @@ func (vm *VM) IsRepeat(ctx context.Context, txs []*chain.Transaction, marker set
 	_, span := vm.tracer.Start(ctx, "VM.IsRepeat")
 	defer span.End()
 
+	if vm.nonces {
+		// Each transaction consumes the nonce of its sponsor, so any repeat of an
+		// accepted transaction will fail [chain.Transaction.PreExecute] and
+		// [seen] is not populated.
+		return marker
+	}
 	return vm.seen.Contains(txs, marker, stop)
 }
 
@@ -175,6 +187,13 @@ func (vm *VM) processAcceptedBlock(b *chain.StatelessBlock) {
 		vm.Fatal("accepted processing failed", zap.Error(err))
 	}
 
+	// Update transaction index
+	if vm.indexer != nil {
+		if err := vm.indexer.Accepted(context.TODO(), b); err != nil {
+			vm.Fatal("unable to index accepted block", zap.Error(err))
+		}
+	}
+
 	// Sign and store any warp messages (regardless if validator now, may become one)
 	results := b.Results()
 	for i, tx := range b.Txs {
@@ -253,6 +272,19 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 
 	vm.metrics.txsAccepted.Add(float64(len(b.Txs)))
 
+	// Record the post-execution root of [b] (which was just committed) so that
+	// state can be read at its height
+	//
+	// We do this before setting [lastAccepted] so that the root of any block
+	// at or below [lastAccepted] is known (unless it has been evicted).
+	if vm.StateReady() {
+		root, err := vm.stateDB.GetMerkleRoot(ctx)
+		if err != nil {
+			vm.Fatal("unable to get state root", zap.Error(err))
+		}
+		vm.stateRoots.Put(b.Height(), root)
+	}
+
 	// Update accepted blocks on-disk and caches
 	if err := vm.UpdateLastAccepted(b); err != nil {
 		vm.Fatal("unable to update last accepted", zap.Error(err))
@@ -273,7 +305,9 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	blkTime := b.Tmstmp
 	evicted := vm.seen.SetMin(blkTime)
 	vm.Logger().Debug("txs evicted from seen", zap.Int("len", len(evicted)))
-	vm.seen.Add(b.Txs)
+	if !vm.nonces {
+		vm.seen.Add(b.Txs)
+	}
 
 	// Verify if emap is now sufficient (we need a consecutive run of blocks with
 	// timestamps of at least [ValidityWindow] for this to occur).
@@ -329,7 +363,7 @@ func (vm *VM) Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.N
 
 func (vm *VM) CurrentValidators(
 	ctx context.Context,
-) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
+) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}, error) {
 	return vm.proposerMonitor.Validators(ctx)
 }
 
@@ -337,6 +371,21 @@ func (vm *VM) GatherSignatures(ctx context.Context, txID ids.ID, msg []byte) {
 	vm.warpManager.GatherSignatures(ctx, txID, msg)
 }
 
+func (vm *VM) ReadWarpState(
+	ctx context.Context,
+	height uint64,
+	keys [][]byte,
+) (*warp.UnsignedMessage, []*chain.WarpSignature, error) {
+	if !vm.config.GetWarpStateReadAPIEnabled() {
+		return nil, nil, ErrStateReadDisabled
+	}
+	return vm.warpStateReader.Read(ctx, height, keys)
+}
+
+func (vm *VM) GetWarpAggregationQuorum() (uint64, uint64) {
+	return vm.config.GetWarpAggregationQuorum()
+}
+
 func (vm *VM) NodeID() ids.NodeID {
 	return vm.snowCtx.NodeID
 }
@@ -413,10 +462,48 @@ func (vm *VM) RecordStateOperations(c int) {
 	vm.metrics.stateOperations.Add(float64(c))
 }
 
+func (vm *VM) GetIndexedTransaction(txID ids.ID) (bool, uint64, int64, *chain.Result, error) {
+	if vm.indexer == nil {
+		return false, 0, 0, nil, ErrIndexingDisabled
+	}
+	return vm.indexer.GetTransaction(txID)
+}
+
+func (vm *VM) GetAddressTransactions(addr codec.Address, cursor []byte, limit int) ([]ids.ID, []byte, error) {
+	if vm.indexer == nil {
+		return nil, nil, ErrIndexingDisabled
+	}
+	return vm.indexer.GetAddressTransactions(addr, cursor, limit)
+}
+
+func (vm *VM) GetMempoolTx(ctx context.Context, txID ids.ID) (*chain.Transaction, bool) {
+	return vm.mempool.Get(ctx, txID)
+}
+
+func (vm *VM) GetMempoolTxs(ctx context.Context, cursor ids.ID, limit int) ([]*chain.Transaction, ids.ID, bool) {
+	return vm.mempool.List(ctx, cursor, limit)
+}
+
+func (vm *VM) GetMempoolSponsorTxs(ctx context.Context, sponsor codec.Address, limit int) []*chain.Transaction {
+	return vm.mempool.Sponsored(ctx, sponsor, limit)
+}
+
+func (vm *VM) GetMempoolSponsors(ctx context.Context, limit int) ([]*mempool.SponsorUsage, int) {
+	return vm.mempool.Sponsors(ctx, limit)
+}
+
 func (vm *VM) GetVerifyAuth() bool {
 	return vm.config.GetVerifyAuth()
 }
 
+func (vm *VM) GetArchive() bool {
+	return vm.config.GetArchive()
+}
+
+func (vm *VM) GetTransactionTracing() bool {
+	return vm.config.GetTransactionTracing()
+}
+
 func (vm *VM) RecordTxsGossiped(c int) {
 	vm.metrics.txsGossiped.Add(float64(c))
 }
@@ -429,6 +516,30 @@ func (vm *VM) RecordSeenTxsReceived(c int) {
 	vm.metrics.seenTxsReceived.Add(float64(c))
 }
 
+func (vm *VM) RecordInvalidTxsReceived(c int) {
+	vm.metrics.invalidTxsReceived.Add(float64(c))
+}
+
+func (vm *VM) RecordExpiredTxsReceived(c int) {
+	vm.metrics.expiredTxsReceived.Add(float64(c))
+}
+
+func (vm *VM) RecordRateLimitedTxsReceived(c int) {
+	vm.metrics.rateLimitedTxsReceived.Add(float64(c))
+}
+
+func (vm *VM) RecordIgnoredGossip() {
+	vm.metrics.ignoredGossip.Inc()
+}
+
+func (vm *VM) RecordPeersIgnored() {
+	vm.metrics.peersIgnored.Inc()
+}
+
+func (vm *VM) GossipPeerScores() []*gossiper.PeerScore {
+	return vm.gossiper.PeerScores()
+}
+
 func (vm *VM) RecordBuildCapped() {
 	vm.metrics.buildCapped.Inc()
 }
@@ -473,18 +584,45 @@ func (vm *VM) RecordClearedMempool() {
 	vm.metrics.clearedMempool.Inc()
 }
 
-func (vm *VM) UnitPrices(context.Context) (chain.Dimensions, error) {
-	v, err := vm.stateDB.Get(chain.FeeKey(vm.StateManager().FeeKey()))
+// UnitPrices returns the unit prices [Submit] requires transactions to pay
+// (those of a block built on the preferred block now). If the VM is not ready,
+// it returns the unit prices of the last accepted block.
+func (vm *VM) UnitPrices(ctx context.Context) (chain.Dimensions, error) {
+	if !vm.isReady() {
+		v, err := vm.stateDB.Get(chain.FeeKey(vm.StateManager().FeeKey()))
+		if err != nil {
+			return chain.Dimensions{}, err
+		}
+		return chain.NewFeeManager(v).UnitPrices(), nil
+	}
+	blk, err := vm.GetStatelessBlock(ctx, vm.preferred)
 	if err != nil {
 		return chain.Dimensions{}, err
 	}
-	return chain.NewFeeManager(v).UnitPrices(), nil
+	view, err := blk.View(ctx, false)
+	if err != nil {
+		return chain.Dimensions{}, err
+	}
+	feeRaw, err := view.GetValue(ctx, chain.FeeKey(vm.StateManager().FeeKey()))
+	if err != nil {
+		return chain.Dimensions{}, err
+	}
+	now := time.Now().UnixMilli()
+	nextFeeManager, err := chain.NewFeeManager(feeRaw).ComputeNext(blk.Tmstmp, now, vm.c.Rules(now))
+	if err != nil {
+		return chain.Dimensions{}, err
+	}
+	return nextFeeManager.UnitPrices(), nil
 }
 
 func (vm *VM) GetTransactionExecutionCores() int {
 	return vm.config.GetTransactionExecutionCores()
 }
 
+func (vm *VM) GetOptimisticTransactionExecution() bool {
+	return vm.config.GetOptimisticTransactionExecution()
+}
+
 func (vm *VM) GetExecutorBuildRecorder() executor.Metrics {
 	return vm.metrics.executorBuildRecorder
 }
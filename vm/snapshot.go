@@ -0,0 +1,311 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/snapshot"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	snapshotPageSize        = 1024
+	snapshotImportBatchSize = 4 * units.MiB
+)
+
+// ExportSnapshot writes the post-execution state of the accepted block at
+// [height] to [w], along with genesis and enough blocks before [height] to
+// backfill a [ValidityWindow] of transactions (see [ImportSnapshot]).
+//
+// Like [ReadStateAt], [height] must be one of the last [GetStateHistoryLength]
+// roots and it must remain so until the export completes, otherwise
+// [ErrStatePruned] is returned.
+func (vm *VM) ExportSnapshot(ctx context.Context, height uint64, w io.Writer) (*snapshot.Manifest, error) {
+	if !vm.isReady() {
+		return nil, ErrNotReady
+	}
+	root, err := vm.stateRootAt(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, _, err := vm.GetAcceptedBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mirror the walk in [backfillSeenTransactions], which loads the first
+	// block outside of the [ValidityWindow] before stopping
+	r := vm.Rules(blk.Tmstmp)
+	blocks := []*chain.StatelessBlock{blk}
+	for curr := blk; curr.Hght > 1 && blk.Tmstmp-curr.Tmstmp <= r.GetValidityWindow(); {
+		prev, _, err := vm.GetAcceptedBlock(ctx, curr.Hght-1)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, prev)
+		curr = prev
+	}
+	if blocks[len(blocks)-1].Hght != 0 {
+		blocks = append(blocks, vm.genesisBlk)
+	}
+
+	m := &snapshot.Manifest{
+		Version:      snapshot.Version,
+		NetworkID:    vm.snowCtx.NetworkID,
+		ChainID:      vm.snowCtx.ChainID,
+		Height:       height,
+		BlockID:      blk.ID(),
+		Timestamp:    blk.Tmstmp,
+		StateRoot:    root,
+		BranchFactor: vm.genesis.GetStateBranchFactor(),
+	}
+	sw, err := snapshot.NewWriter(w, m)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if err := sw.WriteBlock(blocks[i].Hght, blocks[i].Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Page through the state at [root] with range proofs (which are served
+	// from history, so the state can't change underneath us)
+	start := maybe.Nothing[[]byte]()
+	for {
+		proof, err := vm.stateDB.GetRangeProofAtRoot(ctx, root, start, maybe.Nothing[[]byte](), snapshotPageSize)
+		if errors.Is(err, merkledb.ErrInsufficientHistory) {
+			return nil, ErrStatePruned
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range proof.KeyValues {
+			if err := sw.WriteKeyValue(kv.Key, kv.Value); err != nil {
+				return nil, err
+			}
+		}
+		if len(proof.KeyValues) < snapshotPageSize {
+			break
+		}
+		next := slices.Clone(proof.KeyValues[len(proof.KeyValues)-1].Key)
+		start = maybe.Some(append(next, 0x0))
+	}
+	return m, sw.Close()
+}
+
+// SnapshotCheckpoint is what the caller of [ImportSnapshot] trusts about the
+// chain, which must be obtained independently of the snapshot (like from a
+// node they run).
+type SnapshotCheckpoint struct {
+	NetworkID uint32
+	ChainID   ids.ID
+	GenesisID ids.ID
+
+	// BlockID is the ID of the accepted block at the height of the snapshot
+	// and StateRoot is the root of its post-execution state (the [StateRoot]
+	// of its child).
+	BlockID   ids.ID
+	StateRoot ids.ID
+}
+
+// ImportSnapshot initializes the databases of a new node (see [storage.New])
+// with the snapshot in [r] so that it can start from [Manifest.Height]
+// without replaying blocks from genesis or state syncing.
+//
+// The snapshot is only accepted if it is for the chain in [checkpoint], its
+// blocks (parsed with [parser]) link back from [checkpoint.BlockID], its
+// genesis block is [checkpoint.GenesisID], and its state matches
+// [checkpoint.StateRoot]. If an error is returned, [vmDB] and [rawStateDB]
+// should be discarded.
+func ImportSnapshot(
+	ctx context.Context,
+	r io.Reader,
+	parser chain.Parser,
+	checkpoint *SnapshotCheckpoint,
+	vmDB database.Database,
+	rawStateDB database.Database,
+) (*snapshot.Manifest, error) {
+	has, err := vmDB.Has(lastAccepted)
+	if err != nil {
+		return nil, err
+	}
+	if has || !isEmpty(rawStateDB) {
+		return nil, ErrDatabaseNotEmpty
+	}
+
+	sr, err := snapshot.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+	m := sr.Manifest()
+	if m.NetworkID != checkpoint.NetworkID || m.ChainID != checkpoint.ChainID {
+		return nil, fmt.Errorf(
+			"%w: expected %d/%s but got %d/%s",
+			ErrUnexpectedChain,
+			checkpoint.NetworkID,
+			checkpoint.ChainID,
+			m.NetworkID,
+			m.ChainID,
+		)
+	}
+	if m.BlockID != checkpoint.BlockID {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedBlock, checkpoint.BlockID, m.BlockID)
+	}
+	if m.StateRoot != checkpoint.StateRoot {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedStateRoot, checkpoint.StateRoot, m.StateRoot)
+	}
+
+	stateDB, err := newOfflineStateDB(ctx, rawStateDB, m.BranchFactor)
+	if err != nil {
+		return nil, err
+	}
+	// Blocks are only written to [vmDB] once the state is verified
+	vmBatch := vmDB.NewBatch()
+	err = importSnapshotRecords(ctx, sr, parser, checkpoint, vmBatch, stateDB)
+	if closeErr := stateDB.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := vmBatch.Put(lastAccepted, binary.BigEndian.AppendUint64(nil, m.Height)); err != nil {
+		return nil, err
+	}
+	return m, vmBatch.Write()
+}
+
+// importSnapshotRecords writes the blocks in [sr] to [vmBatch] and its state
+// to [stateDB] (and verifies both against [checkpoint]).
+//
+// Blocks are written in increasing height order (see [ExportSnapshot]), so
+// the block at each height must be the parent of the next (except for
+// genesis, which is followed by the first block outside of the
+// [ValidityWindow]). Because the last block must be [checkpoint.BlockID],
+// this ensures all blocks were accepted.
+func importSnapshotRecords(
+	ctx context.Context,
+	sr *snapshot.Reader,
+	parser chain.Parser,
+	checkpoint *SnapshotCheckpoint,
+	vmBatch database.Batch,
+	stateDB merkledb.MerkleDB,
+) error {
+	m := sr.Manifest()
+	var prev *chain.StatefulBlock
+	var prevID ids.ID
+	stateBatch := stateDB.NewBatch()
+	for {
+		record, err := sr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch record.Type {
+		case snapshot.BlockRecord:
+			blk, err := chain.UnmarshalBlock(record.Block, parser)
+			if err != nil {
+				return err
+			}
+			blkID := utils.ToID(record.Block)
+			switch {
+			case blk.Hght != record.Height:
+				return fmt.Errorf("%w: %s has height %d but was stored at %d", ErrUnexpectedBlock, blkID, blk.Hght, record.Height)
+			case prev == nil && (blk.Hght != 0 || blkID != checkpoint.GenesisID):
+				return fmt.Errorf("%w: expected genesis %s but got %s at %d", ErrUnexpectedBlock, checkpoint.GenesisID, blkID, blk.Hght)
+			case prev != nil && blk.Hght <= prev.Hght:
+				return fmt.Errorf("%w: %d is not after %d", ErrUnexpectedBlock, blk.Hght, prev.Hght)
+			case prev != nil && prev.Hght > 0 && blk.Hght != prev.Hght+1:
+				return fmt.Errorf("%w: %d-%d", ErrMissingBlock, prev.Hght+1, blk.Hght-1)
+			case prev != nil && prev.Hght == 0 && blk.Hght > 1 && m.Timestamp-blk.Tmstmp <= parser.Rules(m.Timestamp).GetValidityWindow():
+				// Mirror the walk in [ExportSnapshot]
+				return fmt.Errorf("%w: %d-%d (in validity window)", ErrMissingBlock, 1, blk.Hght-1)
+			case prev != nil && blk.Hght == prev.Hght+1 && blk.Prnt != prevID:
+				return fmt.Errorf("%w: parent of %s is %s but expected %s", ErrUnexpectedBlock, blkID, blk.Prnt, prevID)
+			case blk.Hght > m.Height:
+				return fmt.Errorf("%w: %d is after %d", ErrUnexpectedBlock, blk.Hght, m.Height)
+			}
+			if err := vmBatch.Put(PrefixBlockKey(record.Height), record.Block); err != nil {
+				return err
+			}
+			if err := vmBatch.Put(PrefixBlockIDHeightKey(blkID), binary.BigEndian.AppendUint64(nil, record.Height)); err != nil {
+				return err
+			}
+			if err := vmBatch.Put(PrefixBlockHeightIDKey(record.Height), blkID[:]); err != nil {
+				return err
+			}
+			prev, prevID = blk, blkID
+		case snapshot.KeyValueRecord:
+			if err := stateBatch.Put(record.Key, record.Value); err != nil {
+				return err
+			}
+			if stateBatch.Size() < snapshotImportBatchSize {
+				continue
+			}
+			if err := stateBatch.Write(); err != nil {
+				return err
+			}
+			stateBatch.Reset()
+		}
+	}
+	if err := stateBatch.Write(); err != nil {
+		return err
+	}
+	if prev == nil || prev.Hght != m.Height {
+		return fmt.Errorf("%w: %d", ErrMissingBlock, m.Height)
+	}
+	if prevID != checkpoint.BlockID || prev.Tmstmp != m.Timestamp {
+		return fmt.Errorf("%w: expected %s at %d but got %s at %d", ErrUnexpectedBlock, checkpoint.BlockID, m.Timestamp, prevID, prev.Tmstmp)
+	}
+	root, err := stateDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if root != checkpoint.StateRoot {
+		return fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedStateRoot, checkpoint.StateRoot, root)
+	}
+	return nil
+}
+
+// newOfflineStateDB opens [db] as a [merkledb.MerkleDB] outside of a running
+// VM (with minimal caches and no history).
+func newOfflineStateDB(ctx context.Context, db database.Database, bf merkledb.BranchFactor) (merkledb.MerkleDB, error) {
+	return merkledb.New(ctx, db, merkledb.Config{
+		BranchFactor:                bf,
+		RootGenConcurrency:          1,
+		HistoryLength:               1,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.MiB,
+		IntermediateWriteBatchSize:  256 * units.KiB,
+		Reg:                         prometheus.NewRegistry(),
+		TraceLevel:                  merkledb.InfoTrace,
+		Tracer:                      trace.Noop,
+	})
+}
+
+func isEmpty(db database.Database) bool {
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	return !iter.Next()
+}
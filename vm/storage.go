@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
@@ -40,6 +41,8 @@ const (
 	blockHeightIDPrefix = 0x2 // Height -> ID (don't always need full block from disk)
 	warpSignaturePrefix = 0x3
 	warpFetchPrefix     = 0x4
+	blockResultsPrefix  = 0x8 // Height -> Results (0x5-0x7 are used by the indexer)
+	warpPendingPrefix   = 0xc // txID -> start time + unsigned message (0x9-0xb are used by archives and traces)
 )
 
 var (
@@ -70,6 +73,13 @@ func PrefixBlockHeightIDKey(height uint64) []byte {
 	return k
 }
 
+func PrefixBlockResultsKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = blockResultsPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
 func (vm *VM) HasGenesis() (bool, error) {
 	return vm.HasDiskBlock(0)
 }
@@ -120,6 +130,7 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	if err := batch.Put(PrefixBlockKey(blk.Height()), blk.Bytes()); err != nil {
 		return err
 	}
+	vm.metrics.blockBytes.Add(float64(len(blk.Bytes())))
 	if err := batch.Put(PrefixBlockIDHeightKey(blk.ID()), bigEndianHeight); err != nil {
 		return err
 	}
@@ -127,9 +138,46 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	if err := batch.Put(PrefixBlockHeightIDKey(blk.Height()), blkID[:]); err != nil {
 		return err
 	}
+	// Blocks accepted during state sync are never executed, so we don't have
+	// their results.
+	if results := blk.Results(); len(results) == len(blk.Txs) {
+		mresults, err := chain.MarshalResults(results)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(PrefixBlockResultsKey(blk.Height()), mresults); err != nil {
+			return err
+		}
+		vm.metrics.resultBytes.Add(float64(len(mresults)))
+	}
+	// Blocks are only traced if tracing was enabled when they were executed
+	if traces := blk.Traces(); len(traces) > 0 && len(traces) == len(blk.Txs) {
+		n, err := putTraces(batch, blk)
+		if err != nil {
+			return err
+		}
+		vm.metrics.traceBytes.Add(float64(n))
+	}
+	// Archive nodes record every state change (genesis is recorded by
+	// [archiveGenesis])
+	if vm.config.GetArchive() && blk.Height() > 0 {
+		changes := blk.StateChanges()
+		if changes == nil {
+			return fmt.Errorf("%w: %s", ErrMissingStateChanges, blk.ID())
+		}
+		var size int
+		for key, value := range changes {
+			n, err := putStateHistory(batch, blk.Height(), []byte(key), value)
+			if err != nil {
+				return err
+			}
+			size += n
+		}
+		vm.metrics.stateHistoryBytes.Add(float64(size))
+	}
 	expiryHeight := blk.Height() - uint64(vm.config.GetAcceptedBlockWindow())
 	var expired bool
-	if expiryHeight > 0 && expiryHeight < blk.Height() { // ensure we don't free genesis
+	if !vm.config.GetArchive() && expiryHeight > 0 && expiryHeight < blk.Height() { // ensure we don't free genesis
 		if err := batch.Delete(PrefixBlockKey(expiryHeight)); err != nil {
 			return err
 		}
@@ -144,6 +192,12 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 		if err := batch.Delete(PrefixBlockHeightIDKey(expiryHeight)); err != nil {
 			return err
 		}
+		if err := batch.Delete(PrefixBlockResultsKey(expiryHeight)); err != nil {
+			return err
+		}
+		if err := vm.deleteTraces(batch, expiryHeight); err != nil {
+			return err
+		}
 		expired = true
 		vm.metrics.deletedBlocks.Inc()
 		vm.Logger().Info("deleted block", zap.Uint64("height", expiryHeight))
@@ -151,7 +205,9 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	if err := batch.Write(); err != nil {
 		return fmt.Errorf("%w: unable to update last accepted", err)
 	}
+	vm.lastAcceptedL.Lock()
 	vm.lastAccepted = blk
+	vm.lastAcceptedL.Unlock()
 	vm.acceptedBlocksByID.Put(blk.ID(), blk)
 	vm.acceptedBlocksByHeight.Put(blk.Height(), blk.ID())
 	if expired && vm.shouldComapct(expiryHeight) {
@@ -175,6 +231,16 @@ func (vm *VM) GetDiskBlock(ctx context.Context, height uint64) (*chain.Stateless
 	return chain.ParseBlock(ctx, b, choices.Accepted, vm)
 }
 
+// GetDiskBlockResults returns the results of the transactions in the accepted
+// block at [height].
+func (vm *VM) GetDiskBlockResults(height uint64) ([]*chain.Result, error) {
+	b, err := vm.vmDB.Get(PrefixBlockResultsKey(height))
+	if err != nil {
+		return nil, err
+	}
+	return chain.UnmarshalResults(b)
+}
+
 func (vm *VM) HasDiskBlock(height uint64) (bool, error) {
 	return vm.vmDB.Has(PrefixBlockKey(height))
 }
@@ -302,6 +368,56 @@ func (vm *VM) StoreWarpFetch(txID ids.ID) error {
 	return vm.vmDB.Put(k, binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixMilli())))
 }
 
+func PrefixWarpPendingKey(txID ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = warpPendingPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+// WarpPending is a warp message that we are still gathering signatures for.
+type WarpPending struct {
+	Start int64 // when we started gathering signatures (ms)
+	Msg   []byte
+}
+
+// StoreWarpPending persists that we are still gathering signatures for the
+// warp message emitted by [txID] so that we can resume after a restart.
+func (vm *VM) StoreWarpPending(txID ids.ID, pending *WarpPending) error {
+	v := make([]byte, consts.Uint64Len+len(pending.Msg))
+	binary.BigEndian.PutUint64(v, uint64(pending.Start))
+	copy(v[consts.Uint64Len:], pending.Msg)
+	return vm.vmDB.Put(PrefixWarpPendingKey(txID), v)
+}
+
+func (vm *VM) RemoveWarpPending(txID ids.ID) error {
+	return vm.vmDB.Delete(PrefixWarpPendingKey(txID))
+}
+
+// GetWarpPending returns all warp messages (by txID) that we are still
+// gathering signatures for.
+func (vm *VM) GetWarpPending() (map[ids.ID]*WarpPending, error) {
+	iter := vm.vmDB.NewIteratorWithPrefix([]byte{warpPendingPrefix})
+	defer iter.Release()
+
+	pending := map[ids.ID]*WarpPending{}
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key()[1:])
+		if err != nil {
+			return nil, err
+		}
+		v := iter.Value()
+		if len(v) < consts.Uint64Len {
+			return nil, fmt.Errorf("%w: pending warp message of %s has length %d", ErrInvalidWarpPending, txID, len(v))
+		}
+		pending[txID] = &WarpPending{
+			Start: int64(binary.BigEndian.Uint64(v)),
+			Msg:   slices.Clone(v[consts.Uint64Len:]),
+		}
+	}
+	return pending, iter.Error()
+}
+
 func (vm *VM) GetWarpFetch(txID ids.ID) (int64, error) {
 	k := PrefixWarpFetchKey(txID)
 	v, err := vm.vmDB.Get(k)
@@ -82,7 +82,9 @@ func (s *stateSyncerClient) AcceptedSyncableBlock(
 		s.vm.snowCtx.Log.Warn("could not determine if syncing", zap.Error(err))
 		return block.StateSyncSkipped, err
 	}
-	if !syncing && (s.vm.lastAccepted.Hght+s.vm.config.GetStateSyncMinBlocks() > sb.Height()) {
+	// Archive nodes must execute every block to retain all state history (and
+	// are always initialized from genesis, so they are never syncing).
+	if !syncing && (s.vm.config.GetArchive() || s.vm.lastAccepted.Hght+s.vm.config.GetStateSyncMinBlocks() > sb.Height()) {
 		s.vm.snowCtx.Log.Info(
 			"bypassing state sync",
 			zap.Uint64("lastAccepted", s.vm.lastAccepted.Hght),
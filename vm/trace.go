@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	txTracePrefix      = 0xa // txID -> height + trace
+	tracedHeightPrefix = 0xb // height -> txIDs traced (for pruning)
+)
+
+func PrefixTxTraceKey(txID ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = txTracePrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+func PrefixTracedHeightKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = tracedHeightPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// putTraces records the [chain.TxTrace] of each transaction in [blk] and
+// returns the number of bytes written.
+func putTraces(batch database.Batch, blk *chain.StatelessBlock) (int, error) {
+	var (
+		size   int
+		height = blk.Height()
+		traces = blk.Traces()
+		txIDs  = make([]byte, 0, len(blk.Txs)*consts.IDLen)
+	)
+	for i, tx := range blk.Txs {
+		p := codec.NewWriter(consts.Uint64Len, consts.MaxInt)
+		p.PackUint64(height)
+		traces[i].Marshal(p)
+		if err := p.Err(); err != nil {
+			return 0, err
+		}
+		txID := tx.ID()
+		k := PrefixTxTraceKey(txID)
+		if err := batch.Put(k, p.Bytes()); err != nil {
+			return 0, err
+		}
+		size += len(k) + len(p.Bytes())
+		txIDs = append(txIDs, txID[:]...)
+	}
+	k := PrefixTracedHeightKey(height)
+	if err := batch.Put(k, txIDs); err != nil {
+		return 0, err
+	}
+	return size + len(k) + len(txIDs), nil
+}
+
+// deleteTraces deletes the traces of the transactions in the block at
+// [height] (if any).
+func (vm *VM) deleteTraces(batch database.Batch, height uint64) error {
+	k := PrefixTracedHeightKey(height)
+	txIDs, err := vm.vmDB.Get(k)
+	if errors.Is(err, database.ErrNotFound) {
+		// Tracing may have been enabled after [height] was accepted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := 0; i+consts.IDLen <= len(txIDs); i += consts.IDLen {
+		if err := batch.Delete(PrefixTxTraceKey(ids.ID(txIDs[i : i+consts.IDLen]))); err != nil {
+			return err
+		}
+	}
+	return batch.Delete(k)
+}
+
+// GetTxTrace returns the height of the block that included [txID] and its
+// [chain.TxTrace] (if [txID] was traced and is in the accepted block window).
+func (vm *VM) GetTxTrace(txID ids.ID) (bool, uint64, *chain.TxTrace, error) {
+	if !vm.config.GetTransactionTracing() {
+		return false, 0, nil, ErrTracingDisabled
+	}
+	v, err := vm.vmDB.Get(PrefixTxTraceKey(txID))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	p := codec.NewReader(v, consts.MaxInt)
+	height := p.UnpackUint64(false)
+	trace, err := chain.UnmarshalTxTrace(p)
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if !p.Empty() {
+		return false, 0, nil, chain.ErrInvalidObject
+	}
+	return true, height, trace, nil
+}
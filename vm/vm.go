@@ -4,10 +4,13 @@
 package vm
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -23,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
@@ -35,6 +39,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/mempool"
@@ -70,7 +75,15 @@ type VM struct {
 	tracer  trace.Tracer
 	mempool *mempool.Mempool[*chain.Transaction]
 
-	// track all accepted but still valid txs (replay protection)
+	// only populated if the mempool is ordered by fee
+	prioritizer *feePrioritizer
+
+	// only populated if transaction indexing is enabled
+	indexer Indexer
+
+	// track all accepted but still valid txs (replay protection), unless
+	// [nonces] are enabled
+	nonces                 bool
 	seen                   *emap.EMap[*chain.Transaction]
 	startSeenTime          int64
 	seenValidityWindowOnce sync.Once
@@ -89,6 +102,12 @@ type VM struct {
 	acceptedBlocksByID     *hcache.FIFO[ids.ID, *chain.StatelessBlock]
 	acceptedBlocksByHeight *hcache.FIFO[uint64, ids.ID]
 
+	// stateRoots maps the height of recently accepted blocks to their
+	// post-execution state root (which is only otherwise available once
+	// a child is accepted). This is only populated while merkledb has
+	// the history to serve reads at these roots.
+	stateRoots *hcache.FIFO[uint64, ids.ID]
+
 	// Accepted block queue
 	acceptedQueue chan *chain.StatelessBlock
 	acceptorDone  chan struct{}
@@ -103,9 +122,14 @@ type VM struct {
 	bootstrapped utils.Atomic[bool]
 	genesisBlk   *chain.StatelessBlock
 	preferred    ids.ID
-	lastAccepted *chain.StatelessBlock
 	toEngine     chan<- common.Message
 
+	// [lastAccepted] is updated by the acceptor, so it must be read under
+	// [lastAcceptedL] by anything that may run concurrently with it (like
+	// RPC handlers)
+	lastAcceptedL sync.RWMutex
+	lastAccepted  *chain.StatelessBlock
+
 	// State Sync client and AppRequest handlers
 	stateSyncClient        *stateSyncerClient
 	stateSyncNetworkClient syncEng.NetworkClient
@@ -115,6 +139,10 @@ type VM struct {
 	// txID
 	warpManager *WarpManager
 
+	// Warp state reader fetches signatures from other validators over the
+	// values of keys in accepted state
+	warpStateReader *WarpStateReader
+
 	// Network manager routes p2p messages to pre-registered handlers
 	networkManager *network.Manager
 
@@ -185,6 +213,7 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("implementation initialization failed: %w", err)
 	}
+	_, vm.nonces = vm.c.StateManager().(chain.NonceManager)
 
 	// Setup tracer
 	vm.tracer, err = htrace.New(vm.config.GetTraceConfig())
@@ -242,15 +271,42 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return err
 	}
+	vm.stateRoots, err = hcache.NewFIFO[uint64, ids.ID](vm.config.GetStateHistoryLength())
+	if err != nil {
+		return err
+	}
 	vm.acceptedQueue = make(chan *chain.StatelessBlock, vm.config.GetAcceptorSize())
 	vm.acceptorDone = make(chan struct{})
 
-	vm.mempool = mempool.New[*chain.Transaction](
-		vm.tracer,
-		vm.config.GetMempoolSize(),
-		vm.config.GetMempoolSponsorSize(),
-		vm.config.GetMempoolExemptSponsors(),
-	)
+	if vm.config.GetMempoolFeeOrdering() {
+		vm.prioritizer = newFeePrioritizer(vm)
+		vm.mempool = mempool.NewPrioritized[*chain.Transaction](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+			vm.prioritizer,
+		)
+	} else {
+		vm.mempool = mempool.New[*chain.Transaction](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+		)
+	}
+
+	if vm.config.GetTransactionIndexing() {
+		if ic, ok := vm.c.(IndexerController); ok {
+			vm.indexer = ic.Indexer(vm.vmDB)
+		} else {
+			window := vm.config.GetAcceptedBlockWindow()
+			if vm.config.GetArchive() {
+				window = 0 // never prune
+			}
+			vm.indexer = newDBIndexer(vm.vmDB, window)
+		}
+	}
 
 	// Try to load last accepted
 	has, err := vm.HasLastAccepted()
@@ -265,6 +321,21 @@ func (vm *VM) Initialize(
 			return err
 		}
 		vm.genesisBlk = genesisBlk
+		archived, err := vm.vmDB.Has(isArchive)
+		if err != nil {
+			return err
+		}
+		switch {
+		case vm.config.GetArchive() && !archived:
+			snowCtx.Log.Error("cannot enable archive on existing database")
+			return ErrArchiveIncomplete
+		case !vm.config.GetArchive() && archived:
+			// Anything retained while archiving is never pruned
+			snowCtx.Log.Warn("disabling archive")
+			if err := vm.vmDB.Delete(isArchive); err != nil {
+				return err
+			}
+		}
 		lastAcceptedHeight, err := vm.GetLastAcceptedHeight()
 		if err != nil {
 			snowCtx.Log.Error("could not get last accepted height", zap.Error(err))
@@ -342,8 +413,16 @@ func (vm *VM) Initialize(
 			return err
 		}
 
+		if vm.config.GetArchive() {
+			if err := vm.archiveGenesis(); err != nil {
+				snowCtx.Log.Error("could not archive genesis state", zap.Error(err))
+				return err
+			}
+		}
+
 		// Update last accepted and preferred block
 		vm.genesisBlk = genesisBlk
+		vm.stateRoots.Put(0, genesisRoot)
 		if err := vm.UpdateLastAccepted(genesisBlk); err != nil {
 			snowCtx.Log.Error("could not set genesis block as last accepted", zap.Error(err))
 			return err
@@ -383,6 +462,11 @@ func (vm *VM) Initialize(
 	gossipHandler, gossipSender := vm.networkManager.Register()
 	vm.networkManager.SetHandler(gossipHandler, NewTxGossipHandler(vm))
 
+	// Setup warp state read networking
+	warpStateReadHandler, warpStateReadSender := vm.networkManager.Register()
+	vm.warpStateReader = NewWarpStateReader(vm, warpStateReadSender)
+	vm.networkManager.SetHandler(warpStateReadHandler, NewWarpStateReadHandler(vm))
+
 	// Startup block builder and gossiper
 	go vm.builder.Run()
 	go vm.gossiper.Run(gossipSender)
@@ -405,6 +489,22 @@ func (vm *VM) Initialize(
 	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, vm.config.GetStreamingBacklogSize())
 	vm.webSocketServer = webSocketServer
 	vm.handlers[rpc.WebSocketEndpoint] = pubsubServer
+	if vm.config.GetSnapshotAPIEnabled() {
+		if _, ok := vm.handlers[rpc.SnapshotEndpoint]; ok {
+			return fmt.Errorf("duplicate snapshot handler found: %s", rpc.SnapshotEndpoint)
+		}
+		vm.handlers[rpc.SnapshotEndpoint] = rpc.NewSnapshotServer(vm)
+	}
+	if vm.config.GetAdminAPIEnabled() {
+		if _, ok := vm.handlers[rpc.AdminJSONRPCEndpoint]; ok {
+			return fmt.Errorf("duplicate admin JSONRPC handler found: %s", rpc.AdminJSONRPCEndpoint)
+		}
+		adminHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewAdminJSONRPCServer(vm))
+		if err != nil {
+			return fmt.Errorf("unable to create admin handler: %w", err)
+		}
+		vm.handlers[rpc.AdminJSONRPCEndpoint] = adminHandler
+	}
 	return nil
 }
 
@@ -470,6 +570,162 @@ func (vm *VM) ReadState(ctx context.Context, keys [][]byte) ([][]byte, []error)
 	return vm.stateDB.GetValues(ctx, keys)
 }
 
+// ReadStateAt reads [keys] from the post-execution state of the accepted
+// block at [height]. Only the last [GetStateHistoryLength] roots committed
+// since the VM started can be read, otherwise [ErrStatePruned] is returned.
+//
+// Archive nodes can read state at any accepted height.
+func (vm *VM) ReadStateAt(ctx context.Context, height uint64, keys [][]byte) ([][]byte, []error) {
+	if !vm.isReady() {
+		return hutils.Repeat[[]byte](nil, len(keys)), hutils.Repeat(ErrNotReady, len(keys))
+	}
+	if vm.config.GetArchive() {
+		if height > vm.LastAcceptedBlock().Hght {
+			return hutils.Repeat[[]byte](nil, len(keys)), hutils.Repeat(ErrHeightNotAccepted, len(keys))
+		}
+		values := make([][]byte, len(keys))
+		errs := make([]error, len(keys))
+		for i, k := range keys {
+			values[i], errs[i] = vm.readStateHistory(height, k)
+		}
+		return values, errs
+	}
+	root, err := vm.stateRootAt(height)
+	if err != nil {
+		return hutils.Repeat[[]byte](nil, len(keys)), hutils.Repeat(err, len(keys))
+	}
+	values := make([][]byte, len(keys))
+	errs := make([]error, len(keys))
+	for i, k := range keys {
+		values[i], errs[i] = vm.readStateAtRoot(ctx, root, k)
+	}
+	return values, errs
+}
+
+// GetStateProofs returns a proof of the value (or absence) of each of [keys]
+// against the [StateRoot] of the accepted block at [height]. Because roots are
+// deferred, this is the post-execution state of the block at [height]-1.
+func (vm *VM) GetStateProofs(ctx context.Context, height uint64, keys [][]byte) (ids.ID, []*merkledb.RangeProof, error) {
+	if !vm.isReady() {
+		return ids.Empty, nil, ErrNotReady
+	}
+	if height > vm.LastAcceptedBlock().Hght {
+		return ids.Empty, nil, ErrHeightNotAccepted
+	}
+	blkID, err := vm.GetBlockIDAtHeight(ctx, height)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	blk, err := vm.GetStatelessBlock(ctx, blkID)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	proofs := make([]*merkledb.RangeProof, len(keys))
+	for i, k := range keys {
+		proof, err := vm.stateDB.GetRangeProofAtRoot(ctx, blk.StateRoot, maybe.Some(k), maybe.Some(k), 1)
+		if errors.Is(err, merkledb.ErrInsufficientHistory) {
+			return ids.Empty, nil, ErrStatePruned
+		}
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		proofs[i] = proof
+	}
+	return blk.StateRoot, proofs, nil
+}
+
+// GetAcceptedBlock returns the accepted block at [height] and the results of
+// its transactions. Unless the node is an archive, only the last
+// [AcceptedBlockWindow] blocks (and genesis) are retained, otherwise
+// [ErrBlockPruned] is returned.
+//
+// Blocks accepted during state sync were never executed, so their results are
+// nil.
+func (vm *VM) GetAcceptedBlock(ctx context.Context, height uint64) (*chain.StatelessBlock, []*chain.Result, error) {
+	lastAccepted := vm.LastAcceptedBlock().Hght
+	if height > lastAccepted {
+		return nil, nil, ErrHeightNotAccepted
+	}
+	if !vm.config.GetArchive() && height > 0 && lastAccepted-height >= uint64(vm.config.GetAcceptedBlockWindow()) {
+		return nil, nil, ErrBlockPruned
+	}
+	blkID, err := vm.GetBlockIDAtHeight(ctx, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	blk, err := vm.GetStatelessBlock(ctx, blkID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Blocks loaded from disk are not executed again
+	if results := blk.Results(); results != nil || len(blk.Txs) == 0 {
+		return blk, results, nil
+	}
+	results, err := vm.GetDiskBlockResults(height)
+	if errors.Is(err, database.ErrNotFound) {
+		return blk, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return blk, results, nil
+}
+
+// GetAcceptedBlockByID is the same as [GetAcceptedBlock] but looks up the
+// block by [blkID]. If [blkID] is not accepted (or has been pruned),
+// [ErrBlockNotAccepted] is returned.
+func (vm *VM) GetAcceptedBlockByID(ctx context.Context, blkID ids.ID) (*chain.StatelessBlock, []*chain.Result, error) {
+	blk, err := vm.GetStatelessBlock(ctx, blkID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil, ErrBlockNotAccepted
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	// [GetStatelessBlock] also returns verified (but not yet accepted) blocks
+	acceptedID, err := vm.GetBlockIDAtHeight(ctx, blk.Hght)
+	if errors.Is(err, database.ErrNotFound) || (err == nil && acceptedID != blkID) {
+		return nil, nil, ErrBlockNotAccepted
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	blk, results, err := vm.GetAcceptedBlock(ctx, blk.Hght)
+	if errors.Is(err, ErrHeightNotAccepted) || errors.Is(err, ErrBlockPruned) {
+		return nil, nil, ErrBlockNotAccepted
+	}
+	return blk, results, err
+}
+
+func (vm *VM) stateRootAt(height uint64) (ids.ID, error) {
+	if root, ok := vm.stateRoots.Get(height); ok {
+		return root, nil
+	}
+	if height > vm.LastAcceptedBlock().Hght {
+		return ids.Empty, ErrHeightNotAccepted
+	}
+	return ids.Empty, ErrStatePruned
+}
+
+// readStateAtRoot uses a single-key range proof to read [key] from the
+// merkledb history at [root].
+func (vm *VM) readStateAtRoot(ctx context.Context, root ids.ID, key []byte) ([]byte, error) {
+	proof, err := vm.stateDB.GetRangeProofAtRoot(ctx, root, maybe.Some(key), maybe.Some(key), 1)
+	switch {
+	case errors.Is(err, merkledb.ErrInsufficientHistory):
+		return nil, ErrStatePruned
+	case errors.Is(err, merkledb.ErrEmptyProof):
+		// [root] is the root of an empty trie
+		return nil, database.ErrNotFound
+	case err != nil:
+		return nil, err
+	}
+	if len(proof.KeyValues) == 0 || !bytes.Equal(proof.KeyValues[0].Key, key) {
+		return nil, database.ErrNotFound
+	}
+	return proof.KeyValues[0].Value, nil
+}
+
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	switch state {
 	case snow.StateSyncing:
@@ -811,6 +1067,9 @@ func (vm *VM) Submit(
 	if err != nil {
 		return []error{err}
 	}
+	if vm.prioritizer != nil {
+		vm.prioritizer.SetUnitPrices(nextFeeManager.UnitPrices())
+	}
 
 	// Find repeats
 	oldestAllowed := now - r.GetValidityWindow()
@@ -819,11 +1078,26 @@ func (vm *VM) Submit(
 		return []error{err}
 	}
 
-	validTxs := []*chain.Transaction{}
-	for i, tx := range txs {
+	var (
+		validTxs = []*chain.Transaction{}
+		pending  = map[codec.Address]map[uint64]bool{} // nonces of [validTxs]
+	)
+	// Transactions are checked in nonce order, so a transaction can fill the
+	// nonce gap of one that precedes it in [txs] (errors are still returned in
+	// the order of [txs]).
+	order := make([]int, len(txs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return txs[order[a]].Nonce() < txs[order[b]].Nonce()
+	})
+	errs = make([]error, len(txs))
+	for _, i := range order {
+		tx := txs[i]
 		// Check if transaction is a repeat before doing any extra work
 		if repeats.Contains(i) {
-			errs = append(errs, chain.ErrDuplicateTx)
+			errs[i] = chain.ErrDuplicateTx
 			continue
 		}
 
@@ -832,14 +1106,14 @@ func (vm *VM) Submit(
 		if vm.mempool.Has(ctx, txID) {
 			// Don't remove from listeners, it will be removed elsewhere if not
 			// included
-			errs = append(errs, ErrNotAdded)
+			errs[i] = ErrNotAdded
 			continue
 		}
 
 		// Ensure state keys are valid
 		_, err := tx.StateKeys(vm.c.StateManager())
 		if err != nil {
-			errs = append(errs, ErrNotAdded)
+			errs[i] = ErrNotAdded
 			continue
 		}
 
@@ -848,7 +1122,7 @@ func (vm *VM) Submit(
 			msg, err := tx.Digest()
 			if err != nil {
 				// Should never fail
-				errs = append(errs, err)
+				errs[i] = err
 				continue
 			}
 			if err := tx.Auth.Verify(ctx, msg); err != nil {
@@ -858,7 +1132,7 @@ func (vm *VM) Submit(
 				if err := vm.webSocketServer.RemoveTx(txID, err); err != nil {
 					vm.snowCtx.Log.Warn("unable to remove tx from webSocketServer", zap.Error(err))
 				}
-				errs = append(errs, err)
+				errs[i] = err
 				continue
 			}
 		}
@@ -872,12 +1146,30 @@ func (vm *VM) Submit(
 		// Note, [PreExecute] ensures that the pending transaction does not have
 		// an expiry time further ahead than [ValidityWindow]. This ensures anything
 		// added to the [Mempool] is immediately executable.
-		if err := tx.PreExecute(ctx, nextFeeManager, vm.c.StateManager(), r, view, now); err != nil {
-			errs = append(errs, err)
-			continue
+		//
+		// If nonces are enabled, a transaction with a nonce ahead of its sponsor
+		// is still added if the transactions that fill the gap are pending.
+		if err := tx.PreExecuteWithMaxFee(ctx, nextFeeManager, vm.c.StateManager(), r, view, now); err != nil {
+			if !errors.Is(err, chain.ErrNonceTooHigh) {
+				errs[i] = err
+				continue
+			}
+			filled, ferr := vm.fillsNonceGap(ctx, view, tx, pending)
+			if ferr != nil {
+				errs[i] = ferr
+				continue
+			}
+			if !filled {
+				errs[i] = err
+				continue
+			}
 		}
-		errs = append(errs, nil)
 		validTxs = append(validTxs, tx)
+		sponsor := tx.Sponsor()
+		if _, ok := pending[sponsor]; !ok {
+			pending[sponsor] = map[uint64]bool{}
+		}
+		pending[sponsor][tx.Nonce()] = true
 	}
 	vm.mempool.Add(ctx, validTxs)
 	vm.checkActivity(ctx)
@@ -885,6 +1177,36 @@ func (vm *VM) Submit(
 	return errs
 }
 
+// SimulateTx executes [tx] on top of the last accepted state (using the unit
+// prices of the next block) without persisting any changes. It returns the
+// [chain.Result] of [tx] and all keys it touched.
+//
+// [tx] does not need to be signed (see [chain.UnmarshalUnsignedTx]).
+func (vm *VM) SimulateTx(ctx context.Context, tx *chain.Transaction) (*chain.Result, []string, error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.SimulateTx")
+	defer span.End()
+
+	if !vm.isReady() {
+		return nil, nil, ErrNotReady
+	}
+	blk := vm.LastAcceptedBlock()
+	view, err := blk.View(ctx, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	feeRaw, err := view.GetValue(ctx, chain.FeeKey(vm.StateManager().FeeKey()))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now().UnixMilli()
+	r := vm.c.Rules(now)
+	feeManager, err := chain.NewFeeManager(feeRaw).ComputeNext(blk.Tmstmp, now, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx.Simulate(ctx, feeManager, vm.c.StateManager(), r, view, now)
+}
+
 // "SetPreference" implements "block.ChainVM"
 // replaces "core.SnowmanVM.SetPreference"
 func (vm *VM) SetPreference(_ context.Context, id ids.ID) error {
@@ -1057,7 +1379,12 @@ func (vm *VM) backfillSeenTransactions() {
 		}
 
 		// It is ok to add transactions from newest to oldest
-		vm.seen.Add(blk.Txs)
+		//
+		// If nonces are enabled, we still walk back [ValidityWindow] so that
+		// readiness does not depend on the [StateManager].
+		if !vm.nonces {
+			vm.seen.Add(blk.Txs)
+		}
 		vm.startSeenTime = blk.Tmstmp
 		oldest = blk.Hght
 
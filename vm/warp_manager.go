@@ -14,25 +14,31 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/heap"
+	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 )
 
 const (
-	maxWarpResponse   = bls.PublicKeyLen + bls.SignatureLen
-	minGatherInterval = 30 * 60 // 30 minutes
-	initialBackoff    = 2       // give time for others to sign
-	backoffIncrease   = 5
-	maxRetries        = 10
-	maxOutstanding    = 8 // TODO: make a config
+	maxWarpResponse = bls.PublicKeyLen + bls.SignatureLen
+	initialBackoff  = 2 // give time for others to sign
+	backoffIncrease = 5
 )
 
 // WarpManager takes requests to get signatures from other nodes and then
 // stores the result in our DB for future usage.
+//
+// WarpManager gathers signatures for every outgoing warp message until
+// signatures from [GetWarpAggregationQuorum] of the stake weight of the
+// current validator set have been collected. In-progress messages are
+// persisted so that gathering resumes after a restart. Messages that don't
+// reach quorum within [GetWarpMaxPendingAge] are dropped.
 type WarpManager struct {
 	vm        *VM
 	appSender common.AppSender
@@ -43,6 +49,10 @@ type WarpManager struct {
 	pendingJobs *heap.Heap[*signatureJob, int64]
 	jobs        map[uint32]*signatureJob
 
+	// pending are the messages (by txID) that we have not gathered enough
+	// signatures for
+	pending map[ids.ID]*WarpPending
+
 	done chan struct{}
 }
 
@@ -60,6 +70,7 @@ func NewWarpManager(vm *VM) *WarpManager {
 		vm:          vm,
 		pendingJobs: heap.New[*signatureJob, int64](64, true),
 		jobs:        map[uint32]*signatureJob{},
+		pending:     map[ids.ID]*WarpPending{},
 		done:        make(chan struct{}),
 	}
 }
@@ -70,13 +81,34 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 	w.vm.Logger().Info("starting warp manager")
 	defer close(w.done)
 
+	// Wait for the VM to be ready before resuming any messages we were
+	// gathering signatures for
+	select {
+	case <-w.vm.ready:
+	case <-w.vm.stop:
+		w.vm.Logger().Info("stopping warp manager")
+		return
+	}
+	if err := w.resume(context.Background()); err != nil {
+		w.vm.snowCtx.Log.Error("unable to resume gathering signatures", zap.Error(err))
+	}
+	lastSweep := time.Now()
+
 	t := time.NewTicker(1 * time.Second)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
+			// Periodically retry gathering signatures for messages that have
+			// not reached quorum (validators may have been offline)
+			if time.Since(lastSweep) >= w.vm.config.GetWarpMinGatherInterval() {
+				w.sweep(context.Background())
+				lastSweep = time.Now()
+			}
+
 			w.l.Lock()
 			now := time.Now().Unix()
+			maxOutstanding := w.vm.config.GetWarpMaxOutstanding()
 			for w.pendingJobs.Len() > 0 && len(w.jobs) < maxOutstanding {
 				first := w.pendingJobs.First()
 				if first.Val > now {
@@ -104,6 +136,44 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 	}
 }
 
+// resume loads all messages that we were gathering signatures for before
+// shutdown and immediately resumes gathering signatures for them.
+func (w *WarpManager) resume(ctx context.Context) error {
+	pending, err := w.vm.GetWarpPending()
+	if err != nil {
+		return err
+	}
+	w.l.Lock()
+	for txID, p := range pending {
+		w.pending[txID] = p
+	}
+	w.l.Unlock()
+	for txID, p := range pending {
+		w.gather(ctx, txID, p.Msg, true)
+	}
+	w.vm.snowCtx.Log.Info("resumed gathering signatures", zap.Int("pending", len(pending)))
+	return nil
+}
+
+// sweep attempts to gather signatures for all messages that have not reached
+// quorum and drops those that have been pending for longer than
+// [GetWarpMaxPendingAge].
+func (w *WarpManager) sweep(ctx context.Context) {
+	w.l.Lock()
+	pending := maps.Clone(w.pending)
+	w.l.Unlock()
+	oldest := time.Now().Add(-w.vm.config.GetWarpMaxPendingAge()).UnixMilli()
+	for txID, p := range pending {
+		if p.Start < oldest {
+			if w.untrack(txID) {
+				w.vm.snowCtx.Log.Warn("stopped gathering signatures for warp message", zap.Stringer("txID", txID))
+			}
+			continue
+		}
+		w.gather(ctx, txID, p.Msg, false)
+	}
+}
+
 // GatherSignatures makes a best effort to acquire signatures from other
 // validators and store them inside the vmDB.
 //
@@ -112,19 +182,46 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 // abuse, we limit how frequently we attempt to gather signatures for a given
 // TxID.
 func (w *WarpManager) GatherSignatures(ctx context.Context, txID ids.ID, msg []byte) {
-	lastFetch, err := w.vm.GetWarpFetch(txID)
-	if err != nil {
-		w.vm.snowCtx.Log.Error("unable to get last fetch", zap.Error(err))
+	w.gather(ctx, txID, msg, false)
+}
+
+// gather enqueues signature requests for [msg] to all validators we don't
+// have a signature from. If [force] is false, we skip gathering if we
+// recently gathered signatures for [msg].
+//
+// If [msg] was dropped by [sweep], it is tracked again.
+func (w *WarpManager) gather(ctx context.Context, txID ids.ID, msg []byte, force bool) {
+	if !force {
+		lastFetch, err := w.vm.GetWarpFetch(txID)
+		if err != nil {
+			w.vm.snowCtx.Log.Error("unable to get last fetch", zap.Error(err))
+			return
+		}
+		if time.Now().UnixMilli()-lastFetch < w.vm.config.GetWarpMinGatherInterval().Milliseconds() {
+			w.vm.snowCtx.Log.Debug("skipping fetch too recent", zap.Stringer("txID", txID))
+			return
+		}
+	}
+	if err := w.vm.StoreWarpFetch(txID); err != nil {
+		w.vm.snowCtx.Log.Error("unable to store last fetch", zap.Error(err))
 		return
 	}
-	if time.Now().Unix()-lastFetch < minGatherInterval {
-		w.vm.snowCtx.Log.Error("skipping fetch too recent", zap.Stringer("txID", txID))
+
+	// Track [msg] until we've gathered enough signatures
+	aggregated, err := w.aggregated(ctx, txID)
+	if err != nil {
+		w.vm.snowCtx.Log.Error("unable to check signature weight", zap.Error(err))
 		return
 	}
-	if err := w.vm.StoreWarpFetch(txID); err != nil {
-		w.vm.snowCtx.Log.Error("unable to get last fetch", zap.Error(err))
+	if aggregated {
+		if w.untrack(txID) {
+			w.vm.snowCtx.Log.Info("gathered signatures for warp message", zap.Stringer("txID", txID))
+		}
+	} else if err := w.track(txID, msg); err != nil {
+		w.vm.snowCtx.Log.Error("unable to store pending message", zap.Error(err))
 		return
 	}
+
 	height, err := w.vm.snowCtx.ValidatorState.GetCurrentHeight(ctx)
 	if err != nil {
 		w.vm.snowCtx.Log.Error("unable to get current p-chain height", zap.Error(err))
@@ -191,6 +288,62 @@ func (w *WarpManager) GatherSignatures(ctx context.Context, txID ids.ID, msg []b
 	}
 }
 
+// track persists that we are gathering signatures for [msg].
+func (w *WarpManager) track(txID ids.ID, msg []byte) error {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if _, ok := w.pending[txID]; ok {
+		return nil
+	}
+	p := &WarpPending{Start: time.Now().UnixMilli(), Msg: msg}
+	if err := w.vm.StoreWarpPending(txID, p); err != nil {
+		return err
+	}
+	w.pending[txID] = p
+	return nil
+}
+
+// untrack stops tracking [txID] (returning true if it was tracked).
+func (w *WarpManager) untrack(txID ids.ID) bool {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if _, ok := w.pending[txID]; !ok {
+		return false
+	}
+	if err := w.vm.RemoveWarpPending(txID); err != nil {
+		w.vm.snowCtx.Log.Error("unable to remove pending message", zap.Error(err))
+		return false
+	}
+	delete(w.pending, txID)
+	return true
+}
+
+// aggregated returns true if signatures from at least
+// [GetWarpAggregationQuorum] of the stake weight of the current validator
+// set have been gathered for [txID].
+func (w *WarpManager) aggregated(ctx context.Context, txID ids.ID) (bool, error) {
+	validators, _, err := w.vm.CurrentValidators(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(validators) == 0 {
+		// We can't tell if we have enough signatures without a validator set
+		return false, nil
+	}
+	signatures, err := w.vm.GetWarpSignatures(txID)
+	if err != nil {
+		return false, err
+	}
+	weight, signatureWeight, err := rpc.WarpSignatureWeight(validators, signatures)
+	if err != nil {
+		return false, err
+	}
+	quorumNum, quorumDen := w.vm.config.GetWarpAggregationQuorum()
+	return warp.VerifyWeight(signatureWeight, weight, quorumNum, quorumDen) == nil, nil
+}
+
 // you must hold [w.l] when calling this function
 func (w *WarpManager) request(
 	ctx context.Context,
@@ -320,6 +473,16 @@ func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 		),
 		zap.String("publicKey", hex.EncodeToString(job.publicKey)),
 	)
+
+	// Stop tracking the message if we have enough signatures
+	aggregated, err := w.aggregated(context.TODO(), job.txID)
+	if err != nil {
+		w.vm.snowCtx.Log.Warn("could not check signature weight", zap.Error(err))
+		return nil
+	}
+	if aggregated && w.untrack(job.txID) {
+		w.vm.snowCtx.Log.Info("gathered signatures for warp message", zap.Stringer("txID", job.txID))
+	}
 	return nil
 }
 
@@ -333,7 +496,7 @@ func (w *WarpManager) HandleRequestFailed(requestID uint32) error {
 	}
 
 	// Drop if we've already retried too many times
-	if job.retry >= maxRetries {
+	if job.retry >= w.vm.config.GetWarpMaxRetries() {
 		w.vm.snowCtx.Log.Info(
 			"fetch job failed",
 			zap.Stringer("nodeID", job.nodeID),
@@ -0,0 +1,342 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	maxWarpStateReadRequest = consts.Uint64Len + consts.IntLen + chain.MaxWarpStateReadSize
+
+	// warpStateReadTimeout is the maximum amount of time we wait for other
+	// validators to sign a state read
+	warpStateReadTimeout = 5 * time.Second
+
+	// Each validator can request at most [warpStateReadRate] state reads per
+	// second (with bursts up to [warpStateReadBurst] reads)
+	warpStateReadRate  = 5
+	warpStateReadBurst = 10
+)
+
+// WarpStateReader gathers signatures from other validators over
+// [chain.WarpStateRead] messages on request (unlike [WarpManager], these
+// messages are never stored).
+type WarpStateReader struct {
+	vm        *VM
+	appSender common.AppSender
+
+	l         sync.Mutex
+	requestID uint32
+	requests  map[uint32]*stateReadRequest
+
+	// limits rate limits the requests we serve from each validator (the least
+	// recently seen validators are evicted, which only resets their limit)
+	limitsL sync.Mutex
+	limits  *cache.LRU[ids.NodeID, *stateReadLimit]
+}
+
+type stateReadLimit struct {
+	tokens     float64
+	lastRefill int64 // ms
+}
+
+type stateReadRequest struct {
+	publicKey []byte
+	msg       []byte
+
+	// signatures receives the signature of [publicKey] over [msg] (or nil,
+	// if the request failed)
+	signatures chan *chain.WarpSignature
+}
+
+func NewWarpStateReader(vm *VM, appSender common.AppSender) *WarpStateReader {
+	return &WarpStateReader{
+		vm:        vm,
+		appSender: appSender,
+		requests:  map[uint32]*stateReadRequest{},
+		limits:    &cache.LRU[ids.NodeID, *stateReadLimit]{Size: 1024},
+	}
+}
+
+// Read returns a warp message attesting to the values of [keys] in the state
+// committed to by the accepted block at [height] and the signatures over it
+// from all current validators that responded before [ctx] is done.
+func (r *WarpStateReader) Read(
+	ctx context.Context,
+	height uint64,
+	keys [][]byte,
+) (*warp.UnsignedMessage, []*chain.WarpSignature, error) {
+	msg, err := r.vm.warpStateRead(ctx, height, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := r.vm.snowCtx.WarpSigner.Sign(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	signatures := []*chain.WarpSignature{{PublicKey: r.vm.pkBytes, Signature: signature}}
+
+	// Request signatures from all other validators
+	request, err := marshalWarpStateReadRequest(height, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, warpStateReadTimeout)
+	defer cancel()
+	validators, _, err := r.vm.CurrentValidators(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	responses := make(chan *chain.WarpSignature, len(validators))
+	requestIDs := make([]uint32, 0, len(validators))
+	defer func() {
+		// Ignore any responses that arrive after we return
+		r.l.Lock()
+		for _, requestID := range requestIDs {
+			delete(r.requests, requestID)
+		}
+		r.l.Unlock()
+	}()
+	for nodeID, validator := range validators {
+		if nodeID == r.vm.snowCtx.NodeID || validator.PublicKey == nil {
+			continue
+		}
+		r.l.Lock()
+		requestID := r.requestID
+		r.requestID++
+		r.requests[requestID] = &stateReadRequest{
+			publicKey:  bls.PublicKeyToBytes(validator.PublicKey),
+			msg:        msg.Bytes(),
+			signatures: responses,
+		}
+		r.l.Unlock()
+		requestIDs = append(requestIDs, requestID)
+		if err := r.appSender.SendAppRequest(ctx, set.Of(nodeID), requestID, request); err != nil {
+			return nil, nil, err
+		}
+	}
+	for range requestIDs {
+		select {
+		case signature := <-responses:
+			if signature != nil {
+				signatures = append(signatures, signature)
+			}
+		case <-ctx.Done():
+			r.vm.snowCtx.Log.Debug("stopped waiting for state read signatures", zap.Error(ctx.Err()))
+			return msg, signatures, nil
+		}
+	}
+	return msg, signatures, nil
+}
+
+func (r *WarpStateReader) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	request []byte,
+) error {
+	// Reads are expensive, so we only serve a limited number of them to
+	// validators
+	isValidator, err := r.vm.IsValidator(ctx, nodeID)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("unable to determine if nodeID is validator", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return nil
+	}
+	if !isValidator {
+		r.vm.snowCtx.Log.Debug("dropping state read request from non-validator", zap.Stringer("nodeID", nodeID))
+		return nil
+	}
+	if !r.allow(nodeID, time.Now().UnixMilli()) {
+		r.vm.snowCtx.Log.Debug("dropping rate limited state read request", zap.Stringer("nodeID", nodeID))
+		return nil
+	}
+	height, keys, err := unmarshalWarpStateReadRequest(request)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("unable to unpack state read request", zap.Error(err))
+		return nil
+	}
+	msg, err := r.vm.warpStateRead(ctx, height, keys)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("unable to read state", zap.Uint64("height", height), zap.Error(err))
+		return nil
+	}
+	signature, err := r.vm.snowCtx.WarpSigner.Sign(msg)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("could not sign state read", zap.Error(err))
+		return nil
+	}
+	wp := codec.NewWriter(maxWarpResponse, maxWarpResponse)
+	wp.PackFixedBytes(r.vm.pkBytes)
+	wp.PackFixedBytes(signature)
+	if err := wp.Err(); err != nil {
+		r.vm.snowCtx.Log.Warn("could not encode state read signature", zap.Error(err))
+		return nil
+	}
+	return r.appSender.SendAppResponse(ctx, nodeID, requestID, wp.Bytes())
+}
+
+// allow returns true if [nodeID] can make another request at [now].
+func (r *WarpStateReader) allow(nodeID ids.NodeID, now int64) bool {
+	r.limitsL.Lock()
+	defer r.limitsL.Unlock()
+
+	limit, ok := r.limits.Get(nodeID)
+	if !ok {
+		limit = &stateReadLimit{tokens: warpStateReadBurst, lastRefill: now}
+		r.limits.Put(nodeID, limit)
+	}
+	if elapsed := now - limit.lastRefill; elapsed > 0 {
+		limit.tokens += float64(elapsed) * warpStateReadRate / 1_000
+		if limit.tokens > warpStateReadBurst {
+			limit.tokens = warpStateReadBurst
+		}
+		limit.lastRefill = now
+	}
+	if limit.tokens < 1 {
+		return false
+	}
+	limit.tokens--
+	return true
+}
+
+func (r *WarpStateReader) HandleRequestFailed(requestID uint32) error {
+	r.l.Lock()
+	req, ok := r.requests[requestID]
+	delete(r.requests, requestID)
+	r.l.Unlock()
+	if ok {
+		req.signatures <- nil
+	}
+	return nil
+}
+
+func (r *WarpStateReader) HandleResponse(requestID uint32, msg []byte) error {
+	r.l.Lock()
+	req, ok := r.requests[requestID]
+	delete(r.requests, requestID)
+	r.l.Unlock()
+	if !ok {
+		return nil
+	}
+
+	// Only return valid signatures from the expected validator
+	var signature *chain.WarpSignature
+	defer func() {
+		req.signatures <- signature
+	}()
+	p := codec.NewReader(msg, maxWarpResponse)
+	publicKey := make([]byte, bls.PublicKeyLen)
+	p.UnpackFixedBytes(bls.PublicKeyLen, &publicKey)
+	sigBytes := make([]byte, bls.SignatureLen)
+	p.UnpackFixedBytes(bls.SignatureLen, &sigBytes)
+	if err := p.Err(); err != nil {
+		r.vm.snowCtx.Log.Warn("could not decode state read signature", zap.Error(err))
+		return nil
+	}
+	if !bytes.Equal(publicKey, req.publicKey) {
+		r.vm.snowCtx.Log.Warn("public key mismatch")
+		return nil
+	}
+	pk, err := bls.PublicKeyFromBytes(publicKey)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("could not decode public key", zap.Error(err))
+		return nil
+	}
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	if err != nil {
+		r.vm.snowCtx.Log.Warn("could not decode signature", zap.Error(err))
+		return nil
+	}
+	if !bls.Verify(pk, sig, req.msg) {
+		// The validator may have read different values (or not have state
+		// at the requested height)
+		r.vm.snowCtx.Log.Warn("could not verify state read signature")
+		return nil
+	}
+	signature = &chain.WarpSignature{PublicKey: publicKey, Signature: sigBytes}
+	return nil
+}
+
+func marshalWarpStateReadRequest(height uint64, keys [][]byte) ([]byte, error) {
+	size := consts.Uint64Len + consts.IntLen
+	for _, k := range keys {
+		size += codec.BytesLen(k)
+	}
+	p := codec.NewWriter(size, maxWarpStateReadRequest)
+	p.PackUint64(height)
+	p.PackInt(len(keys))
+	for _, k := range keys {
+		p.PackBytes(k)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func unmarshalWarpStateReadRequest(request []byte) (uint64, [][]byte, error) {
+	p := codec.NewReader(request, maxWarpStateReadRequest)
+	height := p.UnpackUint64(false)
+	numKeys := p.UnpackInt(true)
+	if numKeys > chain.MaxWarpStateReadKeys {
+		return 0, nil, chain.ErrTooManyWarpStateReadKeys
+	}
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		p.UnpackBytes(chain.MaxWarpStateReadSize, true, &keys[i])
+	}
+	return height, keys, p.Err()
+}
+
+// warpStateRead returns an unsigned warp message (from this chain) attesting
+// to the values of [keys] in the state committed to by the accepted block at
+// [height].
+func (vm *VM) warpStateRead(ctx context.Context, height uint64, keys [][]byte) (*warp.UnsignedMessage, error) {
+	if len(keys) > chain.MaxWarpStateReadKeys {
+		return nil, chain.ErrTooManyWarpStateReadKeys
+	}
+	root, proofs, err := vm.GetStateProofs(ctx, height, keys)
+	if err != nil {
+		return nil, err
+	}
+	blkID, err := vm.GetBlockIDAtHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := vm.GetStatelessBlock(ctx, blkID)
+	if err != nil {
+		return nil, err
+	}
+	values := make([][]byte, len(keys))
+	for i, proof := range proofs {
+		if len(proof.KeyValues) > 0 && bytes.Equal(proof.KeyValues[0].Key, keys[i]) {
+			values[i] = proof.KeyValues[0].Value
+		}
+	}
+	read := &chain.WarpStateRead{
+		Height:    height,
+		Timestamp: blk.Tmstmp,
+		Root:      root,
+		Keys:      keys,
+		Values:    values,
+	}
+	payload, err := read.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(vm.snowCtx.NetworkID, vm.snowCtx.ChainID, payload)
+}
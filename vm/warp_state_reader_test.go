@@ -0,0 +1,29 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpStateReaderAllow(t *testing.T) {
+	require := require.New(t)
+	r := NewWarpStateReader(nil, nil)
+	a, b := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+
+	// Each validator can burst up to the limit
+	for i := 0; i < warpStateReadBurst; i++ {
+		require.True(r.allow(a, 0))
+	}
+	require.False(r.allow(a, 0))
+	require.True(r.allow(b, 0))
+
+	// Requests are allowed again at the configured rate
+	require.False(r.allow(a, 1_000/warpStateReadRate-1))
+	require.True(r.allow(a, 1_000/warpStateReadRate))
+	require.False(r.allow(a, 1_000/warpStateReadRate))
+}
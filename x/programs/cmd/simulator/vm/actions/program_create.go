@@ -48,16 +48,16 @@ func (t *ProgramCreate) Execute(
 	_ codec.Address,
 	id ids.ID,
 	_ bool,
-) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+) (bool, uint64, []byte, []*chain.Event, *warp.UnsignedMessage, error) {
 	if len(t.Program) == 0 {
-		return false, 1, OutputValueZero, nil, nil
+		return false, 1, OutputValueZero, nil, nil, nil
 	}
 
 	if err := storage.SetProgram(ctx, mu, id, t.Program); err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 
-	return true, 1, nil, nil, nil
+	return true, 1, nil, nil, nil, nil
 }
 
 func (*ProgramCreate) MaxComputeUnits(chain.Rules) uint64 {
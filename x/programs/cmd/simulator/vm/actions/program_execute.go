@@ -64,41 +64,41 @@ func (t *ProgramExecute) Execute(
 	_ codec.Address,
 	_ ids.ID,
 	_ bool,
-) (success bool, computeUnits uint64, output []byte, warpMessage *warp.UnsignedMessage, err error) {
+) (success bool, computeUnits uint64, output []byte, events []*chain.Event, warpMessage *warp.UnsignedMessage, err error) {
 	if len(t.Function) == 0 {
-		return false, 1, OutputValueZero, nil, nil
+		return false, 1, OutputValueZero, nil, nil, nil
 	}
 	if len(t.Params) == 0 {
-		return false, 1, OutputValueZero, nil, nil
+		return false, 1, OutputValueZero, nil, nil, nil
 	}
 
 	programIDStr, ok := t.Params[0].Value.(string)
 	if !ok {
-		return false, 1, utils.ErrBytes(fmt.Errorf("invalid call param: must be ID")), nil, nil
+		return false, 1, utils.ErrBytes(fmt.Errorf("invalid call param: must be ID")), nil, nil, nil
 	}
 
 	// TODO: take fee out of balance?
 	programID, err := ids.FromString(programIDStr)
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 	t.Params[0].Value = programID
 	programBytes, err := storage.GetProgram(ctx, mu, programID)
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 
 	// TODO: get cfg from genesis
 	cfg := runtime.NewConfig()
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 
 	ecfg, err := engine.NewConfigBuilder().
 		WithDefaultCache(true).
 		Build()
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 	eng := engine.New(ecfg)
 
@@ -115,22 +115,22 @@ func (t *ProgramExecute) Execute(
 	t.rt = runtime.New(logging.NoLog{}, eng, imports, cfg)
 	err = t.rt.Initialize(ctx, programBytes, t.MaxUnits)
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 	defer t.rt.Stop()
 
 	mem, err := t.rt.Memory()
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 	params, err := WriteParams(mem, t.Params)
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 
 	resp, err := t.rt.Call(ctx, t.Function, params...)
 	if err != nil {
-		return false, 1, utils.ErrBytes(err), nil, nil
+		return false, 1, utils.ErrBytes(err), nil, nil, nil
 	}
 
 	// TODO: remove this is to support readonly response for now.
@@ -139,7 +139,7 @@ func (t *ProgramExecute) Execute(
 		p.PackInt64(r)
 	}
 
-	return true, 1, p.Bytes(), nil, nil
+	return true, 1, p.Bytes(), nil, nil, nil
 }
 
 func (*ProgramExecute) MaxComputeUnits(chain.Rules) uint64 {
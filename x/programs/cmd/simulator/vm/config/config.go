@@ -46,6 +46,7 @@ type Config struct {
 	MempoolSize           int      `json:"mempoolSize"`
 	MempoolSponsorSize    int      `json:"mempoolSponsorSize"`
 	MempoolExemptSponsors []string `json:"mempoolExemptSponsors"`
+	MempoolFeeOrdering    bool     `json:"mempoolFeeOrdering"`
 
 	// Misc
 	VerifyAuth        bool          `json:"verifyAuth"`
@@ -88,6 +89,7 @@ func (c *Config) setDefault() {
 	c.LogLevel = c.Config.GetLogLevel()
 	c.MempoolSize = c.Config.GetMempoolSize()
 	c.MempoolSponsorSize = c.Config.GetMempoolSponsorSize()
+	c.MempoolFeeOrdering = c.Config.GetMempoolFeeOrdering()
 	c.StateSyncServerDelay = c.Config.GetStateSyncServerDelay()
 	c.StreamingBacklogSize = c.Config.GetStreamingBacklogSize()
 	c.VerifyAuth = c.Config.GetVerifyAuth()
@@ -99,6 +101,7 @@ func (c *Config) GetTestMode() bool                         { return c.TestMode
 func (c *Config) GetMempoolSize() int                       { return c.MempoolSize }
 func (c *Config) GetMempoolSponsorSize() int                { return c.MempoolSponsorSize }
 func (c *Config) GetMempoolExemptSponsors() []codec.Address { return c.parsedExemptSponsors }
+func (c *Config) GetMempoolFeeOrdering() bool               { return c.MempoolFeeOrdering }
 func (c *Config) GetTraceConfig() *trace.Config {
 	return &trace.Config{
 		Enabled:         c.TraceEnabled,
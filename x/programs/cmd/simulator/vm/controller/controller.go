@@ -119,14 +119,14 @@ func (c *Controller) Initialize(
 	var (
 		build  builder.Builder
 		gossip gossiper.Gossiper
+		gcfg   = gossiper.DefaultProposerConfig()
 	)
 	if c.config.TestMode {
 		c.inner.Logger().Info("running build and gossip in test mode")
 		build = builder.NewManual(inner)
-		gossip = gossiper.NewManual(inner)
+		gossip = gossiper.NewManual(inner, gcfg)
 	} else {
 		build = builder.NewTime(inner)
-		gcfg := gossiper.DefaultProposerConfig()
 		gossip, err = gossiper.NewProposer(inner, gcfg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
@@ -8,4 +8,6 @@ import "errors"
 var (
 	ErrInvalidHRP    = errors.New("invalid HRP")
 	ErrInvalidTarget = errors.New("invalid target")
+
+	ErrInvalidMaxActionsPerTx = errors.New("maxActionsPerTx must be non-zero")
 )
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/upgrade"
 	"github.com/ava-labs/hypersdk/vm"
 
 	"github.com/ava-labs/hypersdk/x/programs/cmd/simulator/vm/consts"
@@ -44,7 +45,8 @@ type Genesis struct {
 	MaxBlockUnits              chain.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
-	ValidityWindow int64 `json:"validityWindow"` // ms
+	ValidityWindow  int64 `json:"validityWindow"` // ms
+	MaxActionsPerTx uint8 `json:"maxActionsPerTx"`
 
 	// Tx Fee Parameters
 	BaseComputeUnits          uint64 `json:"baseUnits"`
@@ -64,6 +66,35 @@ type Genesis struct {
 
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
+
+	// Upgrades (parsed from upgradeBytes)
+	upgrades upgrade.Schedule
+	forks    []*Genesis // params of each upgrade
+}
+
+// upgradableParams are the parameters (by their JSON key) that can be changed
+// by an upgrade. Parameters that are committed to by state (the state branch
+// factor and the HRP), that bound replay protection (the validity window), or
+// that change how programs are executed (the runtime parameters) can't be
+// changed.
+var upgradableParams = []string{
+	"minBlockGap",
+	"minEmptyBlockGap",
+	"minUnitPrice",
+	"unitPriceChangeDenominator",
+	"windowTargetUnits",
+	"maxBlockUnits",
+	"maxActionsPerTx",
+	"baseUnits",
+	"baseWarpUnits",
+	"warpUnitsPerSigner",
+	"outgoingWarpComputeUnits",
+	"storageKeyReadUnits",
+	"storageValueReadUnits",
+	"storageKeyAllocateUnits",
+	"storageValueAllocateUnits",
+	"storageKeyWriteUnits",
+	"storageValueWriteUnits",
 }
 
 func Default() *Genesis {
@@ -100,7 +131,8 @@ func Default() *Genesis {
 		StorageValueWriteUnits:    3,
 
 		// Tx Parameters
-		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
+		ValidityWindow:  60 * hconsts.MillisecondsPerSecond, // ms
+		MaxActionsPerTx: 16,
 
 		// program Runtime Parameters
 		EnableDebugMode: true,
@@ -109,16 +141,50 @@ func Default() *Genesis {
 	}
 }
 
-func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+func New(b []byte, upgradeBytes []byte) (*Genesis, error) {
 	g := Default()
 	if len(b) > 0 {
 		if err := json.Unmarshal(b, g); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
+	if g.MaxActionsPerTx == 0 {
+		return nil, ErrInvalidMaxActionsPerTx
+	}
+	upgrades, err := upgrade.Parse(upgradeBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.SetUpgrades(upgrades); err != nil {
+		return nil, err
+	}
 	return g, nil
 }
 
+// SetUpgrades schedules [upgrades], which may only override the
+// [upgradableParams] of [g].
+func (g *Genesis) SetUpgrades(upgrades upgrade.Schedule) error {
+	if err := upgrades.Verify(); err != nil {
+		return err
+	}
+	forks, err := upgrade.Apply(upgrades, g, upgradableParams)
+	if err != nil {
+		return err
+	}
+	for i, fork := range forks {
+		if fork.MaxActionsPerTx == 0 {
+			return fmt.Errorf("%w: in %s", ErrInvalidMaxActionsPerTx, upgrades[i].Name)
+		}
+	}
+	g.upgrades = upgrades
+	g.forks = forks
+	return nil
+}
+
+func (g *Genesis) Upgrades() upgrade.Schedule {
+	return g.upgrades
+}
+
 func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutable) error {
 	if consts.HRP != g.HRP {
 		return ErrInvalidHRP
@@ -6,20 +6,27 @@ package genesis
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/upgrade"
 )
 
 var _ chain.Rules = (*Rules)(nil)
 
 type Rules struct {
-	g *Genesis
+	g        *Genesis // params of the active upgrade
+	upgrades upgrade.Schedule
 
 	networkID uint32
 	chainID   ids.ID
 }
 
-// TODO: use upgradeBytes
-func (g *Genesis) Rules(_ int64, networkID uint32, chainID ids.ID) *Rules {
-	return &Rules{g, networkID, chainID}
+// Rules returns the [Rules] of the most recent upgrade active at [t] (or of
+// genesis, if none are active).
+func (g *Genesis) Rules(t int64, networkID uint32, chainID ids.ID) *Rules {
+	params := g
+	if i := g.upgrades.Active(t); i >= 0 {
+		params = g.forks[i]
+	}
+	return &Rules{params, g.upgrades, networkID, chainID}
 }
 
 func (r *Rules) GetSponsorStateKeysMaxChunks() []uint16 {
@@ -38,6 +45,10 @@ func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
 
+func (r *Rules) GetMaxActionsPerTx() uint8 {
+	return r.g.MaxActionsPerTx
+}
+
 func (r *Rules) GetMinUnitPrice() chain.Dimensions {
 	return r.g.MinUnitPrice
 }
@@ -106,6 +117,10 @@ func (r *Rules) ChainID() ids.ID {
 	return r.chainID
 }
 
+func (r *Rules) GetUpgradeTimestamp(name string) (int64, bool) {
+	return r.upgrades.Timestamp(name)
+}
+
 func (*Rules) FetchCustom(string) (any, bool) {
 	return nil, false
 }
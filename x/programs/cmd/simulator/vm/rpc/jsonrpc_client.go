@@ -50,6 +50,9 @@ func (cli *JSONRPCClient) Genesis(ctx context.Context) (*genesis.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := resp.Genesis.SetUpgrades(resp.Upgrades); err != nil {
+		return nil, err
+	}
 	cli.g = resp.Genesis
 	return resp.Genesis, nil
 }
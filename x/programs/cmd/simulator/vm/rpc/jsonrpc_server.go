@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/upgrade"
 
 	"github.com/ava-labs/hypersdk/x/programs/cmd/simulator/vm/genesis"
 )
@@ -22,11 +23,13 @@ func NewJSONRPCServer(c Controller) *JSONRPCServer {
 }
 
 type GenesisReply struct {
-	Genesis *genesis.Genesis `json:"genesis"`
+	Genesis  *genesis.Genesis `json:"genesis"`
+	Upgrades upgrade.Schedule `json:"upgrades"`
 }
 
 func (j *JSONRPCServer) Genesis(_ *http.Request, _ *struct{}, reply *GenesisReply) (err error) {
 	reply.Genesis = j.c.Genesis()
+	reply.Upgrades = reply.Genesis.Upgrades()
 	return nil
 }
 